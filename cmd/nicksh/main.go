@@ -4,14 +4,29 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/AntonioJCosta/nicksh/internal/adapters/aliasfile"
 	"github.com/AntonioJCosta/nicksh/internal/adapters/aliasgeneration"
 	"github.com/AntonioJCosta/nicksh/internal/adapters/commandanalysis"
-	"github.com/AntonioJCosta/nicksh/internal/adapters/oscommand"
+	"github.com/AntonioJCosta/nicksh/internal/adapters/commandresolution"
+	"github.com/AntonioJCosta/nicksh/internal/adapters/logging"
+	"github.com/AntonioJCosta/nicksh/internal/adapters/namingconventions"
 	"github.com/AntonioJCosta/nicksh/internal/adapters/predefinedaliases"
+	"github.com/AntonioJCosta/nicksh/internal/adapters/registry"
+	"github.com/AntonioJCosta/nicksh/internal/adapters/secretdetection"
+	"github.com/AntonioJCosta/nicksh/internal/adapters/shellenvironment"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/AntonioJCosta/nicksh/internal/core/services/aliasmanagement"
+	"github.com/AntonioJCosta/nicksh/internal/core/services/aliasresolution"
 	"github.com/AntonioJCosta/nicksh/internal/core/services/aliassuggestion"
+	"github.com/AntonioJCosta/nicksh/internal/core/services/aliassync"
+	"github.com/AntonioJCosta/nicksh/internal/core/services/aliastransfer"
+	"github.com/AntonioJCosta/nicksh/internal/core/services/diagnostics"
+	"github.com/AntonioJCosta/nicksh/internal/core/services/historystats"
+	"github.com/AntonioJCosta/nicksh/internal/core/services/projectaliases"
 	"github.com/AntonioJCosta/nicksh/internal/handlers/cli"
+	"github.com/AntonioJCosta/nicksh/internal/repositories/gitsync"
 	"github.com/AntonioJCosta/nicksh/internal/repositories/history"
+	"github.com/AntonioJCosta/nicksh/internal/repositories/projectconfig"
 	"github.com/AntonioJCosta/nicksh/internal/repositories/shellconfig"
 )
 
@@ -19,19 +34,47 @@ import (
 var Version = "dev"
 
 func main() {
-	cmdExec := oscommand.NewOSCommandExecutor()
+	// Loggers are handed out now, and set to the verbosity of the command line's flags once it is parsed.
+	// What is logged until then, like the warnings below, is held and logged with that verbosity.
+	logs := logging.NewLogging(os.Stderr)
+	logger := logs.Logger()
 
+	// Broken exclusions only fail the commands that read history; the others, like
+	// 'project env' run on every cd, keep working.
+	historyExclusions, err := history.LoadHistoryExclusions()
+	if err != nil {
+		logger.Warn("could not load history exclusions", "error", err)
+	}
 	historyFileFinder := history.NewDefaultHistoryFileFinder()
-	historyRepo, err := history.NewHistoryProvider(cmdExec, historyFileFinder)
+	historyFileRepo, err := history.NewHistoryProvider(historyFileFinder, historyExclusions, logger)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing history provider: %v\n", err)
 		os.Exit(1)
 	}
+	// The backend (history file, atuin, zsh-histdb, mcfly) is selected by the CLI's --history-backend flag,
+	// and the history files it merges by the --history flag.
+	historyRepo := history.NewHistoryBackendSelector(historyFileRepo, historyExclusions, logger)
 
 	cmdAnalyzer := commandanalysis.NewBasicAnalyzer()
-	aliasGen := aliasgeneration.NewAliasGenerator(cmdAnalyzer)
+	namingConventions, err := namingconventions.NewYAMLProvider()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing naming conventions: %v\n", err)
+		os.Exit(1)
+	}
+	secretDetector := secretdetection.NewPatternDetector()
+	aliasGen := aliasgeneration.NewAliasGenerator(cmdAnalyzer, commandresolution.NewPathResolver(), namingConventions, secretDetector, logger)
 
-	shellConf, err := shellconfig.NewShellConfigAccessor()
+	// Without an environment, 'nicksh doctor' reports that it is not available and the aliases
+	// of the shell startup files are not read; other commands still work.
+	shellEnv, err := shellenvironment.NewOSEnvironment()
+	if err != nil {
+		logger.Warn(fmt.Sprintf("could not inspect the shell environment %v.", err))
+		shellEnv = nil
+	}
+	aliasValidator := aliasresolution.NewValidator(commandresolution.NewPathResolver())
+	// The shell whose syntax aliases are written in is selected by the CLI's --dialect flag,
+	// and how they are written (aliases, or fish abbreviations) by its --style flag.
+	shellConf, err := shellconfig.NewShellConfigAccessor(secretDetector, aliasValidator, shellEnv, logger)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing shell config accessor: %v\n", err)
 		os.Exit(1)
@@ -41,16 +84,45 @@ func main() {
 	predefinedAliasProvider, err := predefinedaliases.NewYAMLProvider()
 	if err != nil {
 		// The service will handle a nil predefinedAliasProvider.
-		fmt.Fprintf(os.Stderr, "Warning: Could not initialize predefined alias provider %v. Continuing without predefined aliases.\n", err)
+		logger.Warn(fmt.Sprintf("could not initialize predefined alias provider %v. Continuing without predefined aliases.", err))
 		predefinedAliasProvider = nil // Explicitly set to nil on error
 	}
+	// A team registry, if configured, comes first: its aliases win over the embedded ones.
+	if registryURL := os.Getenv("NICKSH_REGISTRY_URL"); registryURL != "" {
+		registryProvider, err := registry.NewHTTPProvider(registryURL, logger)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("could not initialize alias registry %v. Continuing without it.", err))
+		} else if predefinedAliasProvider != nil {
+			predefinedAliasProvider = predefinedaliases.NewMultiProvider(logger, registryProvider, predefinedAliasProvider)
+		} else {
+			predefinedAliasProvider = registryProvider
+		}
+	}
 	// --- End Predefined Aliases Setup ---
 
-	aliasSuggestionSvc := aliassuggestion.NewService(historyRepo, aliasGen, shellConf, predefinedAliasProvider) // Pass provider (can be nil)
-	aliasManagementSvc := aliasmanagement.NewService(shellConf)
-	rootCmd := cli.NewRootCommand(Version, aliasSuggestionSvc, aliasManagementSvc)
+	aliasSuggestionSvc := aliassuggestion.NewService(historyRepo, aliasGen, shellConf, predefinedAliasProvider, logger) // Pass provider (can be nil)
+	aliasManagementSvc := aliasmanagement.NewService(shellConf, aliasValidator, logger)
+	projectAliasSvc := projectaliases.NewService(projectconfig.NewProjectAliasProvider(logger), projectconfig.NewProjectAllowList(logger), aliasGen, logger)
+	historyStatsSvc := historystats.NewService(historyRepo, cmdAnalyzer, aliasGen, shellConf, logger)
+	aliasCodec := aliasfile.NewCodec()
+	aliasTransferSvc := aliastransfer.NewService(shellConf, aliasCodec, aliasGen, logger)
+	// The git working copy to sync with is given by the CLI's --dir flag.
+	aliasSyncSvc := aliassync.NewService(shellConf, func(dir string) (ports.AliasSyncStore, error) {
+		return gitsync.NewGitAliasStore(dir, aliasCodec, logger)
+	}, logger)
+	registryServer := registry.NewServer(aliasCodec, logger)
 
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	aliasResolutionSvc := aliasresolution.NewService(shellConf, commandresolution.NewPathResolver(), logger)
+	var diagnosticSvc ports.DiagnosticService
+	if shellEnv != nil {
+		diagnosticSvc = diagnostics.NewService(historyFileFinder, historyRepo, shellConf, shellEnv, logger)
+	}
+	rootCmd := cli.NewRootCommand(Version, aliasSuggestionSvc, aliasManagementSvc, projectAliasSvc, aliasTransferSvc, aliasSyncSvc, registryServer, diagnosticSvc, historyStatsSvc, aliasResolutionSvc, historyRepo, historyRepo, shellConf, shellConf, namingConventions, logs)
+
+	err = rootCmd.Execute()
+	// The logging is not configured if the command line could not be parsed.
+	logs.Flush()
+	if err != nil {
+		os.Exit(cli.ReportError(err))
 	}
 }
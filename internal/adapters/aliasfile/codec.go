@@ -0,0 +1,84 @@
+package aliasfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"gopkg.in/yaml.v3"
+)
+
+// Codec implements the ports.AliasFileCodec interface with the YAML and JSON encodings
+// of alias.Alias, which share the schema of predefined_aliases.yaml.
+type Codec struct{}
+
+// NewCodec creates a new Codec.
+func NewCodec() ports.AliasFileCodec {
+	return &Codec{}
+}
+
+// Encode implements the ports.AliasFileCodec interface.
+func (c *Codec) Encode(aliases []alias.Alias, format string) ([]byte, error) {
+	if aliases == nil {
+		aliases = []alias.Alias{} // Encode an empty list rather than null.
+	}
+	switch format {
+	case ports.AliasFileFormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(aliases); err != nil {
+			return nil, fmt.Errorf("failed to encode aliases as YAML: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode aliases as YAML: %w", err)
+		}
+		return buf.Bytes(), nil
+	case ports.AliasFileFormatJSON:
+		data, err := json.MarshalIndent(aliases, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode aliases as JSON: %w", err)
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, unknownFormatError(format)
+	}
+}
+
+// Decode implements the ports.AliasFileCodec interface.
+// An empty document decodes to no aliases.
+func (c *Codec) Decode(data []byte, format string) ([]alias.Alias, error) {
+	aliases := []alias.Alias{}
+	switch format {
+	case ports.AliasFileFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&aliases); err != nil {
+			if errors.Is(err, io.EOF) {
+				return []alias.Alias{}, nil
+			}
+			return nil, fmt.Errorf("failed to decode YAML aliases: %w", err)
+		}
+	case ports.AliasFileFormatJSON:
+		if len(bytes.TrimSpace(data)) == 0 {
+			return aliases, nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&aliases); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON aliases: %w", err)
+		}
+	default:
+		return nil, unknownFormatError(format)
+	}
+	return aliases, nil
+}
+
+// unknownFormatError reports an unsupported alias file format.
+func unknownFormatError(format string) error {
+	return fmt.Errorf("unknown alias file format '%s': use %s or %s", format, ports.AliasFileFormatYAML, ports.AliasFileFormatJSON)
+}
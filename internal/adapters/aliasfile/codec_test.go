@@ -0,0 +1,96 @@
+package aliasfile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+func TestCodec_EncodeDecode(t *testing.T) {
+	aliases := []alias.Alias{
+		{Name: "gs", Command: "git status", Group: "git"},
+		{Name: "ll", Command: "ls -l", Frequency: 12, Source: alias.SourceExactCommand}, // Suggestion details are not persisted.
+	}
+	want := []alias.Alias{
+		{Name: "gs", Command: "git status", Group: "git"},
+		{Name: "ll", Command: "ls -l"},
+	}
+
+	tests := []struct {
+		format      string
+		wantEncoded string
+	}{
+		{
+			format:      ports.AliasFileFormatYAML,
+			wantEncoded: "- command: git status\n  alias: gs\n  group: git\n- command: ls -l\n  alias: ll\n",
+		},
+		{
+			format:      ports.AliasFileFormatJSON,
+			wantEncoded: "[\n  {\n    \"command\": \"git status\",\n    \"alias\": \"gs\",\n    \"group\": \"git\"\n  },\n  {\n    \"command\": \"ls -l\",\n    \"alias\": \"ll\"\n  }\n]\n",
+		},
+	}
+
+	codec := NewCodec()
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			encoded, err := codec.Encode(aliases, tt.format)
+			if err != nil {
+				t.Fatalf("Encode() unexpected error: %v", err)
+			}
+			if string(encoded) != tt.wantEncoded {
+				t.Errorf("Encode() = %q, want %q", encoded, tt.wantEncoded)
+			}
+			decoded, err := codec.Decode(encoded, tt.format)
+			if err != nil {
+				t.Fatalf("Decode() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, want) {
+				t.Errorf("Decode() = %+v, want %+v", decoded, want)
+			}
+		})
+	}
+}
+
+func TestCodec_Decode(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		format          string
+		want            []alias.Alias
+		wantErrContains string
+	}{
+		{name: "empty YAML", data: "# nothing yet\n", format: ports.AliasFileFormatYAML, want: []alias.Alias{}},
+		{name: "empty JSON", data: " \n", format: ports.AliasFileFormatJSON, want: []alias.Alias{}},
+		{
+			name:   "predefined aliases schema",
+			data:   "- command: git\n  alias: g\n- command: kubectl\n  alias: k\n",
+			format: ports.AliasFileFormatYAML,
+			want:   []alias.Alias{{Name: "g", Command: "git"}, {Name: "k", Command: "kubectl"}},
+		},
+		{name: "unknown YAML field", data: "- name: g\n  command: git\n", format: ports.AliasFileFormatYAML, wantErrContains: "failed to decode YAML aliases"},
+		{name: "unknown JSON field", data: `[{"name": "g", "command": "git"}]`, format: ports.AliasFileFormatJSON, wantErrContains: "failed to decode JSON aliases"},
+		{name: "unknown format", data: "[]", format: "toml", wantErrContains: "unknown alias file format 'toml'"},
+	}
+
+	codec := NewCodec()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := codec.Decode([]byte(tt.data), tt.format)
+			if tt.wantErrContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+					t.Fatalf("Decode() error = %v, want error containing %q", err, tt.wantErrContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
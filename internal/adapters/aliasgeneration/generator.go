@@ -11,12 +11,35 @@ import (
 
 // AliasGenerator generates alias suggestions based on command history.
 type AliasGenerator struct {
-	analyzer ports.CommandAnalyzer
+	analyzer    ports.CommandAnalyzer
+	resolver    ports.CommandResolver          // Can be nil, in which case commands are not checked for typos.
+	conventions ports.NamingConventionProvider // Can be nil, in which case names are always made of initials.
+	secrets     ports.SecretDetector           // Can be nil, in which case commands are not checked for secrets.
+	logger      ports.Logger                   // Can be nil, in which case nothing is logged.
+}
+
+// log returns the generator's logger, or ports.NopLogger if it has none.
+func (g *AliasGenerator) log() ports.Logger {
+	if g.logger == nil {
+		return ports.NopLogger
+	}
+	return g.logger
 }
 
 // NewAliasGenerator creates a new AliasGenerator.
-func NewAliasGenerator(analyzer ports.CommandAnalyzer) ports.AliasGenerator {
-	return &AliasGenerator{analyzer: analyzer}
+// resolver is used to leave out likely typos (e.g. "gti status") and can be nil.
+// conventions supplies well-known names (e.g. "gst" for "git status") that are preferred
+// over generated initials, and can be nil.
+// secrets is used to redact and flag suggestions whose command contains a secret, and can be nil.
+// logger receives why candidate names and commands were left out, and can be nil.
+func NewAliasGenerator(
+	analyzer ports.CommandAnalyzer,
+	resolver ports.CommandResolver,
+	conventions ports.NamingConventionProvider,
+	secrets ports.SecretDetector,
+	logger ports.Logger,
+) ports.AliasGenerator {
+	return &AliasGenerator{analyzer: analyzer, resolver: resolver, conventions: conventions, secrets: secrets, logger: logger}
 }
 
 // GenerateSuggestions creates alias suggestions from command frequencies using multiple strategies.
@@ -28,61 +51,111 @@ func (g *AliasGenerator) GenerateSuggestions(
 	allSuggestions := []alias.Alias{}
 	// Tracks names generated in this run to avoid duplicates from different strategies.
 	generatedNamesInThisRun := make(map[string]bool)
+	// Tracks commands suggested in this run, so a command is not suggested twice under different names.
+	suggestedCommandsInThisRun := make(map[string]bool)
 	// Minimum effective length (non-space characters) for a command to be considered by some strategies.
 	const minCommandEffectiveLength = 4
 
+	// Commands whose name cannot be resolved are likely typos (e.g. "gti status");
+	// shortcuts are only generated for the commands that can actually be run.
+	commands, misspelledCommands := g.partitionByResolvability(commands, existingAliases)
+
+	// Well-known names for commands, tried before the generated initials.
+	conventionalNames := g.loadConventionalNames()
+
 	// Strategy 1: Aliases for "command + first non-flag argument" patterns (e.g., "git pull" -> "gp").
-	cmdFirstArgFreq, cmdFirstArgToAnalyzedCmd := g.aggregateForCommandFirstArgStrategy(
+	cmdFirstArgFreq, cmdFirstArgToAnalyzedCmd, cmdFirstArgExamples := g.aggregateForCommandFirstArgStrategy(
 		commands,
 		minCommandEffectiveLength,
 	)
 	strategy1Suggestions := g.generateAliasesFromCommandFirstArgAggregation(
 		cmdFirstArgFreq,
 		cmdFirstArgToAnalyzedCmd,
+		cmdFirstArgExamples,
+		conventionalNames,
 		minFrequency,
 		existingAliases,
 		generatedNamesInThisRun,
+		suggestedCommandsInThisRun,
 	)
 	allSuggestions = append(allSuggestions, strategy1Suggestions...)
 
 	// Strategy 2: Aliases for exact full command strings (e.g., "git commit -m 'feat: initial'" -> "gcm").
 	strategy2Suggestions := g.generateExactFullCommandAliasesStrategy(
 		commands,
+		conventionalNames,
 		minFrequency,
 		existingAliases,
 		generatedNamesInThisRun,
+		suggestedCommandsInThisRun,
 		minCommandEffectiveLength,
 	)
 	allSuggestions = append(allSuggestions, strategy2Suggestions...)
 
+	// Strategy 3: Corrections for repeatedly misspelled command names (e.g., "gti" -> "git").
+	// These are marked with IsCorrection so they can be shown apart from shortcuts.
+	strategy3Suggestions := g.generateTypoCorrectionAliasesStrategy(
+		misspelledCommands,
+		commands,
+		minFrequency,
+		existingAliases,
+		generatedNamesInThisRun,
+	)
+	allSuggestions = append(allSuggestions, strategy3Suggestions...)
+
+	// Strategy 4: Global aliases for repeated pipeline tails (e.g., "| grep -i" -> "GI").
+	// Only zsh supports them: the caller leaves them out for other shells.
+	strategy4Suggestions := g.generatePipelineTailAliasesStrategy(
+		commands,
+		minFrequency,
+		existingAliases,
+		generatedNamesInThisRun,
+		minCommandEffectiveLength,
+	)
+	allSuggestions = append(allSuggestions, strategy4Suggestions...)
+
 	// Future strategies could be added here.
-	// e.g., common misspellings, command-only aliases for long commands.
+	// e.g., command-only aliases for long commands.
+
+	// Secrets (tokens, passwords, ...) must never reach an alias file: suggestions
+	// containing one are redacted and flagged, so they can be reported but not added.
+	g.redactSecrets(allSuggestions)
 
 	return allSuggestions
 }
 
-// validAliasCharsRegexGenerator ensures generated alias names are alphanumeric.
-var validAliasCharsRegexGenerator = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+// validAliasCharsRegexGenerator ensures alias names are alphanumeric, allowing dots after
+// the first character like the generated names do (e.g. "ga.").
+var validAliasCharsRegexGenerator = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.]*$`)
 
 // IsValidAliasName checks if a given name is suitable for use as an alias.
 // It verifies length, character set, and conflicts with existing aliases or system commands.
 func (g *AliasGenerator) IsValidAliasName(nameToCheck string, existingAliases map[string]string) bool {
+	if reason := aliasNameRejection(nameToCheck, existingAliases); reason != "" {
+		g.log().Debug("rejected alias name", "name", nameToCheck, "reason", reason)
+		return false
+	}
+	return true
+}
+
+// aliasNameRejection returns why nameToCheck cannot be used as an alias, or "" if it can.
+func aliasNameRejection(nameToCheck string, existingAliases map[string]string) string {
 	// Rule: Alias must be at least 1 character long.
 	if len(nameToCheck) < 1 { // Consider making this minimum length configurable.
-		return false
+		return "empty name"
 	}
-	// Rule: Alias must only contain alphanumeric characters.
+	// Rule: Alias must only contain alphanumeric characters and dots.
 	if !validAliasCharsRegexGenerator.MatchString(nameToCheck) {
-		return false
+		return "not only letters, digits and dots"
 	}
 	// Rule: Alias must not conflict with existing aliases.
 	if _, exists := existingAliases[nameToCheck]; exists {
-		return false
+		return "already an alias"
 	}
 	// Rule: Alias must not conflict with system commands.
-	if _, err := exec.LookPath(nameToCheck); err == nil {
+	if path, err := exec.LookPath(nameToCheck); err == nil {
 		// Name corresponds to an executable in PATH, so it's a conflict.
-		return false
+		return "shadows the command " + path
 	}
-	return true
+	return ""
 }
@@ -2,6 +2,7 @@ package aliasgeneration
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
@@ -44,24 +45,41 @@ func (g *AliasGenerator) isProposedNameValid(
 	existingAliases map[string]string,
 	generatedNamesInThisRun map[string]bool,
 ) bool {
+	if reason := proposedNameRejection(proposedName, originalCommandName, existingAliases, generatedNamesInThisRun); reason != "" {
+		g.log().Debug("rejected candidate alias name", "name", proposedName, "command", originalCommandName, "reason", reason)
+		return false
+	}
+	return true
+}
+
+// proposedNameRejection returns why isProposedNameValid rejects proposedName, or "" if it does not.
+func proposedNameRejection(
+	proposedName string,
+	originalCommandName string,
+	existingAliases map[string]string,
+	generatedNamesInThisRun map[string]bool,
+) string {
 	// Rule: Alias must be at least 2 characters long.
 	if len(proposedName) < 2 {
-		return false
+		return "shorter than 2 characters"
 	}
 	// Rule: Alias must only contain alphanumeric characters.
 	if !validAliasCharsRegex.MatchString(proposedName) {
-		return false
+		return "not only letters, digits and dots"
 	}
 	// Rule: Alias should not be the same as the original command name.
 	if proposedName == originalCommandName {
-		return false
+		return "same as the command name"
 	}
 	// Rule: Alias must not have been generated already in the current suggestion run.
 	if _, exists := generatedNamesInThisRun[proposedName]; exists {
-		return false
+		return "already suggested for another command"
 	}
 	// Rule: Alias must not conflict with existing aliases (checked by local helper).
-	return isAliasNameValid(proposedName, existingAliases)
+	if !isAliasNameValid(proposedName, existingAliases) {
+		return "already an alias"
+	}
+	return ""
 }
 
 /*
@@ -70,16 +88,25 @@ For example, "git pull" might become "gp".
 It prioritizes common patterns like "cd .." -> "up".
 */
 func (g *AliasGenerator) generateCommandSubcommandAliasName(analyzedCmd command.AnalyzedCommand) string {
+	name, _ := g.commandSubcommandInitials(analyzedCmd)
+	return name
+}
+
+// commandSubcommandInitials builds the name returned by generateCommandSubcommandAliasName.
+// It also returns the word the last initial of the name was taken from, or "" if the
+// name is not made of initials (e.g. "up").
+func (g *AliasGenerator) commandSubcommandInitials(analyzedCmd command.AnalyzedCommand) (string, string) {
 	if analyzedCmd.CommandName == "" {
-		return ""
+		return "", ""
 	}
 
 	if analyzedCmd.CommandName == "cd" && len(analyzedCmd.PotentialArgs) == 1 && analyzedCmd.PotentialArgs[0] == ".." {
-		return "up"
+		return "up", ""
 	}
 
 	cmdNameLower := strings.ToLower(analyzedCmd.CommandName)
 	aliasInitial := string(cmdNameLower[0])
+	lastWord := ""
 
 	if len(analyzedCmd.PotentialArgs) > 0 && len(analyzedCmd.PotentialArgs[0]) > 0 {
 		firstArg := analyzedCmd.PotentialArgs[0]
@@ -88,11 +115,13 @@ func (g *AliasGenerator) generateCommandSubcommandAliasName(analyzedCmd command.
 			lastPart := parts[len(parts)-1]
 			if lastPart != "" && !strings.HasPrefix(lastPart, ".") {
 				aliasInitial += string(strings.ToLower(lastPart)[0])
+				lastWord = lastPart
 			} else if len(firstArg) > 0 {
 				aliasInitial += string(strings.ToLower(firstArg)[0])
 			}
 		} else if !strings.HasPrefix(firstArg, "-") { // Regular argument
 			aliasInitial += string(strings.ToLower(firstArg)[0])
+			lastWord = firstArg
 		} else if len(cmdNameLower) > 1 { // Fallback for flags or if arg logic didn't add anything
 			aliasInitial = cmdNameLower[:2]
 		}
@@ -101,7 +130,7 @@ func (g *AliasGenerator) generateCommandSubcommandAliasName(analyzedCmd command.
 	} else if len(cmdNameLower) > 1 {
 		aliasInitial = cmdNameLower[:2]
 	}
-	return aliasInitial
+	return aliasInitial, lastWord
 }
 
 /*
@@ -111,22 +140,31 @@ Handles common patterns like "cd .." -> "up".
 For complex commands, it might generate a simpler alias based on the command name.
 */
 func (g *AliasGenerator) generateExactCommandAliasName(analyzedCmd command.AnalyzedCommand) string {
+	name, _ := g.exactCommandInitials(analyzedCmd)
+	return name
+}
+
+// exactCommandInitials builds the name returned by generateExactCommandAliasName.
+// It also returns the word the last initial of the name was taken from, or "" if the
+// name does not end with the initial of an argument.
+func (g *AliasGenerator) exactCommandInitials(analyzedCmd command.AnalyzedCommand) (string, string) {
 	if analyzedCmd.CommandName == "" {
-		return ""
+		return "", ""
 	}
 
 	if analyzedCmd.CommandName == "cd" && len(analyzedCmd.PotentialArgs) == 1 && analyzedCmd.PotentialArgs[0] == ".." {
-		return "up"
+		return "up", ""
 	}
 
 	if analyzedCmd.IsComplex {
 		if len(analyzedCmd.CommandName) >= 2 {
-			return strings.ToLower(analyzedCmd.CommandName[:2])
+			return strings.ToLower(analyzedCmd.CommandName[:2]), ""
 		}
-		return string(strings.ToLower(analyzedCmd.CommandName)[0]) // Likely filtered by length check later.
+		return string(strings.ToLower(analyzedCmd.CommandName)[0]), "" // Likely filtered by length check later.
 	}
 
 	nameParts := []string{string(strings.ToLower(analyzedCmd.CommandName)[0])}
+	lastWord := ""
 	argInitialsCount := 0
 	const maxArgInitials = 3 // Max argument initials to include after command initial.
 
@@ -140,6 +178,7 @@ func (g *AliasGenerator) generateExactCommandAliasName(analyzedCmd command.Analy
 
 		cleanArg := strings.ToLower(arg)
 		var partToAdd string
+		var sourceWord string // The word partToAdd is the initial of, if it can be extended.
 
 		if strings.HasPrefix(cleanArg, "--") && len(cleanArg) > 2 {
 			partToAdd = string(cleanArg[2])
@@ -165,16 +204,20 @@ func (g *AliasGenerator) generateExactCommandAliasName(analyzedCmd command.Analy
 
 			if len(significantPart) > 0 {
 				partToAdd = string(significantPart[0])
+				sourceWord = significantPart
 			}
 		} else if !strings.HasPrefix(cleanArg, "-") { // Regular argument
 			partToAdd = string(cleanArg[0])
+			sourceWord = cleanArg
 		}
 
 		if partToAdd != "" {
 			if partToAdd == "." && len(nameParts) > 1 && len(strings.Join(nameParts, "")) > 1 {
 				nameParts = append(nameParts, partToAdd) // Allow e.g. "ga."
+				lastWord = ""
 			} else if partToAdd != "." {
 				nameParts = append(nameParts, partToAdd)
+				lastWord = sourceWord
 			}
 			if partToAdd != "." { // Don't count "." towards argInitialsCount.
 				argInitialsCount++
@@ -184,19 +227,46 @@ func (g *AliasGenerator) generateExactCommandAliasName(analyzedCmd command.Analy
 
 	if len(nameParts) == 1 { // Only command initial was added.
 		if len(analyzedCmd.CommandName) >= 2 {
-			return strings.ToLower(analyzedCmd.CommandName[:2]) // Use first two letters of command.
+			return strings.ToLower(analyzedCmd.CommandName[:2]), "" // Use first two letters of command.
 		}
-		return nameParts[0] // Single letter command, single letter alias.
+		return nameParts[0], "" // Single letter command, single letter alias.
 	}
 
-	return strings.Join(nameParts, "")
+	return strings.Join(nameParts, ""), lastWord
+}
+
+/*
+partitionByResolvability splits commands into those whose command name can be
+resolved (an executable on PATH, a shell builtin or one of existingAliases) and
+those that cannot, which are likely misspellings. Without a resolver, every
+command is considered resolvable.
+*/
+func (g *AliasGenerator) partitionByResolvability(
+	commands []history.CommandFrequency,
+	existingAliases map[string]string,
+) (resolvable, unresolvable []history.CommandFrequency) {
+	if g.resolver == nil {
+		return commands, nil
+	}
+	for _, cmdFreq := range commands {
+		name := g.analyzer.Analyze(cmdFreq.Command).CommandName
+		if _, isAlias := existingAliases[name]; isAlias || g.resolver.IsResolvable(name) {
+			resolvable = append(resolvable, cmdFreq)
+		} else {
+			g.log().Debug("left command out of shortcuts: its name cannot be resolved, so it may be a typo", "command", cmdFreq.Command)
+			unresolvable = append(unresolvable, cmdFreq)
+		}
+	}
+	return resolvable, unresolvable
 }
 
 func (g *AliasGenerator) generateExactFullCommandAliasesStrategy(
 	commands []history.CommandFrequency,
+	conventionalNames map[string]string,
 	minFrequency int,
 	existingAliases map[string]string,
 	generatedNamesInThisRun map[string]bool, // Modifies this map
+	suggestedCommandsInThisRun map[string]bool, // Modifies this map
 	minCommandEffectiveLength int,
 ) []alias.Alias {
 	suggestions := []alias.Alias{}
@@ -204,7 +274,7 @@ func (g *AliasGenerator) generateExactFullCommandAliasesStrategy(
 		analyzed := g.analyzer.Analyze(cmdFreq.Command)
 
 		if analyzed.IsComplex {
-			// Optionally log: log.Printf("Skipping complex command for exact alias strategy: %s", cmdFreq.Command)
+			g.log().Debug("skipped complex command for the exact command strategy", "command", cmdFreq.Command)
 			continue
 		}
 
@@ -216,31 +286,42 @@ func (g *AliasGenerator) generateExactFullCommandAliasesStrategy(
 			continue
 		}
 
-		if analyzed.CommandName == "" {
+		if analyzed.CommandName == "" || suggestedCommandsInThisRun[cmdFreq.Command] {
 			continue
 		}
 
-		proposedName := g.generateExactCommandAliasName(analyzed)
+		initials, lastWord := g.exactCommandInitials(analyzed)
 
-		// Use the comprehensive IsValidAliasName for the final check,
-		// after isProposedNameValid (which checks generatedInThisRun).
-		// The current structure calls isProposedNameValid which is a preliminary check.
+		// isProposedNameValid (which checks generatedInThisRun) is a preliminary check.
 		// The full IsValidAliasName (with LookPath) is expected to be called by the service layer
 		// or before finalizing. For internal generation, isProposedNameValid is used.
-		if g.isProposedNameValid(proposedName, analyzed.CommandName, existingAliases, generatedNamesInThisRun) {
-			suggestions = append(suggestions, alias.Alias{Name: proposedName, Command: cmdFreq.Command})
+		candidates := withConventionalName(conventionalNames, cmdFreq.Command, rankNameCandidates(initials, lastWord))
+		proposedName := g.firstValidName(candidates, analyzed.CommandName, existingAliases, generatedNamesInThisRun)
+		if proposedName != "" {
+			suggestions = append(suggestions, alias.Alias{
+				Name:      proposedName,
+				Command:   cmdFreq.Command,
+				Frequency: cmdFreq.Count,
+				Source:    alias.SourceExactCommand,
+				Examples:  []string{cmdFreq.Command},
+			})
 			generatedNamesInThisRun[proposedName] = true
+			suggestedCommandsInThisRun[cmdFreq.Command] = true
 		}
 	}
 	return suggestions
 }
 
+// maxSuggestionExamples is the maximum number of history lines kept as examples of a suggestion.
+const maxSuggestionExamples = 5
+
 func (g *AliasGenerator) aggregateForCommandFirstArgStrategy(
 	commands []history.CommandFrequency,
 	minCommandEffectiveLength int,
-) (map[string]int, map[string]command.AnalyzedCommand) {
+) (map[string]int, map[string]command.AnalyzedCommand, map[string][]string) {
 	cmdFirstArgFreq := make(map[string]int)
 	cmdFirstArgToAnalyzedCmd := make(map[string]command.AnalyzedCommand)
+	cmdFirstArgExamples := make(map[string][]string)
 
 	for _, cmdFreq := range commands {
 		analyzed := g.analyzer.Analyze(cmdFreq.Command)
@@ -264,6 +345,9 @@ func (g *AliasGenerator) aggregateForCommandFirstArgStrategy(
 		if firstNonFlagArg != "" {
 			key := analyzed.CommandName + " " + firstNonFlagArg
 			cmdFirstArgFreq[key] += cmdFreq.Count
+			if len(cmdFirstArgExamples[key]) < maxSuggestionExamples {
+				cmdFirstArgExamples[key] = append(cmdFirstArgExamples[key], cmdFreq.Command)
+			}
 			if _, exists := cmdFirstArgToAnalyzedCmd[key]; !exists {
 				// Store a simplified AnalyzedCommand for generating the alias name.
 				cmdFirstArgToAnalyzedCmd[key] = command.AnalyzedCommand{
@@ -275,30 +359,73 @@ func (g *AliasGenerator) aggregateForCommandFirstArgStrategy(
 			}
 		}
 	}
-	return cmdFirstArgFreq, cmdFirstArgToAnalyzedCmd
+	return cmdFirstArgFreq, cmdFirstArgToAnalyzedCmd, cmdFirstArgExamples
 }
 
 func (g *AliasGenerator) generateAliasesFromCommandFirstArgAggregation(
 	cmdFirstArgFreq map[string]int,
 	cmdFirstArgToAnalyzedCmd map[string]command.AnalyzedCommand,
+	cmdFirstArgExamples map[string][]string,
+	conventionalNames map[string]string,
 	minFrequency int,
 	existingAliases map[string]string,
 	generatedNamesInThisRun map[string]bool, // Modifies this map
+	suggestedCommandsInThisRun map[string]bool, // Modifies this map
 ) []alias.Alias {
+	// The most frequent patterns pick their names first, so "git pull" run 30 times gets
+	// "gp" before "git push" run 10 times, which falls back to its next candidate.
+	keys := make([]string, 0, len(cmdFirstArgFreq))
+	for key := range cmdFirstArgFreq {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if cmdFirstArgFreq[keys[i]] != cmdFirstArgFreq[keys[j]] {
+			return cmdFirstArgFreq[keys[i]] > cmdFirstArgFreq[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
 	suggestions := []alias.Alias{}
-	for keyCmdFirstArg, count := range cmdFirstArgFreq {
+	for _, keyCmdFirstArg := range keys {
+		count := cmdFirstArgFreq[keyCmdFirstArg]
 		if count < minFrequency {
 			continue
 		}
 
 		analyzedForNameGen := cmdFirstArgToAnalyzedCmd[keyCmdFirstArg]
-		proposedName := g.generateCommandSubcommandAliasName(analyzedForNameGen)
+		initials, lastWord := g.commandSubcommandInitials(analyzedForNameGen)
+		candidates := withConventionalName(conventionalNames, keyCmdFirstArg, rankNameCandidates(initials, lastWord))
+		proposedName := g.firstValidName(candidates, analyzedForNameGen.CommandName, existingAliases, generatedNamesInThisRun)
 		aliasCommandString := keyCmdFirstArg // The alias command is the aggregated "cmd arg1"
 
-		if g.isProposedNameValid(proposedName, analyzedForNameGen.CommandName, existingAliases, generatedNamesInThisRun) {
-			suggestions = append(suggestions, alias.Alias{Name: proposedName, Command: aliasCommandString})
+		if proposedName != "" {
+			suggestions = append(suggestions, alias.Alias{
+				Name:      proposedName,
+				Command:   aliasCommandString,
+				Frequency: count,
+				Source:    alias.SourceSubcommand,
+				Examples:  cmdFirstArgExamples[keyCmdFirstArg],
+			})
 			generatedNamesInThisRun[proposedName] = true
+			suggestedCommandsInThisRun[aliasCommandString] = true
 		}
 	}
 	return suggestions
 }
+
+// redactSecrets replaces the secrets in the commands and examples of suggestions with
+// placeholders, and lists the kinds of secrets found in each command in its Secrets field.
+func (g *AliasGenerator) redactSecrets(suggestions []alias.Alias) {
+	if g.secrets == nil {
+		return
+	}
+	for i := range suggestions {
+		if kinds := g.secrets.DetectSecrets(suggestions[i].Command); len(kinds) > 0 {
+			suggestions[i].Secrets = kinds
+			suggestions[i].Command = g.secrets.Redact(suggestions[i].Command)
+		}
+		for j, example := range suggestions[i].Examples {
+			suggestions[i].Examples[j] = g.secrets.Redact(example)
+		}
+	}
+}
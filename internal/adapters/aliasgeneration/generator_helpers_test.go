@@ -1,6 +1,7 @@
 package aliasgeneration
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/command"
@@ -9,7 +10,7 @@ import (
 
 func TestNewAliasGenerator(t *testing.T) {
 	mockAnalyzer := testutil.NewMockCommandAnalyzer()
-	gen := NewAliasGenerator(mockAnalyzer)
+	gen := NewAliasGenerator(mockAnalyzer, &testutil.MockCommandResolver{}, nil, nil, nil)
 	if gen == nil {
 		t.Fatal("NewAliasGenerator returned nil")
 	}
@@ -326,3 +327,15 @@ func TestIsAliasNameValid_PackageHelper(t *testing.T) {
 		})
 	}
 }
+
+func TestAliasGenerator_LogsRejectedNames(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	gen := &AliasGenerator{logger: logger}
+
+	gen.isProposedNameValid("gp", "git", map[string]string{"gp": "git push"}, map[string]bool{})
+	gen.isProposedNameValid("gs", "git", map[string]string{}, map[string]bool{})
+	want := []string{"DEBUG rejected candidate alias name name=gp command=git reason=already an alias"}
+	if !reflect.DeepEqual(logger.Entries, want) {
+		t.Errorf("logged %q, want %q", logger.Entries, want)
+	}
+}
@@ -22,10 +22,21 @@ func sortAliases(aliases []alias.Alias) {
 	})
 }
 
+// withoutSuggestionDetails clears the frequency, source and examples of suggestions,
+// for tests that only check the generated names and commands.
+func withoutSuggestionDetails(aliases []alias.Alias) []alias.Alias {
+	for i := range aliases {
+		aliases[i].Frequency = 0
+		aliases[i].Source = ""
+		aliases[i].Examples = nil
+	}
+	return aliases
+}
+
 func TestAliasGenerator_GenerateSuggestions(t *testing.T) {
 	mockAnalyzer := testutil.NewMockCommandAnalyzer()
 	// Assuming NewAliasGenerator is the correct constructor name.
-	gen := NewAliasGenerator(mockAnalyzer)
+	gen := NewAliasGenerator(mockAnalyzer, &testutil.MockCommandResolver{}, nil, nil, nil)
 
 	tests := []struct {
 		name            string
@@ -188,7 +199,7 @@ func TestAliasGenerator_GenerateSuggestions(t *testing.T) {
 			want: []alias.Alias{},
 		},
 		{
-			name: "Existing alias prevents suggestion",
+			name: "Existing alias name falls back to the next candidate",
 			commands: []history.CommandFrequency{
 				{Command: "git pull", Count: 20},
 			},
@@ -197,8 +208,9 @@ func TestAliasGenerator_GenerateSuggestions(t *testing.T) {
 			analyzeFuncs: map[string]command.AnalyzedCommand{
 				"git pull": {Original: "git pull", CommandName: "git", PotentialArgs: []string{"pull"}, EffectiveLength: len("gitpull")},
 			},
-			// "git pull" -> "gp" (by both strategies). "gp" is in existingAliases. So, no suggestion.
-			want: []alias.Alias{},
+			// "git pull" -> "gp" (by both strategies), but "gp" is in existingAliases,
+			// so the next candidate "gpu" is used. The exact strategy skips the already suggested command.
+			want: []alias.Alias{{Name: "gpu", Command: "git pull"}},
 		},
 	}
 
@@ -227,6 +239,7 @@ func TestAliasGenerator_GenerateSuggestions(t *testing.T) {
 
 			got := gen.GenerateSuggestions(tt.commands, tt.existingAliases, tt.minFrequency)
 
+			got = withoutSuggestionDetails(got)
 			sortAliases(got)
 			sortAliases(tt.want)
 
@@ -236,3 +249,92 @@ func TestAliasGenerator_GenerateSuggestions(t *testing.T) {
 		})
 	}
 }
+
+func TestAliasGenerator_GenerateSuggestions_SkipsUnresolvableCommands(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		parts := strings.Fields(cmdStr)
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: parts[0], PotentialArgs: parts[1:], EffectiveLength: len(strings.ReplaceAll(cmdStr, " ", ""))}
+	}
+	resolver := &testutil.MockCommandResolver{IsResolvableFunc: func(name string) bool {
+		return name == "git"
+	}}
+	gen := NewAliasGenerator(mockAnalyzer, resolver, nil, nil, nil)
+
+	commands := []history.CommandFrequency{
+		{Command: "git status", Count: 20},
+		{Command: "gti status", Count: 20},
+		{Command: "kc get pods", Count: 20}, // "kc" is an existing alias, so it is not a typo.
+	}
+	got := gen.GenerateSuggestions(commands, map[string]string{"kc": "kubectl"}, 10)
+	want := []alias.Alias{
+		{Name: "gs", Command: "git status"},
+		{Name: "kg", Command: "kc get"},
+		{Name: "kgp", Command: "kc get pods"},
+	}
+
+	got = withoutSuggestionDetails(got)
+	sortAliases(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSuggestions() got = %v, want %v", got, want)
+	}
+}
+
+func TestAliasGenerator_GenerateSuggestions_Details(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		parts := strings.Fields(cmdStr)
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: parts[0], PotentialArgs: parts[1:], EffectiveLength: len(strings.ReplaceAll(cmdStr, " ", ""))}
+	}
+	gen := NewAliasGenerator(mockAnalyzer, &testutil.MockCommandResolver{}, nil, nil, nil)
+
+	commands := []history.CommandFrequency{
+		{Command: "git log --oneline", Count: 12},
+		{Command: "git log", Count: 3},
+	}
+	got := gen.GenerateSuggestions(commands, nil, 10)
+	want := []alias.Alias{
+		{Name: "gl", Command: "git log", Frequency: 15, Source: alias.SourceSubcommand, Examples: []string{"git log --oneline", "git log"}},
+		{Name: "glo", Command: "git log --oneline", Frequency: 12, Source: alias.SourceExactCommand, Examples: []string{"git log --oneline"}},
+	}
+
+	sortAliases(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSuggestions() got = %v, want %v", got, want)
+	}
+}
+
+func TestAliasGenerator_GenerateSuggestions_RedactsSecrets(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		parts := strings.Fields(cmdStr)
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: parts[0], PotentialArgs: parts[1:], EffectiveLength: len(strings.ReplaceAll(cmdStr, " ", ""))}
+	}
+	secrets := &testutil.MockSecretDetector{
+		DetectSecretsFunc: func(command string) []string {
+			if strings.Contains(command, "hunter2") {
+				return []string{"password or token argument"}
+			}
+			return nil
+		},
+		RedactFunc: func(command string) string {
+			return strings.ReplaceAll(command, "hunter2", "<redacted>")
+		},
+	}
+	gen := NewAliasGenerator(mockAnalyzer, &testutil.MockCommandResolver{}, nil, secrets, nil)
+
+	commands := []history.CommandFrequency{
+		{Command: "mysql --password=hunter2", Count: 5},
+		{Command: "git status", Count: 4},
+	}
+	got := gen.GenerateSuggestions(commands, nil, 3)
+	want := []alias.Alias{
+		{Name: "gs", Command: "git status", Frequency: 4, Source: alias.SourceSubcommand, Examples: []string{"git status"}},
+		{Name: "mp", Command: "mysql --password=<redacted>", Frequency: 5, Source: alias.SourceExactCommand, Examples: []string{"mysql --password=<redacted>"}, Secrets: []string{"password or token argument"}},
+	}
+
+	sortAliases(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSuggestions() got = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package aliasgeneration
+
+import "strings"
+
+/*
+rankNameCandidates returns the alias names to try for a command, best first,
+starting from its initials (e.g. "gp" for "git push"). lastWord is the word the
+last initial was taken from; when it is known, the candidates continue with:
+
+ 1. the initials extended with the next letters of lastWord ("gpu", "gpus"),
+ 2. the initials with the vowel-dropped lastWord ("gpsh").
+
+Example:
+
+	rankNameCandidates("gp", "push") // ["gp", "gpu", "gpus", "gpush", "gpsh"]
+*/
+func rankNameCandidates(initials, lastWord string) []string {
+	candidates := []string{initials}
+	lastWord = strings.ToLower(lastWord)
+	if initials == "" || lastWord == "" || lastWord[0] != initials[len(initials)-1] {
+		return candidates
+	}
+
+	stem := initials[:len(initials)-1]
+	for i := 2; i <= len(lastWord); i++ {
+		candidates = appendUnique(candidates, stem+lastWord[:i])
+	}
+	candidates = appendUnique(candidates, stem+dropVowels(lastWord))
+	return candidates
+}
+
+// withConventionalName puts the conventional name of commandStr, if it has one, in front of candidates.
+func withConventionalName(conventionalNames map[string]string, commandStr string, candidates []string) []string {
+	name, ok := conventionalNames[strings.Join(strings.Fields(commandStr), " ")]
+	if !ok {
+		return candidates
+	}
+	ranked := []string{name}
+	for _, candidate := range candidates {
+		ranked = appendUnique(ranked, candidate)
+	}
+	return ranked
+}
+
+// loadConventionalNames returns the conventional names of the enabled tools, keyed by command.
+// Without a convention provider, or if it fails, there are no conventional names.
+func (g *AliasGenerator) loadConventionalNames() map[string]string {
+	if g.conventions == nil {
+		return nil
+	}
+	names, err := g.conventions.GetConventionalNames()
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+// dropVowels removes the vowels from word, except for its first letter ("push" -> "psh").
+func dropVowels(word string) string {
+	if word == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte(word[0])
+	for _, r := range word[1:] {
+		if !strings.ContainsRune("aeiou", r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// appendUnique appends name to names unless it is already present.
+func appendUnique(names []string, name string) []string {
+	for _, existing := range names {
+		if existing == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
+
+// firstValidName returns the first of candidates that passes isProposedNameValid, or "" if none does.
+func (g *AliasGenerator) firstValidName(
+	candidates []string,
+	originalCommandName string,
+	existingAliases map[string]string,
+	generatedNamesInThisRun map[string]bool,
+) string {
+	for _, candidate := range candidates {
+		if g.isProposedNameValid(candidate, originalCommandName, existingAliases, generatedNamesInThisRun) {
+			return candidate
+		}
+	}
+	return ""
+}
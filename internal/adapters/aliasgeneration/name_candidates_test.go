@@ -0,0 +1,126 @@
+package aliasgeneration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/command"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+func TestRankNameCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		initials string
+		lastWord string
+		want     []string
+	}{
+		{name: "subcommand", initials: "gp", lastWord: "push", want: []string{"gp", "gpu", "gpus", "gpush", "gpsh"}},
+		{name: "vowel-dropped word already listed", initials: "ks", lastWord: "sh", want: []string{"ks", "ksh"}},
+		{name: "mixed case word", initials: "dc", lastWord: "Compose", want: []string{"dc", "dco", "dcom", "dcomp", "dcompo", "dcompos", "dcompose", "dcmps"}},
+		{name: "no source word", initials: "up", lastWord: "", want: []string{"up"}},
+		{name: "word does not match the last initial", initials: "ga.", lastWord: "add", want: []string{"ga."}},
+		{name: "empty initials", initials: "", lastWord: "push", want: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankNameCandidates(tt.initials, tt.lastWord); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankNameCandidates(%q, %q) = %v, want %v", tt.initials, tt.lastWord, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDropVowels(t *testing.T) {
+	tests := map[string]string{"push": "psh", "pull": "pll", "apply": "apply", "": "", "a": "a"}
+	for word, want := range tests {
+		if got := dropVowels(word); got != want {
+			t.Errorf("dropVowels(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestAliasGenerator_GenerateSuggestions_CollidingInitials(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		parts := strings.Fields(cmdStr)
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: parts[0], PotentialArgs: parts[1:], EffectiveLength: len(strings.ReplaceAll(cmdStr, " ", ""))}
+	}
+	gen := NewAliasGenerator(mockAnalyzer, &testutil.MockCommandResolver{}, nil, nil, nil)
+
+	commands := []history.CommandFrequency{
+		{Command: "git push", Count: 10},
+		{Command: "git pull", Count: 30},
+		{Command: "git pop", Count: 5},
+	}
+	// "gpu" is taken by an existing alias, so "git push" skips it as well.
+	got := gen.GenerateSuggestions(commands, map[string]string{"gpu": "gpupdate"}, 3)
+	want := []alias.Alias{
+		{Name: "gp", Command: "git pull"},   // Most frequent, gets the initials.
+		{Name: "gpo", Command: "git pop"},   // Initials + next letter.
+		{Name: "gpus", Command: "git push"}, // "gp" and "gpu" are taken.
+	}
+
+	got = withoutSuggestionDetails(got)
+	sortAliases(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSuggestions() got = %v, want %v", got, want)
+	}
+}
+
+func TestWithConventionalName(t *testing.T) {
+	conventionalNames := map[string]string{"git checkout": "gco", "git pull": "gl"}
+	tests := []struct {
+		name       string
+		commandStr string
+		candidates []string
+		want       []string
+	}{
+		{name: "conventional name first", commandStr: "git checkout", candidates: []string{"gc", "gch"}, want: []string{"gco", "gc", "gch"}},
+		{name: "whitespace is normalized", commandStr: "git   pull ", candidates: []string{"gp"}, want: []string{"gl", "gp"}},
+		{name: "conventional name not repeated", commandStr: "git checkout", candidates: []string{"gc", "gco"}, want: []string{"gco", "gc"}},
+		{name: "no conventional name", commandStr: "git push", candidates: []string{"gp"}, want: []string{"gp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withConventionalName(conventionalNames, tt.commandStr, tt.candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withConventionalName(%q) = %v, want %v", tt.commandStr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAliasGenerator_GenerateSuggestions_Conventions(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		parts := strings.Fields(cmdStr)
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: parts[0], PotentialArgs: parts[1:], EffectiveLength: len(strings.ReplaceAll(cmdStr, " ", ""))}
+	}
+	conventions := &testutil.MockNamingConventionProvider{GetConventionalNamesFunc: func() (map[string]string, error) {
+		return map[string]string{"git checkout": "gco", "git status": "gst", "kubectl get pods": "kgp"}, nil
+	}}
+	gen := NewAliasGenerator(mockAnalyzer, &testutil.MockCommandResolver{}, conventions, nil, nil)
+
+	commands := []history.CommandFrequency{
+		{Command: "git checkout", Count: 10},
+		{Command: "git status", Count: 10},
+		{Command: "kubectl get pods", Count: 10},
+	}
+	// "gst" is already taken, so "git status" falls back to its initials.
+	got := gen.GenerateSuggestions(commands, map[string]string{"gst": "git stash"}, 3)
+	want := []alias.Alias{
+		{Name: "gco", Command: "git checkout"},
+		{Name: "gs", Command: "git status"},
+		{Name: "kg", Command: "kubectl get"},
+		{Name: "kgp", Command: "kubectl get pods"},
+	}
+
+	got = withoutSuggestionDetails(got)
+	sortAliases(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSuggestions() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+package aliasgeneration
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
+
+/*
+generatePipelineTailAliasesStrategy suggests global aliases (a zsh feature)
+for the pipeline stages repeated across commands, e.g. "| grep -i" or
+"| less". A global alias is expanded anywhere on the command line, so with
+GI='| grep -i', "dmesg GI usb" runs "dmesg | grep -i usb".
+
+Every stage after a pipe is cut before its first argument that is not a flag,
+so "| grep -i usb" and "| grep -i error" are the same tail "| grep -i". Tails
+whose command is at least minTailEffectiveLength characters long, seen at least
+minFrequency times, are suggested, the most frequent first. Names are the
+uppercased initials of the tail ("GI"), as is customary for global aliases.
+*/
+func (g *AliasGenerator) generatePipelineTailAliasesStrategy(
+	commands []history.CommandFrequency,
+	minFrequency int,
+	existingAliases map[string]string,
+	generatedNamesInThisRun map[string]bool, // Modifies this map
+	minTailEffectiveLength int,
+) []alias.Alias {
+	tailFreq := make(map[string]int)
+	tailExamples := make(map[string][]string)
+	for _, cmdFreq := range commands {
+		for _, tail := range pipelineTails(cmdFreq.Command) {
+			if len(strings.ReplaceAll(strings.TrimPrefix(tail, "| "), " ", "")) < minTailEffectiveLength {
+				continue
+			}
+			tailFreq[tail] += cmdFreq.Count
+			if len(tailExamples[tail]) < maxSuggestionExamples {
+				tailExamples[tail] = append(tailExamples[tail], cmdFreq.Command)
+			}
+		}
+	}
+
+	tails := sortedKeys(tailFreq)
+	sort.SliceStable(tails, func(i, j int) bool { return tailFreq[tails[i]] > tailFreq[tails[j]] })
+
+	suggestions := []alias.Alias{}
+	for _, tail := range tails {
+		if tailFreq[tail] < minFrequency {
+			continue
+		}
+		name := g.firstValidGlobalName(pipelineTailNameCandidates(tail), existingAliases, generatedNamesInThisRun)
+		if name == "" {
+			continue
+		}
+		suggestions = append(suggestions, alias.Alias{
+			Name:      name,
+			Command:   tail,
+			Kind:      alias.KindGlobal,
+			Frequency: tailFreq[tail],
+			Source:    alias.SourcePipelineTail,
+			Examples:  tailExamples[tail],
+		})
+		generatedNamesInThisRun[name] = true
+	}
+	return suggestions
+}
+
+/*
+pipelineTails returns the stages of commandStr after a pipe, each cut before
+its first argument that is not a flag and prefixed with "| ", without
+duplicates. Pipes inside quotes and "||" are not pipes.
+
+Example:
+
+	pipelineTails("dmesg | grep -i usb | less") // ["| grep -i", "| less"]
+*/
+func pipelineTails(commandStr string) []string {
+	var stages []string
+	var current strings.Builder
+	var quote rune
+	runes := []rune(commandStr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\\':
+			current.WriteRune(r)
+			i++
+			if i < len(runes) {
+				current.WriteRune(runes[i])
+			}
+			continue
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '|' && i+1 < len(runes) && (runes[i+1] == '|' || runes[i+1] == '&'):
+			// "||" runs the next command on failure, and "|&" pipes stderr too: neither is a plain pipe.
+			current.WriteRune(r)
+			current.WriteRune(runes[i+1])
+			i++
+			continue
+		case r == '|':
+			stages = append(stages, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	stages = append(stages, current.String())
+
+	var tails []string
+	for _, stage := range stages[1:] {
+		fields := strings.Fields(stage)
+		if len(fields) == 0 || strings.ContainsAny(fields[0], `'"\$`) {
+			continue
+		}
+		words := []string{fields[0]}
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") || strings.ContainsAny(field, `'"\$;&`) {
+				break
+			}
+			words = append(words, field)
+		}
+		tails = appendUnique(tails, "| "+strings.Join(words, " "))
+	}
+	return tails
+}
+
+// pipelineTailNameCandidates returns the names to try for the global alias of tail, best first:
+// its uppercased initials, then its uppercased command name followed by the initials of its flags.
+//
+// Example:
+//
+//	pipelineTailNameCandidates("| grep -i") // ["GI", "GREPI"]
+func pipelineTailNameCandidates(tail string) []string {
+	words := strings.Fields(strings.TrimPrefix(tail, "|"))
+	if len(words) == 0 {
+		return nil
+	}
+	var flagInitials strings.Builder
+	for _, flag := range words[1:] {
+		if trimmed := strings.TrimLeft(flag, "-"); trimmed != "" {
+			flagInitials.WriteByte(trimmed[0])
+		}
+	}
+	initials := strings.ToUpper(words[0][:1] + flagInitials.String())
+	return appendUnique([]string{initials}, strings.ToUpper(words[0]+flagInitials.String()))
+}
+
+// firstValidGlobalName returns the first of candidates that can name a new global alias, or "" if none can.
+// Unlike the names of regular aliases, a single letter is fine: global aliases are uppercase by convention,
+// so they hardly ever clash with the words of a command line.
+func (g *AliasGenerator) firstValidGlobalName(
+	candidates []string,
+	existingAliases map[string]string,
+	generatedNamesInThisRun map[string]bool,
+) string {
+	for _, candidate := range candidates {
+		reason := aliasNameRejection(candidate, existingAliases)
+		if reason == "" && generatedNamesInThisRun[candidate] {
+			reason = "already suggested for another command"
+		}
+		if reason == "" {
+			return candidate
+		}
+		g.log().Debug("rejected candidate global alias name", "name", candidate, "reason", reason)
+	}
+	return ""
+}
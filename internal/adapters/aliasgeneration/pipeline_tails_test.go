@@ -0,0 +1,74 @@
+package aliasgeneration
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
+
+func TestPipelineTails(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"dmesg | grep -i usb | less", []string{"| grep -i", "| less"}},
+		{"ps aux|grep -v grep|wc -l", []string{"| grep -v", "| wc -l"}},
+		{"cat a | sort | uniq -c | sort -rn | head -n 20", []string{"| sort", "| uniq -c", "| sort -rn", "| head -n"}},
+		{"ls -l", nil},
+		{"make || echo failed", nil},
+		{"make |& tee log", nil},
+		{`echo 'a | b' "c|d" e\|f`, nil},
+		{"echo x | $PAGER", nil},
+		{"ls |", nil},
+	}
+	for _, tt := range tests {
+		if got := pipelineTails(tt.command); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("pipelineTails(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestPipelineTailNameCandidates(t *testing.T) {
+	tests := []struct {
+		tail string
+		want []string
+	}{
+		{"| grep -i", []string{"GI", "GREPI"}},
+		{"| less", []string{"L", "LESS"}},
+		{"| sort --reverse -n", []string{"SRN", "SORTRN"}},
+		{"| x", []string{"X"}},
+	}
+	for _, tt := range tests {
+		if got := pipelineTailNameCandidates(tt.tail); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("pipelineTailNameCandidates(%q) = %q, want %q", tt.tail, got, tt.want)
+		}
+	}
+}
+
+func TestAliasGenerator_PipelineTailAliasesStrategy(t *testing.T) {
+	gen := &AliasGenerator{}
+	commands := []history.CommandFrequency{
+		{Command: "dmesg | grep -i usb", Count: 3},
+		{Command: "journalctl | grep -i error | less", Count: 2},
+		{Command: "git log | less", Count: 1},
+		{Command: "cat f | jq", Count: 9}, // Too short to be worth a global alias.
+		{Command: "ps | wc -l", Count: 2}, // Below minFrequency.
+	}
+	generated := map[string]bool{}
+	got := gen.generatePipelineTailAliasesStrategy(commands, 3, map[string]string{"L": "| less -R"}, generated, 4)
+	want := []alias.Alias{
+		{Name: "GI", Command: "| grep -i", Kind: alias.KindGlobal, Frequency: 5, Source: alias.SourcePipelineTail,
+			Examples: []string{"dmesg | grep -i usb", "journalctl | grep -i error | less"}},
+		// "L" is already an alias.
+		{Name: "LESS", Command: "| less", Kind: alias.KindGlobal, Frequency: 3, Source: alias.SourcePipelineTail,
+			Examples: []string{"journalctl | grep -i error | less", "git log | less"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generatePipelineTailAliasesStrategy() = %+v, want %+v", got, want)
+	}
+	if !generated["GI"] || !generated["LESS"] {
+		t.Errorf("generatePipelineTailAliasesStrategy() did not record the names it generated: %v", generated)
+	}
+}
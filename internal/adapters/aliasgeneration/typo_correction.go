@@ -0,0 +1,148 @@
+package aliasgeneration
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
+
+/*
+generateTypoCorrectionAliasesStrategy suggests aliases that correct misspelled
+command names, e.g. "gti" -> "git".
+
+The names of misspelledCommands are aggregated, and every name seen at least
+minFrequency times is matched against the known commands (executables on PATH
+and shell builtins) and existingAliases. The closest match within
+maxTypoDistance wins; ties go to the command used most in resolvableCommands,
+then to the alphabetically first one.
+*/
+func (g *AliasGenerator) generateTypoCorrectionAliasesStrategy(
+	misspelledCommands []history.CommandFrequency,
+	resolvableCommands []history.CommandFrequency,
+	minFrequency int,
+	existingAliases map[string]string,
+	generatedNamesInThisRun map[string]bool, // Modifies this map
+) []alias.Alias {
+	suggestions := []alias.Alias{}
+	if g.resolver == nil || len(misspelledCommands) == 0 {
+		return suggestions
+	}
+
+	typoFreq := make(map[string]int)
+	typoExamples := make(map[string][]string)
+	for _, cmdFreq := range misspelledCommands {
+		if name := g.analyzer.Analyze(cmdFreq.Command).CommandName; name != "" {
+			typoFreq[name] += cmdFreq.Count
+			if len(typoExamples[name]) < maxSuggestionExamples {
+				typoExamples[name] = append(typoExamples[name], cmdFreq.Command)
+			}
+		}
+	}
+
+	usage := make(map[string]int)
+	for _, cmdFreq := range resolvableCommands {
+		usage[g.analyzer.Analyze(cmdFreq.Command).CommandName] += cmdFreq.Count
+	}
+
+	var candidates []string
+	for _, typo := range sortedKeys(typoFreq) {
+		if typoFreq[typo] < minFrequency {
+			continue
+		}
+		if candidates == nil {
+			// Only list the known commands once a typo is frequent enough to matter.
+			candidates = slices.Concat(g.resolver.KnownCommands(), sortedKeys(existingAliases))
+		}
+		correction := closestCommand(typo, candidates, usage)
+		if correction == "" {
+			continue
+		}
+		if g.isProposedNameValid(typo, correction, existingAliases, generatedNamesInThisRun) {
+			suggestions = append(suggestions, alias.Alias{
+				Name:         typo,
+				Command:      correction,
+				IsCorrection: true,
+				Frequency:    typoFreq[typo],
+				Source:       alias.SourceCorrection,
+				Examples:     typoExamples[typo],
+			})
+			generatedNamesInThisRun[typo] = true
+		}
+	}
+	return suggestions
+}
+
+// maxTypoDistance returns the largest edit distance at which name is still
+// considered a misspelling: 1 for short names, 2 for longer ones.
+func maxTypoDistance(name string) int {
+	if len(name) <= 4 {
+		return 1
+	}
+	return 2
+}
+
+// closestCommand returns the candidate closest to typo within maxTypoDistance,
+// or "" if there is none. Ties are broken by usage, then alphabetically.
+func closestCommand(typo string, candidates []string, usage map[string]int) string {
+	best, bestDistance := "", maxTypoDistance(typo)+1
+	for _, candidate := range candidates {
+		if candidate == typo {
+			continue
+		}
+		distance := editDistance(typo, candidate)
+		if distance > maxTypoDistance(typo) {
+			continue
+		}
+		better := distance < bestDistance ||
+			(distance == bestDistance && usage[candidate] > usage[best]) ||
+			(distance == bestDistance && usage[candidate] == usage[best] && candidate < best)
+		if better {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+/*
+editDistance returns the optimal string alignment distance between a and b:
+the number of insertions, deletions, substitutions and transpositions of
+adjacent characters needed to turn a into b. Transpositions count as a single
+edit, since swapped letters ("gti") are the most common typing mistake.
+*/
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// d[i][j] is the distance between the first i runes of a and the first j runes of b.
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
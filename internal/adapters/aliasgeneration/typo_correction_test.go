@@ -0,0 +1,107 @@
+package aliasgeneration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/command"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"git", "git", 0},
+		{"gti", "git", 1},  // Transposition.
+		{"gi", "git", 1},   // Deletion.
+		{"gitt", "git", 1}, // Insertion.
+		{"got", "git", 1},  // Substitution.
+		{"dokcer", "docker", 1},
+		{"kubeclt", "kubectl", 1},
+		{"pyhton3", "python3", 1},
+		{"ls", "cat", 3},
+		{"", "git", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestCommand(t *testing.T) {
+	candidates := []string{"gcc", "git", "gio", "docker", "sl"}
+	tests := []struct {
+		name  string
+		typo  string
+		usage map[string]int
+		want  string
+	}{
+		{name: "single match", typo: "dokcer", want: "docker"},
+		{name: "tie broken by usage", typo: "gi", usage: map[string]int{"git": 10, "gio": 1}, want: "git"},
+		{name: "tie broken alphabetically", typo: "gi", want: "gio"},
+		{name: "closest wins over usage", typo: "gti", usage: map[string]int{"gio": 50}, want: "git"},
+		{name: "too far for a short name", typo: "gxx", want: ""},
+		{name: "exact name is not a correction", typo: "sl", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := closestCommand(tt.typo, candidates, tt.usage); got != tt.want {
+				t.Errorf("closestCommand(%q) = %q, want %q", tt.typo, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAliasGenerator_GenerateSuggestions_TypoCorrections(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		parts := strings.Fields(cmdStr)
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: parts[0], PotentialArgs: parts[1:], EffectiveLength: len(strings.ReplaceAll(cmdStr, " ", ""))}
+	}
+	known := map[string]bool{"git": true, "docker": true, "ls": true}
+	resolver := &testutil.MockCommandResolver{
+		IsResolvableFunc:  func(name string) bool { return known[name] },
+		KnownCommandsFunc: func() []string { return []string{"docker", "git", "ls"} },
+	}
+	gen := NewAliasGenerator(mockAnalyzer, resolver, nil, nil, nil)
+
+	commands := []history.CommandFrequency{
+		{Command: "gti status", Count: 3},
+		{Command: "gti push", Count: 2},  // Counts add up per command name.
+		{Command: "dokcer ps", Count: 2}, // Below minFrequency.
+		{Command: "kgp", Count: 6},       // Close to the existing alias "kgpo".
+		{Command: "zzzzz", Count: 9},     // No close command.
+		{Command: "ls", Count: 1},
+	}
+	got := gen.GenerateSuggestions(commands, map[string]string{"kgpo": "kubectl get pods"}, 4)
+	want := []alias.Alias{
+		{Name: "gti", Command: "git", IsCorrection: true, Frequency: 5, Source: alias.SourceCorrection, Examples: []string{"gti status", "gti push"}},
+		{Name: "kgp", Command: "kgpo", IsCorrection: true, Frequency: 6, Source: alias.SourceCorrection, Examples: []string{"kgp"}},
+	}
+
+	sortAliases(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateSuggestions() got = %v, want %v", got, want)
+	}
+}
+
+func TestAliasGenerator_GenerateSuggestions_NoCorrectionsWithoutResolver(t *testing.T) {
+	mockAnalyzer := testutil.NewMockCommandAnalyzer()
+	mockAnalyzer.AnalyzeFunc = func(cmdStr string) command.AnalyzedCommand {
+		return command.AnalyzedCommand{Original: cmdStr, CommandName: cmdStr, EffectiveLength: len(cmdStr)}
+	}
+	gen := NewAliasGenerator(mockAnalyzer, nil, nil, nil, nil)
+
+	got := gen.GenerateSuggestions([]history.CommandFrequency{{Command: "gti", Count: 10}}, nil, 3)
+	for _, suggestion := range got {
+		if suggestion.IsCorrection {
+			t.Errorf("GenerateSuggestions() without resolver returned correction %v", suggestion)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package commandresolution
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// PathResolver resolves command names against the executables on PATH and the shell builtins.
+type PathResolver struct {
+	lookPath      func(file string) (string, error)
+	pathEnv       string
+	knownCommands []string // Cached result of KnownCommands.
+}
+
+// NewPathResolver creates a new PathResolver using the PATH of the current process.
+func NewPathResolver() ports.CommandResolver {
+	return &PathResolver{lookPath: exec.LookPath, pathEnv: os.Getenv("PATH")}
+}
+
+// IsResolvable implements the ports.CommandResolver interface.
+func (r *PathResolver) IsResolvable(name string) bool {
+	if name == "" {
+		return false
+	}
+	if !isJudgeableName(name) {
+		return true
+	}
+	if _, isBuiltin := shellBuiltins[name]; isBuiltin {
+		return true
+	}
+	_, err := r.lookPath(name)
+	return err == nil
+}
+
+// KnownCommands implements the ports.CommandResolver interface.
+// The PATH directories are only read once.
+func (r *PathResolver) KnownCommands() []string {
+	if r.knownCommands == nil {
+		r.knownCommands = listKnownCommands(r.pathEnv)
+	}
+	return r.knownCommands
+}
+
+// Locate implements the ports.CommandResolver interface.
+func (r *PathResolver) Locate(name string) (string, bool) {
+	_, isBuiltin := shellBuiltins[name]
+	if name == "" || !isJudgeableName(name) {
+		return "", isBuiltin
+	}
+	path, err := r.lookPath(name)
+	if err != nil {
+		return "", isBuiltin
+	}
+	return path, isBuiltin
+}
@@ -0,0 +1,75 @@
+package commandresolution
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/*
+shellBuiltins lists the builtins and reserved words of bash, zsh and fish that
+commonly start a command line. They never appear on PATH, but are not typos.
+*/
+var shellBuiltins = map[string]struct{}{
+	".": {}, ":": {}, "[": {}, "[[": {}, "alias": {}, "autoload": {}, "bg": {}, "bind": {},
+	"bindkey": {}, "break": {}, "builtin": {}, "case": {}, "cd": {}, "command": {},
+	"compdef": {}, "continue": {}, "declare": {}, "dirs": {}, "disown": {}, "echo": {},
+	"emulate": {}, "eval": {}, "exec": {}, "exit": {}, "export": {}, "false": {}, "fc": {},
+	"fg": {}, "for": {}, "function": {}, "functions": {}, "getopts": {}, "hash": {},
+	"help": {}, "history": {}, "if": {}, "jobs": {}, "kill": {}, "let": {}, "local": {},
+	"logout": {}, "noglob": {}, "popd": {}, "print": {}, "printf": {}, "pushd": {}, "pwd": {},
+	"read": {}, "readonly": {}, "rehash": {}, "return": {}, "set": {}, "setopt": {},
+	"shift": {}, "source": {}, "test": {}, "time": {}, "times": {}, "trap": {}, "true": {},
+	"type": {}, "typeset": {}, "ulimit": {}, "umask": {}, "unalias": {}, "unset": {},
+	"unsetopt": {}, "until": {}, "wait": {}, "whence": {}, "where": {}, "which": {},
+	"while": {}, "abbr": {}, "and": {}, "begin": {}, "contains": {}, "end": {}, "funced": {},
+	"funcsave": {}, "not": {}, "or": {}, "status": {}, "string": {}, "switch": {},
+}
+
+// isJudgeableName reports whether name is a plain command name that can be looked up.
+// Paths ("./run.sh", "~/bin/x"), variable assignments ("FOO=1") and expansions ("$EDITOR")
+// depend on the context the command was run in, so they are not judged.
+func isJudgeableName(name string) bool {
+	return !strings.ContainsAny(name, "/=$~")
+}
+
+// listKnownCommands returns the sorted, de-duplicated names of the shell builtins
+// and of the executable files in the directories of pathEnv.
+// Unreadable directories are skipped.
+func listKnownCommands(pathEnv string) []string {
+	seen := make(map[string]struct{}, len(shellBuiltins))
+	for name := range shellBuiltins {
+		seen[name] = struct{}{}
+	}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			info, err := entry.Info() // Does not follow symlinks, see below.
+			if err != nil {
+				continue
+			}
+			if info.Mode()&os.ModeSymlink != 0 {
+				if info, err = os.Stat(filepath.Join(dir, entry.Name())); err != nil {
+					continue
+				}
+			}
+			if info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0 {
+				seen[entry.Name()] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
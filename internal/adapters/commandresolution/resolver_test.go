@@ -0,0 +1,126 @@
+package commandresolution
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewPathResolver(t *testing.T) {
+	resolver := NewPathResolver()
+	if resolver == nil {
+		t.Fatal("NewPathResolver() returned nil")
+	}
+	if _, ok := resolver.(*PathResolver); !ok {
+		t.Errorf("NewPathResolver() did not return a *PathResolver, got %T", resolver)
+	}
+}
+
+func TestPathResolver_IsResolvable(t *testing.T) {
+	onPath := map[string]bool{"git": true, "make": true}
+	resolver := &PathResolver{lookPath: func(file string) (string, error) {
+		if onPath[file] {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}}
+
+	tests := []struct {
+		name string
+		cmd  string
+		want bool
+	}{
+		{name: "executable on PATH", cmd: "git", want: true},
+		{name: "typo", cmd: "gti", want: false},
+		{name: "bash builtin", cmd: "cd", want: true},
+		{name: "zsh builtin", cmd: "setopt", want: true},
+		{name: "fish builtin", cmd: "abbr", want: true},
+		{name: "relative path is not judged", cmd: "./run.sh", want: true},
+		{name: "home path is not judged", cmd: "~/bin/tool", want: true},
+		{name: "variable assignment is not judged", cmd: "FOO=1", want: true},
+		{name: "expansion is not judged", cmd: "$EDITOR", want: true},
+		{name: "empty name", cmd: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.IsResolvable(tt.cmd); got != tt.want {
+				t.Errorf("IsResolvable(%q) = %v, want %v", tt.cmd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathResolver_Locate(t *testing.T) {
+	onPath := map[string]bool{"git": true, "echo": true}
+	resolver := &PathResolver{lookPath: func(file string) (string, error) {
+		if onPath[file] {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}}
+
+	tests := []struct {
+		name        string
+		cmd         string
+		wantPath    string
+		wantBuiltin bool
+	}{
+		{name: "executable on PATH", cmd: "git", wantPath: "/usr/bin/git"},
+		{name: "builtin", cmd: "cd", wantBuiltin: true},
+		{name: "builtin also on PATH", cmd: "echo", wantPath: "/usr/bin/echo", wantBuiltin: true},
+		{name: "unknown", cmd: "gti"},
+		{name: "path is not looked up", cmd: "./run.sh"},
+		{name: "empty name", cmd: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, isBuiltin := resolver.Locate(tt.cmd)
+			if path != tt.wantPath || isBuiltin != tt.wantBuiltin {
+				t.Errorf("Locate(%q) = %q, %v, want %q, %v", tt.cmd, path, isBuiltin, tt.wantPath, tt.wantBuiltin)
+			}
+		})
+	}
+}
+
+func TestPathResolver_KnownCommands(t *testing.T) {
+	binDir := t.TempDir()
+	otherBinDir := t.TempDir()
+	writeFile := func(dir, name string, perm os.FileMode) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), perm); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	writeFile(binDir, "git", 0o755)
+	writeFile(binDir, "README", 0o644) // Not executable.
+	writeFile(otherBinDir, "git", 0o755)
+	writeFile(otherBinDir, "kubectl", 0o755)
+	if err := os.Symlink(filepath.Join(otherBinDir, "kubectl"), filepath.Join(binDir, "k")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(binDir, "subdir"), 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	missingDir := filepath.Join(t.TempDir(), "missing")
+	resolver := &PathResolver{pathEnv: strings.Join([]string{binDir, missingDir, otherBinDir}, string(os.PathListSeparator))}
+	got := resolver.KnownCommands()
+
+	for _, want := range []string{"git", "k", "kubectl", "cd", "setopt"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("KnownCommands() does not contain %q", want)
+		}
+	}
+	for _, unwanted := range []string{"README", "subdir"} {
+		if slices.Contains(got, unwanted) {
+			t.Errorf("KnownCommands() unexpectedly contains %q", unwanted)
+		}
+	}
+	if !slices.IsSorted(got) || len(slices.Compact(slices.Clone(got))) != len(got) {
+		t.Errorf("KnownCommands() = %v, want sorted names without duplicates", got)
+	}
+}
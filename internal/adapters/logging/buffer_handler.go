@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// bufferedRecord is a record held by a bufferHandler, with the handler to pass it to.
+type bufferedRecord struct {
+	handler slog.Handler
+	record  slog.Record
+}
+
+// recordBuffer holds the records logged before the logging is configured.
+type recordBuffer struct {
+	mu        sync.Mutex
+	buffering bool
+	records   []bufferedRecord
+}
+
+// bufferHandler is a slog.Handler holding every record until its buffer is flushed,
+// then passing records straight to its handler. Records logged while the dependencies
+// are wired are only handled once the command line's verbosity and log file are known.
+type bufferHandler struct {
+	handler slog.Handler
+	buffer  *recordBuffer
+}
+
+// newBufferHandler creates a bufferHandler holding the records passed to handler.
+func newBufferHandler(handler slog.Handler) *bufferHandler {
+	return &bufferHandler{handler: handler, buffer: &recordBuffer{buffering: true}}
+}
+
+// Enabled reports whether handler is enabled for level. While buffering, every level
+// is, as it is not known yet which ones will be.
+func (h *bufferHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	h.buffer.mu.Lock()
+	buffering := h.buffer.buffering
+	h.buffer.mu.Unlock()
+	return buffering || h.handler.Enabled(ctx, level)
+}
+
+// Handle holds r while buffering, and passes it to handler otherwise.
+func (h *bufferHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.buffer.mu.Lock()
+	if h.buffer.buffering {
+		h.buffer.records = append(h.buffer.records, bufferedRecord{handler: h.handler, record: r.Clone()})
+		h.buffer.mu.Unlock()
+		return nil
+	}
+	h.buffer.mu.Unlock()
+	return h.handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a bufferHandler, sharing h's buffer, whose handler adds attrs to every record.
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &bufferHandler{handler: h.handler.WithAttrs(attrs), buffer: h.buffer}
+}
+
+// WithGroup returns a bufferHandler, sharing h's buffer, whose handler qualifies later attributes with name.
+func (h *bufferHandler) WithGroup(name string) slog.Handler {
+	return &bufferHandler{handler: h.handler.WithGroup(name), buffer: h.buffer}
+}
+
+// flush stops buffering and passes the held records to their handlers, if enabled for their level.
+func (h *bufferHandler) flush() error {
+	h.buffer.mu.Lock()
+	records := h.buffer.records
+	h.buffer.records, h.buffer.buffering = nil, false
+	h.buffer.mu.Unlock()
+
+	var errs []error
+	for _, held := range records {
+		if held.handler.Enabled(context.Background(), held.record.Level) {
+			errs = append(errs, held.handler.Handle(context.Background(), held.record))
+		}
+	}
+	return errors.Join(errs...)
+}
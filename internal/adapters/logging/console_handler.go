@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// levelPrefixes are printed before the message of each record, as nicksh printed warnings before.
+var levelPrefixes = map[slog.Level]string{
+	slog.LevelDebug: "Debug: ",
+	slog.LevelInfo:  "",
+	slog.LevelWarn:  "Warning: ",
+	slog.LevelError: "Error: ",
+}
+
+// ConsoleHandler is a slog.Handler writing one human-readable line per record,
+// e.g. "Warning: could not read aliases key=value", without a timestamp.
+type ConsoleHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	level  slog.Leveler
+	attrs  string // Pre-formatted attributes added with WithAttrs.
+	prefix string // Group prefix added with WithGroup, e.g. "registry.".
+}
+
+// NewConsoleHandler creates a handler writing records of at least level to w.
+func NewConsoleHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &ConsoleHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+// Enabled reports whether records of level are written.
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes the record as a single line.
+func (h *ConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(levelPrefix(r.Level))
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&b, h.prefix, a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// WithAttrs returns a handler adding attrs to every record.
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		appendAttr(&b, h.prefix, a)
+	}
+	clone := *h
+	clone.attrs = b.String()
+	return &clone
+}
+
+// WithGroup returns a handler qualifying the keys of later attributes with name.
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// levelPrefix returns the prefix of level, e.g. "Warning: ".
+func levelPrefix(level slog.Level) string {
+	if prefix, ok := levelPrefixes[level]; ok {
+		return prefix
+	}
+	return level.String() + ": "
+}
+
+// appendAttr writes a as " key=value", expanding groups into dotted keys.
+func appendAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendAttr(b, groupPrefix, ga)
+		}
+		return
+	}
+	value := a.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+	fmt.Fprintf(b, " %s%s=%s", prefix, a.Key, value)
+}
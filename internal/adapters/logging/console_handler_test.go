@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestConsoleHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(logger *slog.Logger)
+		want string
+	}{
+		{
+			name: "warning with attributes",
+			log: func(l *slog.Logger) {
+				l.Warn("could not read aliases", "file", "~/.nicksh/git", "error", "permission denied")
+			},
+			want: "Warning: could not read aliases file=~/.nicksh/git error=\"permission denied\"\n",
+		},
+		{
+			name: "below the level is dropped",
+			log:  func(l *slog.Logger) { l.Debug("skipped history line", "line", 3) },
+			want: "",
+		},
+		{
+			name: "info has no prefix",
+			log:  func(l *slog.Logger) { l.Info("loaded") },
+			want: "loaded\n",
+		},
+		{
+			name: "attributes and groups",
+			log: func(l *slog.Logger) {
+				l.With("source", "registry").WithGroup("http").Error("request failed", "status", 503)
+			},
+			want: "Error: request failed source=registry http.status=503\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(slog.New(NewConsoleHandler(&buf, slog.LevelInfo)))
+			if got := buf.String(); got != tt.want {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
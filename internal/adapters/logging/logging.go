@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// verbosityLevels maps each verbosity to the minimum level logged to the console.
+var verbosityLevels = map[ports.LogVerbosity]slog.Level{
+	ports.LogQuiet:   slog.LevelError,
+	ports.LogNormal:  slog.LevelWarn,
+	ports.LogVerbose: slog.LevelInfo,
+	ports.LogDebug:   slog.LevelDebug,
+}
+
+// Logging implements the LogConfigurator interface. Its Logger writes human-readable
+// lines to the console and, once a log file is configured, JSON records to that file.
+// Records logged before the logging is configured are held until it is (or until Flush),
+// so they honor the command line's verbosity and log file.
+type Logging struct {
+	consoleLevel *slog.LevelVar
+	fileLevel    *slog.LevelVar
+	file         *fileWriter
+	buffer       *bufferHandler
+	logger       *slog.Logger
+}
+
+// NewLogging creates a Logging writing warnings and errors to console, until configured otherwise.
+func NewLogging(console io.Writer) *Logging {
+	consoleLevel := &slog.LevelVar{}
+	consoleLevel.Set(verbosityLevels[ports.LogNormal])
+	fileLevel := &slog.LevelVar{}
+	file := &fileWriter{}
+	buffer := newBufferHandler(&fanoutHandler{handlers: []slog.Handler{
+		NewConsoleHandler(console, consoleLevel),
+		slog.NewJSONHandler(file, &slog.HandlerOptions{Level: &fileGate{file: file, level: fileLevel}}),
+	}})
+	return &Logging{consoleLevel: consoleLevel, fileLevel: fileLevel, file: file, buffer: buffer, logger: slog.New(buffer)}
+}
+
+// Logger returns the logger to hand to the adapters and services.
+func (l *Logging) Logger() *slog.Logger {
+	return l.logger
+}
+
+// Flush logs the records held since the Logging was created, with the current verbosity,
+// and stops holding records. ConfigureLogging flushes them too; Flush is for commands that
+// end before the logging is configured, e.g. on a command line error.
+func (l *Logging) Flush() {
+	_ = l.buffer.flush() // The console and log file handlers don't report write errors either.
+}
+
+// ConfigureLogging implements the ports.LogConfigurator interface.
+// The records held until now are logged once the verbosity and log file are set.
+func (l *Logging) ConfigureLogging(verbosity ports.LogVerbosity, logFile string) error {
+	defer l.Flush()
+	level, ok := verbosityLevels[verbosity]
+	if !ok {
+		return fmt.Errorf("unknown log verbosity %d", verbosity)
+	}
+	l.consoleLevel.Set(level)
+	l.fileLevel.Set(min(level, slog.LevelInfo))
+
+	if logFile == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
+		return fmt.Errorf("failed to create the directory of log file %s: %w", logFile, err)
+	}
+	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %w", logFile, err)
+	}
+	l.file.setFile(f)
+	return nil
+}
+
+// fileWriter is the log file, which is opened after the logger is created.
+// Writes are dropped while no file is set.
+type fileWriter struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (w *fileWriter) setFile(f *os.File) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f != nil {
+		w.f.Close()
+	}
+	w.f = f
+}
+
+func (w *fileWriter) isSet() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f != nil
+}
+
+// Write implements io.Writer. Each JSON record is written in a single call,
+// and the file is opened in append mode, so records are not interleaved.
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return len(p), nil
+	}
+	return w.f.Write(p)
+}
+
+// fileGate is the level of the log file handler: above every level while no file is set,
+// so records are not even formatted.
+type fileGate struct {
+	file  *fileWriter
+	level *slog.LevelVar
+}
+
+// Level implements slog.Leveler.
+func (g *fileGate) Level() slog.Level {
+	if !g.file.isSet() {
+		return slog.Level(1 << 30)
+	}
+	return g.level.Level()
+}
+
+var _ ports.LogConfigurator = (*Logging)(nil)
@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// logEveryLevel logs one record per level, with the logger the adapters get.
+func logEveryLevel(l *Logging) {
+	logger := l.Logger().With("source", "test")
+	logger.Debug("debug record")
+	logger.Info("info record")
+	logger.Warn("warn record")
+	logger.Error("error record")
+}
+
+func TestLogging_ConfigureLogging(t *testing.T) {
+	tests := []struct {
+		name        string
+		verbosity   ports.LogVerbosity
+		wantConsole []string
+		wantFile    []string
+	}{
+		{name: "quiet", verbosity: ports.LogQuiet, wantConsole: []string{"error"}, wantFile: []string{"info", "warn", "error"}},
+		{name: "normal", verbosity: ports.LogNormal, wantConsole: []string{"warn", "error"}, wantFile: []string{"info", "warn", "error"}},
+		{name: "verbose", verbosity: ports.LogVerbose, wantConsole: []string{"info", "warn", "error"}, wantFile: []string{"info", "warn", "error"}},
+		{name: "debug", verbosity: ports.LogDebug, wantConsole: []string{"debug", "info", "warn", "error"}, wantFile: []string{"debug", "info", "warn", "error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var console bytes.Buffer
+			l := NewLogging(&console)
+			logFile := filepath.Join(t.TempDir(), "logs", "nicksh.log")
+			if err := l.ConfigureLogging(tt.verbosity, logFile); err != nil {
+				t.Fatalf("ConfigureLogging() unexpected error: %v", err)
+			}
+			logEveryLevel(l)
+
+			var gotConsole []string
+			for _, line := range strings.Split(strings.TrimSpace(console.String()), "\n") {
+				fields := strings.Fields(line)
+				gotConsole = append(gotConsole, fields[len(fields)-3])
+			}
+			if strings.Join(gotConsole, ",") != strings.Join(tt.wantConsole, ",") {
+				t.Errorf("console got %q, want records %v", console.String(), tt.wantConsole)
+			}
+
+			content, err := os.ReadFile(logFile)
+			if err != nil {
+				t.Fatalf("failed to read log file: %v", err)
+			}
+			var gotFile []string
+			for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+				var record struct {
+					Msg    string `json:"msg"`
+					Source string `json:"source"`
+				}
+				if err := json.Unmarshal([]byte(line), &record); err != nil || record.Source != "test" {
+					t.Fatalf("log file line %q is not a JSON record with its attributes: %v", line, err)
+				}
+				gotFile = append(gotFile, strings.TrimSuffix(record.Msg, " record"))
+			}
+			if strings.Join(gotFile, ",") != strings.Join(tt.wantFile, ",") {
+				t.Errorf("log file got %v, want %v", gotFile, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestLogging_WithoutLogFile(t *testing.T) {
+	var console bytes.Buffer
+	l := NewLogging(&console)
+	logEveryLevel(l)
+	if console.Len() != 0 {
+		t.Errorf("console got %q before the logging was configured or flushed, want nothing", console.String())
+	}
+	l.Flush()
+	want := "Warning: warn record source=test\nError: error record source=test\n"
+	if console.String() != want {
+		t.Errorf("console got %q, want %q", console.String(), want)
+	}
+	logEveryLevel(l)
+	if console.String() != want+want {
+		t.Errorf("console got %q after the flush, want records logged right away", console.String())
+	}
+
+	if err := l.ConfigureLogging(ports.LogVerbosity(7), ""); err == nil {
+		t.Error("ConfigureLogging() with an unknown verbosity: want an error")
+	}
+}
+
+func TestLogging_RecordsBeforeConfiguration(t *testing.T) {
+	var console bytes.Buffer
+	l := NewLogging(&console)
+	logEveryLevel(l) // E.g. warnings while the dependencies are wired, before the flags are parsed.
+
+	logFile := filepath.Join(t.TempDir(), "nicksh.log")
+	if err := l.ConfigureLogging(ports.LogQuiet, logFile); err != nil {
+		t.Fatalf("ConfigureLogging() unexpected error: %v", err)
+	}
+
+	if want := "Error: error record source=test\n"; console.String() != want {
+		t.Errorf("console got %q, want only %q with quiet verbosity", console.String(), want)
+	}
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	for _, msg := range []string{"info record", "warn record", "error record"} {
+		if !strings.Contains(string(content), msg) {
+			t.Errorf("log file %q does not contain the %q logged before it was configured", content, msg)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package namingconventions
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed conventions.yaml
+var embeddedConventions []byte
+
+// allTools is the tool name that stands for every tool in DisableTools.
+const allTools = "all"
+
+// YAMLProvider implements the NamingConventionProvider interface
+// by reading conventions from an embedded YAML file.
+type YAMLProvider struct {
+	conventions   map[string][]alias.Alias // Keyed by tool.
+	disabledTools map[string]bool
+}
+
+// NewYAMLProvider creates a new YAMLProvider with every tool enabled.
+// It returns an error if the embedded convention table cannot be parsed.
+func NewYAMLProvider() (ports.NamingConventionProvider, error) {
+	conventions, err := parseConventions(embeddedConventions)
+	if err != nil {
+		return nil, err
+	}
+	return &YAMLProvider{conventions: conventions, disabledTools: make(map[string]bool)}, nil
+}
+
+// GetConventionalNames implements the ports.NamingConventionProvider interface.
+// Commands are keyed with their whitespace normalized to single spaces.
+func (p *YAMLProvider) GetConventionalNames() (map[string]string, error) {
+	names := make(map[string]string)
+	for tool, conventions := range p.conventions {
+		if p.disabledTools[tool] {
+			continue
+		}
+		for _, convention := range conventions {
+			names[strings.Join(strings.Fields(convention.Command), " ")] = convention.Name
+		}
+	}
+	return names, nil
+}
+
+// AvailableTools implements the ports.NamingConventionProvider interface.
+func (p *YAMLProvider) AvailableTools() []string {
+	tools := make([]string, 0, len(p.conventions))
+	for tool := range p.conventions {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// DisableTools implements the ports.NamingConventionProvider interface.
+func (p *YAMLProvider) DisableTools(tools []string) error {
+	for _, tool := range tools {
+		tool = strings.TrimSpace(tool)
+		switch {
+		case tool == "":
+			continue
+		case tool == allTools:
+			for name := range p.conventions {
+				p.disabledTools[name] = true
+			}
+		case p.conventions[tool] != nil:
+			p.disabledTools[tool] = true
+		default:
+			return fmt.Errorf("unknown naming convention tool '%s' (available: %s, %s)", tool, allTools, strings.Join(p.AvailableTools(), ", "))
+		}
+	}
+	return nil
+}
+
+// parseConventions parses a convention table: a map of tool names to lists of aliases.
+func parseConventions(content []byte) (map[string][]alias.Alias, error) {
+	conventions := map[string][]alias.Alias{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true) // Catches typos in the YAML structure.
+	if err := decoder.Decode(&conventions); err != nil {
+		if errors.Is(err, io.EOF) {
+			// An empty file means no conventions.
+			return map[string][]alias.Alias{}, nil
+		}
+		return nil, fmt.Errorf("failed to unmarshal embedded naming conventions: %w", err)
+	}
+	return conventions, nil
+}
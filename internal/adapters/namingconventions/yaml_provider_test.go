@@ -0,0 +1,120 @@
+package namingconventions
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+)
+
+func TestNewYAMLProvider(t *testing.T) {
+	provider, err := NewYAMLProvider()
+	if err != nil {
+		t.Fatalf("NewYAMLProvider() unexpected error = %v", err)
+	}
+	if _, ok := provider.(*YAMLProvider); !ok {
+		t.Errorf("NewYAMLProvider() did not return a *YAMLProvider, got %T", provider)
+	}
+}
+
+func TestEmbeddedConventions(t *testing.T) {
+	conventions, err := parseConventions(embeddedConventions)
+	if err != nil {
+		t.Fatalf("parseConventions(embedded) unexpected error = %v", err)
+	}
+	// Generated alias names must be alphanumeric (dots allowed), see the alias generator.
+	validName := regexp.MustCompile(`^[a-zA-Z0-9.]{2,}$`)
+	for tool, entries := range conventions {
+		if len(entries) == 0 {
+			t.Errorf("tool %q has no conventions", tool)
+		}
+		for _, entry := range entries {
+			if entry.Command == "" || !validName.MatchString(entry.Name) {
+				t.Errorf("tool %q has an invalid convention %+v", tool, entry)
+			}
+		}
+	}
+}
+
+func TestParseConventions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string][]alias.Alias
+		wantErr string
+	}{
+		{
+			name:    "tools with aliases",
+			content: "git:\n  - command: git status\n    alias: gst\nkubectl:\n  - command: kubectl get pods\n    alias: kgp\n",
+			want: map[string][]alias.Alias{
+				"git":     {{Command: "git status", Name: "gst"}},
+				"kubectl": {{Command: "kubectl get pods", Name: "kgp"}},
+			},
+		},
+		{name: "empty content", content: "", want: map[string][]alias.Alias{}},
+		{name: "unknown field", content: "git:\n  - command: git status\n    name: gst\n", wantErr: "failed to unmarshal embedded naming conventions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseConventions([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseConventions() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseConventions() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseConventions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLProvider_DisableTools(t *testing.T) {
+	newProvider := func() *YAMLProvider {
+		return &YAMLProvider{
+			conventions: map[string][]alias.Alias{
+				"git":     {{Command: "git  status", Name: "gst"}},
+				"kubectl": {{Command: "kubectl get pods", Name: "kgp"}},
+			},
+			disabledTools: make(map[string]bool),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		disable []string
+		want    map[string]string
+		wantErr string
+	}{
+		{name: "all tools enabled", want: map[string]string{"git status": "gst", "kubectl get pods": "kgp"}},
+		{name: "one tool disabled", disable: []string{"kubectl"}, want: map[string]string{"git status": "gst"}},
+		{name: "all disabled", disable: []string{"all"}, want: map[string]string{}},
+		{name: "blank names are ignored", disable: []string{" ", ""}, want: map[string]string{"git status": "gst", "kubectl get pods": "kgp"}},
+		{name: "unknown tool", disable: []string{"npm"}, wantErr: "unknown naming convention tool 'npm' (available: all, git, kubectl)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newProvider()
+			err := provider.DisableTools(tt.disable)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("DisableTools() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DisableTools() unexpected error = %v", err)
+			}
+			got, _ := provider.GetConventionalNames()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetConventionalNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
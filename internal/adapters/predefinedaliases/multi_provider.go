@@ -0,0 +1,55 @@
+package predefinedaliases
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MultiProvider implements the PredefinedAliasProvider interface by combining
+// the aliases of several providers, e.g. a team registry and the embedded set.
+type MultiProvider struct {
+	providers []ports.PredefinedAliasProvider
+	logger    ports.Logger
+}
+
+// NewMultiProvider creates a new MultiProvider. When providers define the same
+// alias name, the definition of the earliest provider is kept.
+// logger receives the warnings about failing providers, and can be nil.
+func NewMultiProvider(logger ports.Logger, providers ...ports.PredefinedAliasProvider) ports.PredefinedAliasProvider {
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &MultiProvider{providers: providers, logger: logger}
+}
+
+// GetPredefinedAliases returns the aliases of every provider.
+// A failing provider is skipped with a warning, unless every provider fails.
+func (p *MultiProvider) GetPredefinedAliases() ([]alias.Alias, error) {
+	combined := []alias.Alias{}
+	seen := make(map[string]bool)
+	var errs []error
+	for _, provider := range p.providers {
+		aliases, err := provider.GetPredefinedAliases()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, a := range aliases {
+			if seen[a.Name] {
+				continue
+			}
+			seen[a.Name] = true
+			combined = append(combined, a)
+		}
+	}
+
+	if len(errs) > 0 && len(errs) == len(p.providers) {
+		return nil, fmt.Errorf("failed to load predefined aliases: %w", errs[0])
+	}
+	for _, err := range errs {
+		p.logger.Warn("could not load predefined aliases", "error", err)
+	}
+	return combined, nil
+}
@@ -0,0 +1,66 @@
+package predefinedaliases
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// stubProvider is a PredefinedAliasProvider returning fixed results.
+type stubProvider struct {
+	aliases []alias.Alias
+	err     error
+}
+
+func (s stubProvider) GetPredefinedAliases() ([]alias.Alias, error) { return s.aliases, s.err }
+
+func TestMultiProvider_GetPredefinedAliases(t *testing.T) {
+	team := stubProvider{aliases: []alias.Alias{{Name: "gs", Command: "git status -sb"}}}
+	builtin := stubProvider{aliases: []alias.Alias{{Name: "gs", Command: "git status"}, {Name: "ll", Command: "ls -l"}}}
+	failing := stubProvider{err: errors.New("registry unreachable")}
+
+	tests := []struct {
+		name         string
+		providers    []ports.PredefinedAliasProvider
+		want         []alias.Alias
+		wantErr      bool
+		wantWarnings int
+	}{
+		{
+			name:      "earlier providers win",
+			providers: []ports.PredefinedAliasProvider{team, builtin},
+			want:      []alias.Alias{{Name: "gs", Command: "git status -sb"}, {Name: "ll", Command: "ls -l"}},
+		},
+		{
+			name:         "failing provider is skipped",
+			providers:    []ports.PredefinedAliasProvider{failing, builtin},
+			want:         builtin.aliases,
+			wantWarnings: 1,
+		},
+		{
+			name:      "every provider failing is an error",
+			providers: []ports.PredefinedAliasProvider{failing},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &testutil.MockLogger{}
+			got, err := NewMultiProvider(logger, tt.providers...).GetPredefinedAliases()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPredefinedAliases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPredefinedAliases() = %+v, want %+v", got, tt.want)
+			}
+			if warnings := logger.EntriesAt("WARN"); len(warnings) != tt.wantWarnings {
+				t.Errorf("GetPredefinedAliases() logged %q, want %d warning(s)", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// SchemaVersion is the version of the catalog format. Clients refuse catalogs with a newer schema.
+const SchemaVersion = 1
+
+// Catalog is the versioned set of alias packs served by an alias registry.
+// Version changes whenever the content of any pack changes.
+type Catalog struct {
+	Schema  int    `json:"schema"`
+	Version string `json:"version"`
+	Packs   []Pack `json:"packs"`
+}
+
+// Pack is a named set of aliases, e.g. the aliases approved for git.
+type Pack struct {
+	Name    string        `json:"name"`
+	Aliases []alias.Alias `json:"aliases"`
+}
+
+// packFormats maps the extensions of pack files to their alias file format.
+var packFormats = map[string]string{
+	".yaml": ports.AliasFileFormatYAML,
+	".yml":  ports.AliasFileFormatYAML,
+	".json": ports.AliasFileFormatJSON,
+}
+
+// loadCatalog builds the catalog of the pack files in dir, in file name order.
+// The pack name is the file name without its extension; other files are ignored.
+func loadCatalog(dir string, codec ports.AliasFileCodec) (Catalog, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Catalog{}, fmt.Errorf("failed to read pack directory %s: %w", dir, err)
+	}
+
+	catalog := Catalog{Schema: SchemaVersion, Packs: []Pack{}}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		format, isPack := packFormats[ext]
+		if entry.IsDir() || !isPack {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return Catalog{}, fmt.Errorf("failed to read pack %s: %w", entry.Name(), err)
+		}
+		aliases, err := codec.Decode(data, format)
+		if err != nil {
+			return Catalog{}, fmt.Errorf("invalid pack %s: %w", entry.Name(), err)
+		}
+		catalog.Packs = append(catalog.Packs, Pack{Name: strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), Aliases: aliases})
+	}
+	sort.Slice(catalog.Packs, func(i, j int) bool { return catalog.Packs[i].Name < catalog.Packs[j].Name })
+
+	// The version is derived from the content, so it changes exactly when a pack does.
+	packsJSON, err := json.Marshal(catalog.Packs)
+	if err != nil {
+		return Catalog{}, fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	sum := sha256.Sum256(packsJSON)
+	catalog.Version = hex.EncodeToString(sum[:])[:12]
+	return catalog, nil
+}
+
+// parseCatalog decodes a catalog served by a registry.
+func parseCatalog(data []byte) (Catalog, error) {
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return Catalog{}, fmt.Errorf("invalid alias catalog: %w", err)
+	}
+	if catalog.Schema > SchemaVersion {
+		return Catalog{}, fmt.Errorf("unsupported alias catalog schema %d: this version of nicksh reads schema %d; please upgrade", catalog.Schema, SchemaVersion)
+	}
+	return catalog, nil
+}
+
+// aliases returns the aliases of every pack, in pack order. When packs define the
+// same alias name, the first definition is kept.
+func (c Catalog) aliases() []alias.Alias {
+	aliases := []alias.Alias{}
+	seen := make(map[string]bool)
+	for _, pack := range c.Packs {
+		for _, a := range pack.Aliases {
+			if a.Name == "" || a.Command == "" || seen[a.Name] {
+				continue
+			}
+			seen[a.Name] = true
+			aliases = append(aliases, a)
+		}
+	}
+	return aliases
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+const (
+	cacheDirName   = ".nicksh/cache"
+	requestTimeout = 5 * time.Second
+	maxCatalogSize = 10 << 20 // Catalogs are small; this only guards against runaway responses.
+)
+
+// HTTPProvider implements the PredefinedAliasProvider interface by fetching the
+// alias catalog of a registry (see NewHandler) over HTTP.
+// The last catalog received is cached with its ETag, so an unchanged catalog is
+// not downloaded again, and the cache is used when the registry is unreachable.
+type HTTPProvider struct {
+	url      string
+	cacheDir string
+	client   *http.Client
+	logger   ports.Logger // Can be nil, in which case nothing is logged.
+}
+
+// NewHTTPProvider creates a new HTTPProvider for the catalog at url, cached in $HOME/.nicksh/cache.
+// logger receives the warnings about falling back to the cache, and can be nil.
+func NewHTTPProvider(url string, logger ports.Logger) (ports.PredefinedAliasProvider, error) {
+	if url == "" {
+		return nil, fmt.Errorf("alias registry URL is empty")
+	}
+	usr, err := user.Current()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	return &HTTPProvider{
+		url:      url,
+		cacheDir: filepath.Join(usr.HomeDir, cacheDirName),
+		client:   &http.Client{Timeout: requestTimeout},
+		logger:   logger,
+	}, nil
+}
+
+// log returns the provider's logger, or ports.NopLogger if it has none.
+func (p *HTTPProvider) log() ports.Logger {
+	if p.logger == nil {
+		return ports.NopLogger
+	}
+	return p.logger
+}
+
+// GetPredefinedAliases returns the aliases of the registry catalog.
+// It falls back to the cached catalog, with a warning, when the registry cannot be
+// reached or answers with an error.
+func (p *HTTPProvider) GetPredefinedAliases() ([]alias.Alias, error) {
+	cached := p.readCache() // nil when nothing usable is cached.
+
+	req, err := http.NewRequest(http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias registry URL %s: %w", p.url, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	p.log().Debug("fetching alias catalog", "url", p.url, "cached", cached != nil)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return p.fallback(cached, fmt.Errorf("could not reach alias registry %s: %w", p.url, err))
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if cached == nil {
+			return nil, fmt.Errorf("alias registry %s answered 304 Not Modified, but no catalog is cached", p.url)
+		}
+		p.log().Info("alias catalog unchanged, using the cache", "url", p.url, "version", cached.Catalog.Version)
+		return cached.Catalog.aliases(), nil
+	case http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCatalogSize))
+		if err != nil {
+			return p.fallback(cached, fmt.Errorf("failed to read catalog from alias registry %s: %w", p.url, err))
+		}
+		catalog, err := parseCatalog(body)
+		if err != nil {
+			return p.fallback(cached, fmt.Errorf("alias registry %s: %w", p.url, err))
+		}
+		if err := p.writeCache(resp.Header.Get("ETag"), catalog); err != nil {
+			p.log().Warn("could not cache the alias catalog", "error", err)
+		}
+		p.log().Info("fetched alias catalog", "url", p.url, "version", catalog.Version, "packs", len(catalog.Packs))
+		return catalog.aliases(), nil
+	default:
+		return p.fallback(cached, fmt.Errorf("alias registry %s answered %s", p.url, resp.Status))
+	}
+}
+
+// fallback returns the aliases of the cached catalog, warning about fetchErr,
+// or fetchErr itself when nothing is cached.
+func (p *HTTPProvider) fallback(cached *cachedCatalog, fetchErr error) ([]alias.Alias, error) {
+	if cached == nil {
+		return nil, fetchErr
+	}
+	p.log().Warn(fmt.Sprintf("%v. Using the cached alias catalog (version %s).", fetchErr, cached.Catalog.Version))
+	return cached.Catalog.aliases(), nil
+}
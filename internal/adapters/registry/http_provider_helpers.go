@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// cachedCatalog is the content of a cache file: the last catalog received from a registry.
+type cachedCatalog struct {
+	URL     string  `json:"url"`
+	ETag    string  `json:"etag,omitempty"`
+	Catalog Catalog `json:"catalog"`
+}
+
+// cachePath returns the path of the cache file of the provider's registry.
+// Each registry URL has its own file, so switching registries never mixes catalogs.
+func (p *HTTPProvider) cachePath() string {
+	sum := sha256.Sum256([]byte(p.url))
+	return filepath.Join(p.cacheDir, "registry-"+hex.EncodeToString(sum[:])[:16]+".json")
+}
+
+// readCache returns the cached catalog of the provider's registry, or nil if there is
+// none. A corrupt or outdated cache file is ignored, as it is rewritten on the next fetch.
+func (p *HTTPProvider) readCache() *cachedCatalog {
+	data, err := os.ReadFile(p.cachePath())
+	if err != nil {
+		return nil
+	}
+	var cached cachedCatalog
+	if err := json.Unmarshal(data, &cached); err != nil || cached.URL != p.url || cached.Catalog.Schema > SchemaVersion {
+		return nil
+	}
+	return &cached
+}
+
+// writeCache stores catalog, received with etag, as the cached catalog of the provider's registry.
+func (p *HTTPProvider) writeCache(etag string, catalog Catalog) error {
+	data, err := json.Marshal(cachedCatalog{URL: p.url, ETag: etag, Catalog: catalog})
+	if err != nil {
+		return fmt.Errorf("failed to encode catalog cache: %w", err)
+	}
+	if err := os.MkdirAll(p.cacheDir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", p.cacheDir, err)
+	}
+
+	// Write to a temporary file first, so a concurrent reader never sees a partial cache.
+	tmp, err := os.CreateTemp(p.cacheDir, "registry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p.cachePath()); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/adapters/aliasfile"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// writePack writes a pack file to dir.
+func writePack(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write pack %s: %v", name, err)
+	}
+}
+
+// registryServer serves the packs of dir and counts the catalogs it sends in full.
+func registryServer(t *testing.T, dir string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fullResponses atomic.Int32
+	handler := NewHandler(dir, aliasfile.NewCodec(), nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		if rec.Code == http.StatusOK {
+			fullResponses.Add(1)
+		}
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server, &fullResponses
+}
+
+// newTestProvider creates an HTTPProvider for url, cached in cacheDir.
+func newTestProvider(url, cacheDir string) *HTTPProvider {
+	return &HTTPProvider{url: url, cacheDir: cacheDir, client: &http.Client{Timeout: time.Second}}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	writePack(t, dir, "k8s.json", `[{"command":"kubectl","alias":"k"}]`)
+	writePack(t, dir, "git.yaml", "- command: git status\n  alias: gs\n")
+	writePack(t, dir, "README.md", "not a pack")
+
+	catalog, err := loadCatalog(dir, aliasfile.NewCodec())
+	if err != nil {
+		t.Fatalf("loadCatalog() unexpected error: %v", err)
+	}
+	want := []Pack{
+		{Name: "git", Aliases: []alias.Alias{{Name: "gs", Command: "git status"}}},
+		{Name: "k8s", Aliases: []alias.Alias{{Name: "k", Command: "kubectl"}}},
+	}
+	if !reflect.DeepEqual(catalog.Packs, want) || catalog.Schema != SchemaVersion || catalog.Version == "" {
+		t.Errorf("loadCatalog() = %+v, want packs %+v with a version", catalog, want)
+	}
+
+	again, _ := loadCatalog(dir, aliasfile.NewCodec())
+	writePack(t, dir, "git.yaml", "- command: git status -sb\n  alias: gs\n")
+	changed, _ := loadCatalog(dir, aliasfile.NewCodec())
+	if again.Version != catalog.Version || changed.Version == catalog.Version {
+		t.Errorf("loadCatalog() versions %q, %q, %q: want stable while unchanged, new after a change", catalog.Version, again.Version, changed.Version)
+	}
+
+	writePack(t, dir, "broken.yaml", "- commnd: oops\n")
+	if _, err := loadCatalog(dir, aliasfile.NewCodec()); err == nil || !strings.Contains(err.Error(), "invalid pack broken.yaml") {
+		t.Errorf("loadCatalog() error = %v, want an invalid pack error", err)
+	}
+}
+
+func TestCatalog_Aliases(t *testing.T) {
+	catalog := Catalog{Packs: []Pack{
+		{Name: "a", Aliases: []alias.Alias{{Name: "gs", Command: "git status"}, {Name: "", Command: "ignored"}}},
+		{Name: "b", Aliases: []alias.Alias{{Name: "gs", Command: "git status -sb"}, {Name: "k", Command: "kubectl"}}},
+	}}
+	want := []alias.Alias{{Name: "gs", Command: "git status"}, {Name: "k", Command: "kubectl"}}
+	if got := catalog.aliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("aliases() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	if _, err := parseCatalog([]byte(`{"schema":2,"version":"x","packs":[]}`)); err == nil || !strings.Contains(err.Error(), "unsupported alias catalog schema 2") {
+		t.Errorf("parseCatalog() error = %v, want an unsupported schema error", err)
+	}
+	if _, err := parseCatalog([]byte(`<html>`)); err == nil || !strings.Contains(err.Error(), "invalid alias catalog") {
+		t.Errorf("parseCatalog() error = %v, want an invalid catalog error", err)
+	}
+}
+
+func TestHTTPProvider_GetPredefinedAliases(t *testing.T) {
+	packsDir := t.TempDir()
+	writePack(t, packsDir, "git.yaml", "- command: git status\n  alias: gs\n")
+	server, fullResponses := registryServer(t, packsDir)
+	provider := newTestProvider(server.URL+CatalogPath, t.TempDir())
+
+	got, err := provider.GetPredefinedAliases()
+	if err != nil {
+		t.Fatalf("first GetPredefinedAliases() unexpected error: %v", err)
+	}
+	if want := []alias.Alias{{Name: "gs", Command: "git status"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first GetPredefinedAliases() = %+v, want %+v", got, want)
+	}
+
+	// Unchanged catalog: the cached ETag gets a 304, and the cache is used.
+	got, err = provider.GetPredefinedAliases()
+	if err != nil || len(got) != 1 || fullResponses.Load() != 1 {
+		t.Errorf("second GetPredefinedAliases() = %+v, %v after %d full responses, want the cached catalog after 1", got, err, fullResponses.Load())
+	}
+
+	// Changed catalog: downloaded again.
+	writePack(t, packsDir, "k8s.yaml", "- command: kubectl\n  alias: k\n")
+	got, err = provider.GetPredefinedAliases()
+	if err != nil || len(got) != 2 || fullResponses.Load() != 2 {
+		t.Errorf("GetPredefinedAliases() after a change = %+v, %v after %d full responses, want 2 aliases after 2", got, err, fullResponses.Load())
+	}
+
+	// Registry offline: the cache is used.
+	server.Close()
+	got, err = provider.GetPredefinedAliases()
+	if err != nil || len(got) != 2 {
+		t.Errorf("offline GetPredefinedAliases() = %+v, %v, want the 2 cached aliases", got, err)
+	}
+}
+
+func TestHTTPProvider_GetPredefinedAliases_Errors(t *testing.T) {
+	t.Run("offline without cache", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		provider := newTestProvider(server.URL+CatalogPath, t.TempDir())
+		if _, err := provider.GetPredefinedAliases(); err == nil || !strings.Contains(err.Error(), "could not reach alias registry") {
+			t.Errorf("GetPredefinedAliases() error = %v, want an unreachable registry error", err)
+		}
+	})
+
+	t.Run("server error falls back to the cache", func(t *testing.T) {
+		packsDir := t.TempDir()
+		writePack(t, packsDir, "git.yaml", "- command: git status\n  alias: gs\n")
+		var failing atomic.Bool
+		handler := NewHandler(packsDir, aliasfile.NewCodec(), nil)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+		provider := newTestProvider(server.URL+CatalogPath, t.TempDir())
+		logger := &testutil.MockLogger{}
+		provider.logger = logger
+		if _, err := provider.GetPredefinedAliases(); err != nil {
+			t.Fatalf("GetPredefinedAliases() unexpected error: %v", err)
+		}
+
+		failing.Store(true)
+		got, err := provider.GetPredefinedAliases()
+		if err != nil || len(got) != 1 {
+			t.Errorf("GetPredefinedAliases() = %+v, %v, want the cached alias", got, err)
+		}
+		if warnings := logger.EntriesAt("WARN"); len(warnings) != 1 || !strings.Contains(warnings[0], "Using the cached alias catalog") {
+			t.Errorf("GetPredefinedAliases() logged %q, want a warning about using the cache", logger.Entries)
+		}
+	})
+}
+
+func TestNewHandler(t *testing.T) {
+	packsDir := t.TempDir()
+	writePack(t, packsDir, "git.yaml", "- command: git status\n  alias: gs\n")
+	handler := NewHandler(packsDir, aliasfile.NewCodec(), nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, CatalogPath, nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || !strings.Contains(rec.Body.String(), `"alias":"gs"`) {
+		t.Fatalf("GET %s = %d, ETag %q, body %s", CatalogPath, rec.Code, etag, rec.Body.String())
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		header   string
+		wantCode int
+	}{
+		{name: "matching ETag", method: http.MethodGet, path: CatalogPath, header: etag, wantCode: http.StatusNotModified},
+		{name: "weak matching ETag", method: http.MethodGet, path: CatalogPath, header: `"old", W/` + etag, wantCode: http.StatusNotModified},
+		{name: "stale ETag", method: http.MethodGet, path: CatalogPath, header: `"old"`, wantCode: http.StatusOK},
+		{name: "wrong method", method: http.MethodPost, path: CatalogPath, wantCode: http.StatusMethodNotAllowed},
+		{name: "unknown path", method: http.MethodGet, path: "/packs", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("If-None-Match", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
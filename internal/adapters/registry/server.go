@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// CatalogPath is the path the catalog is served at.
+const CatalogPath = "/catalog"
+
+// Server implements the AliasRegistryServer interface with an HTTP server.
+type Server struct {
+	codec  ports.AliasFileCodec
+	logger ports.Logger
+}
+
+// NewServer creates a new Server, decoding pack files with codec.
+// logger receives every request served, and can be nil.
+func NewServer(codec ports.AliasFileCodec, logger ports.Logger) ports.AliasRegistryServer {
+	if codec == nil {
+		panic("AliasFileCodec cannot be nil for registry.NewServer")
+	}
+	return &Server{codec: codec, logger: logger}
+}
+
+// Describe implements the ports.AliasRegistryServer interface.
+func (s *Server) Describe(packsDir string) (string, []string, error) {
+	catalog, err := loadCatalog(packsDir, s.codec)
+	if err != nil {
+		return "", nil, err
+	}
+	names := make([]string, 0, len(catalog.Packs))
+	for _, pack := range catalog.Packs {
+		names = append(names, pack.Name)
+	}
+	return catalog.Version, names, nil
+}
+
+// Serve implements the ports.AliasRegistryServer interface.
+func (s *Server) Serve(addr, packsDir string) error {
+	return http.ListenAndServe(addr, NewHandler(packsDir, s.codec, s.logger))
+}
+
+// NewHandler returns an http.Handler serving the catalog of the pack files in packsDir
+// at CatalogPath. The ETag of the response is the catalog version, so clients sending
+// it back in If-None-Match get 304 Not Modified until a pack changes.
+// Requests are logged to logger, which can be nil.
+func NewHandler(packsDir string, codec ports.AliasFileCodec, logger ports.Logger) http.Handler {
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(CatalogPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		catalog, err := loadCatalog(packsDir, codec)
+		if err != nil {
+			logger.Error("could not load alias packs", "dir", packsDir, "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		body, err := json.Marshal(catalog)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to encode catalog: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		etag := `"` + catalog.Version + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			logger.Info("catalog not modified", "client", r.RemoteAddr, "version", catalog.Version)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		logger.Info("serving catalog", "client", r.RemoteAddr, "version", catalog.Version, "packs", len(catalog.Packs))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+	return mux
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package secretdetection
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// redactedPlaceholder replaces secrets in redacted commands.
+const redactedPlaceholder = "<redacted>"
+
+// secretPattern is a known secret format. If re has a group named "secret", only that
+// group is the secret (e.g. the value of a "password=" argument); otherwise the whole match is.
+// If accept is set, matches whose secret it rejects are ignored.
+type secretPattern struct {
+	kind   string
+	re     *regexp.Regexp
+	accept func(secret string) bool
+}
+
+// secretPatterns are checked in order; the first ones are the most specific. Values starting
+// with "<" (already redacted by an earlier pattern) or "$" (a variable reference) are not secrets.
+var secretPatterns = []secretPattern{
+	{kind: "private key", re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{kind: "AWS access key ID", re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{kind: "GitHub token", re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{kind: "GitLab token", re: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`)},
+	{kind: "Slack token", re: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{kind: "Stripe key", re: regexp.MustCompile(`\b[rs]k_(?:live|test)_[A-Za-z0-9]{16,}`)},
+	{kind: "Google API key", re: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{kind: "API key", re: regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`)},
+	{kind: "JSON web token", re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{kind: "authorization header", re: regexp.MustCompile(`(?i)\bauthorization:\s*(?:bearer|basic|digest|token)\s+(?P<secret>[^\s"'<$][^\s"'<]*)`)},
+	{kind: "authorization header", re: regexp.MustCompile(`(?i)\bx-api-key:\s*(?P<secret>[^\s"'<$][^\s"'<]*)`)},
+	{kind: "bearer token", re: regexp.MustCompile(`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/=-]{8,})`)},
+	{kind: "credentials in URL", re: regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:(?P<secret>[^/\s@]+)@`)},
+	{kind: "password or token argument", re: regexp.MustCompile(`(?i)(?:^|\s)--?(?:password|passwd|pass|token|secret|api-?key|access-?key)(?:=|\s+)(?P<secret>[^\s"'<$][^\s"'<]*)`)},
+	// "-p" is the password of login commands (docker login, helm registry login...) and of the MySQL
+	// clients, which also take it attached ("-phunter2"). Their port option is "-P".
+	{
+		kind:   "password or token argument",
+		re:     regexp.MustCompile(`(?:^|[\s;&|(])(?:login|mysql|mysqldump|mysqladmin|mariadb)\s(?:[^;&|]*?\s)?-p\s*(?P<secret>[^\s"'<$-][^\s"'<]*)`),
+		accept: isSecretValue,
+	},
+	// The keyword must end the variable name, so "token_ttl=3600" or "tokenize:watch" are not assignments,
+	// and "KEY" must follow an underscore, so options like "--sort-key=name" are not either.
+	{
+		kind:   "password or token assignment",
+		re:     regexp.MustCompile(`(?i)(?:^|[^A-Za-z0-9])[A-Za-z0-9_]*(?:password|passwd|secret|token|apikey|accesskey|_key|credentials?)\s*[=:]\s*["']?(?P<secret>[^\s"'<$][^\s"'<]*)`),
+		accept: isSecretValue,
+	},
+}
+
+/*
+PatternDetector finds secrets in commands by matching known token formats,
+"password="-style arguments and assignments, and high-entropy strings.
+It implements the ports.SecretDetector interface.
+*/
+type PatternDetector struct {
+	patterns []secretPattern
+}
+
+// NewPatternDetector creates a new PatternDetector.
+func NewPatternDetector() ports.SecretDetector {
+	return &PatternDetector{patterns: secretPatterns}
+}
+
+// DetectSecrets implements the ports.SecretDetector interface.
+func (d *PatternDetector) DetectSecrets(command string) []string {
+	var kinds []string
+	redacted := command
+	for _, pattern := range d.patterns {
+		if len(pattern.matches(redacted)) > 0 {
+			kinds = appendKind(kinds, pattern.kind)
+			// Redact as we go, so a secret is only reported for the most specific pattern.
+			redacted = redactMatches(redacted, pattern)
+		}
+	}
+	if len(highEntropyTokens(redacted)) > 0 {
+		kinds = appendKind(kinds, highEntropyKind)
+	}
+	return kinds
+}
+
+// Redact implements the ports.SecretDetector interface.
+func (d *PatternDetector) Redact(command string) string {
+	redacted := command
+	for _, pattern := range d.patterns {
+		redacted = redactMatches(redacted, pattern)
+	}
+	for _, token := range highEntropyTokens(redacted) {
+		redacted = replaceToken(redacted, token)
+	}
+	return redacted
+}
+
+// appendKind appends kind to kinds unless it is already listed.
+func appendKind(kinds []string, kind string) []string {
+	if slices.Contains(kinds, kind) {
+		return kinds
+	}
+	return append(kinds, kind)
+}
@@ -0,0 +1,149 @@
+package secretdetection
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// highEntropyKind describes secrets found by their entropy rather than their format.
+const highEntropyKind = "high-entropy string"
+
+const (
+	// minHighEntropyLength is the minimum length of a token to be judged by its entropy.
+	minHighEntropyLength = 20
+	// minBase64Entropy and minHexEntropy are the Shannon entropies (bits per character) above
+	// which a token is considered random. Hex strings have a smaller alphabet, hence a lower bar.
+	minBase64Entropy = 4.0
+	minHexEntropy    = 3.0
+	// gitObjectIDLength is the length of git commit hashes, which are hex but not secret.
+	gitObjectIDLength = 40
+	// maxGitObjectIDLength is the length of SHA-256 git object IDs. Hex tokens up to this
+	// length are taken as (possibly abbreviated or suffixed) refs in git commands.
+	maxGitObjectIDLength = 64
+)
+
+// tokenRegex matches the tokens of a command judged by their entropy.
+var tokenRegex = regexp.MustCompile(`[^\s"'=:,;()<>]+`)
+
+// digestPrefixRegex matches the algorithm prefix of a content digest ending a command prefix,
+// e.g. "alpine@sha256:". Digests identify public content and are not secret.
+var digestPrefixRegex = regexp.MustCompile(`(?i)\bsha(?:1|224|256|384|512):$`)
+
+// gitCommandRegex matches commands running git, whose hex arguments are object IDs.
+var gitCommandRegex = regexp.MustCompile(`(?:^|[\s;&|(])git\s`)
+
+// hexTokenRegex and base64TokenRegex match the tokens that can be random keys.
+var (
+	hexTokenRegex    = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	base64TokenRegex = regexp.MustCompile(`^[A-Za-z0-9+/_-]+=*$`)
+)
+
+// matches returns the start and end of the secrets matched by the pattern in command.
+func (p secretPattern) matches(command string) [][2]int {
+	secretGroup := p.re.SubexpIndex("secret")
+	var secrets [][2]int
+	for _, match := range p.re.FindAllStringSubmatchIndex(command, -1) {
+		start, end := match[0], match[1]
+		if secretGroup > 0 && match[2*secretGroup] >= 0 {
+			start, end = match[2*secretGroup], match[2*secretGroup+1]
+		}
+		if p.accept != nil && !p.accept(command[start:end]) {
+			continue
+		}
+		secrets = append(secrets, [2]int{start, end})
+	}
+	return secrets
+}
+
+// redactMatches replaces the secrets matched by pattern in command with the placeholder.
+func redactMatches(command string, pattern secretPattern) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.matches(command) {
+		start, end := match[0], match[1]
+		b.WriteString(command[last:start])
+		b.WriteString(redactedPlaceholder)
+		last = end
+	}
+	b.WriteString(command[last:])
+	return b.String()
+}
+
+// highEntropyTokens returns the tokens of command that look like random keys.
+// Paths, git object IDs, content digests and words made only of letters are never reported.
+func highEntropyTokens(command string) []string {
+	isGitCommand := gitCommandRegex.MatchString(command)
+	var tokens []string
+	for _, loc := range tokenRegex.FindAllStringIndex(command, -1) {
+		token := command[loc[0]:loc[1]]
+		if len(token) < minHighEntropyLength || strings.Contains(token, "/") || !hasLetterAndDigit(token) {
+			continue
+		}
+		if digestPrefixRegex.MatchString(command[:loc[0]]) {
+			continue
+		}
+		switch {
+		case hexTokenRegex.MatchString(token):
+			if len(token) == gitObjectIDLength || (isGitCommand && len(token) <= maxGitObjectIDLength) {
+				continue
+			}
+			if shannonEntropy(token) >= minHexEntropy {
+				tokens = append(tokens, token)
+			}
+		case base64TokenRegex.MatchString(token):
+			if shannonEntropy(token) >= minBase64Entropy {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// isSecretValue reports whether the value of a password or token can be a secret: it is
+// neither a number, e.g. "MAX_TOKENS=4096", nor a placeholder.
+func isSecretValue(value string) bool {
+	isNumber := strings.IndexFunc(value, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+	return !isNumber && !isPlaceholder(value)
+}
+
+// isPlaceholder reports whether value stands for a secret rather than being one:
+// a variable reference ("$TOKEN", "${TOKEN}"), a "<token>" or "xxx".
+func isPlaceholder(value string) bool {
+	switch {
+	case strings.HasPrefix(value, "$"):
+		return true
+	case strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">"):
+		return true
+	default:
+		return strings.Trim(strings.ToLower(value), "x") == ""
+	}
+}
+
+// hasLetterAndDigit reports whether s contains at least one letter and one digit.
+func hasLetterAndDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsLetter) >= 0 && strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
+
+// shannonEntropy returns the Shannon entropy of s, in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// replaceToken replaces every standalone occurrence of token in command with the placeholder.
+func replaceToken(command, token string) string {
+	re := regexp.MustCompile(`(^|[\s"'=:,;()<>])` + regexp.QuoteMeta(token) + `($|[\s"'=:,;()<>])`)
+	return re.ReplaceAllString(command, "${1}"+redactedPlaceholder+"${2}")
+}
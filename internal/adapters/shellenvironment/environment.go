@@ -0,0 +1,114 @@
+package shellenvironment
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// OSEnvironment implements the ShellEnvironment interface with the environment of the current process.
+type OSEnvironment struct {
+	homeDir   string
+	lookupEnv func(key string) (string, bool)
+	lookPath  func(file string) (string, error)
+}
+
+// NewOSEnvironment creates a new OSEnvironment for the current user.
+func NewOSEnvironment() (ports.ShellEnvironment, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting user home directory: %w", err)
+	}
+	return &OSEnvironment{homeDir: homeDir, lookupEnv: os.LookupEnv, lookPath: exec.LookPath}, nil
+}
+
+// Shell implements the ports.ShellEnvironment interface.
+func (e *OSEnvironment) Shell() string {
+	shellPath, _ := e.lookupEnv("SHELL")
+	if shellPath == "" {
+		return ""
+	}
+	return filepath.Base(shellPath)
+}
+
+// LookupEnv implements the ports.ShellEnvironment interface.
+func (e *OSEnvironment) LookupEnv(key string) (string, bool) {
+	return e.lookupEnv(key)
+}
+
+// LookPath implements the ports.ShellEnvironment interface.
+func (e *OSEnvironment) LookPath(name string) (string, error) {
+	return e.lookPath(name)
+}
+
+// RCFiles implements the ports.ShellEnvironment interface.
+// Startup files that cannot be read for another reason than not existing are an error.
+func (e *OSEnvironment) RCFiles(shell string) ([]ports.RCFile, error) {
+	var rcFiles []ports.RCFile
+	for _, path := range e.rcFilePaths(shell) {
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading startup file %s: %w", path, err)
+		}
+		rcFiles = append(rcFiles, ports.RCFile{Path: path, Content: string(content)})
+	}
+	return rcFiles, nil
+}
+
+// rcFilePaths returns the startup files the given shell may read, in the order it reads them.
+func (e *OSEnvironment) rcFilePaths(shell string) []string {
+	switch shell {
+	case "bash":
+		return e.inHome(".bash_profile", ".bash_login", ".profile", ".bashrc")
+	case "zsh":
+		zdotdir, _ := e.lookupEnv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = e.homeDir
+		}
+		paths := make([]string, 0, 4)
+		for _, name := range []string{".zshenv", ".zprofile", ".zshrc", ".zlogin"} {
+			paths = append(paths, filepath.Join(zdotdir, name))
+		}
+		return paths
+	case "fish":
+		configDir := e.configDir()
+		// conf.d snippets are read before config.fish.
+		paths, _ := filepath.Glob(filepath.Join(configDir, "fish", "conf.d", "*.fish"))
+		return append(paths, filepath.Join(configDir, "fish", "config.fish"))
+	case "nu":
+		return []string{filepath.Join(e.configDir(), "nushell", "env.nu"), filepath.Join(e.configDir(), "nushell", "config.nu")}
+	case "pwsh", "powershell":
+		// The profile for all hosts is read before the one of the console host.
+		return []string{
+			filepath.Join(e.configDir(), "powershell", "profile.ps1"),
+			filepath.Join(e.configDir(), "powershell", "Microsoft.PowerShell_profile.ps1"),
+		}
+	default:
+		return e.inHome(".profile")
+	}
+}
+
+// configDir returns $XDG_CONFIG_HOME, or ~/.config if it is not set.
+func (e *OSEnvironment) configDir() string {
+	if configDir, _ := e.lookupEnv("XDG_CONFIG_HOME"); configDir != "" {
+		return configDir
+	}
+	return filepath.Join(e.homeDir, ".config")
+}
+
+// inHome returns the paths of the given file names in the home directory.
+func (e *OSEnvironment) inHome(names ...string) []string {
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(e.homeDir, name))
+	}
+	return paths
+}
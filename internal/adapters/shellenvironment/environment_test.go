@@ -0,0 +1,120 @@
+package shellenvironment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+func TestOSEnvironment_Shell(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "path of the shell", env: map[string]string{"SHELL": "/usr/bin/zsh"}, want: "zsh"},
+		{name: "unset", env: map[string]string{}, want: ""},
+		{name: "empty", env: map[string]string{"SHELL": ""}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &OSEnvironment{lookupEnv: func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			}}
+			if got := env.Shell(); got != tt.want {
+				t.Errorf("Shell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSEnvironment_RCFiles(t *testing.T) {
+	home := t.TempDir()
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(home, ".bashrc"), "bashrc")
+	writeFile(filepath.Join(home, ".profile"), "profile")
+	writeFile(filepath.Join(home, ".zshrc"), "zshrc")
+	writeFile(filepath.Join(home, "zdot", ".zshrc"), "zdot zshrc")
+	writeFile(filepath.Join(home, ".config", "fish", "config.fish"), "config.fish")
+	writeFile(filepath.Join(home, ".config", "fish", "conf.d", "nicksh.fish"), "conf.d")
+	writeFile(filepath.Join(home, ".config", "nushell", "config.nu"), "config.nu")
+	writeFile(filepath.Join(home, "xdg", "powershell", "Microsoft.PowerShell_profile.ps1"), "profile")
+
+	tests := []struct {
+		name  string
+		shell string
+		env   map[string]string
+		want  []ports.RCFile
+	}{
+		{
+			name:  "bash reads the login files first",
+			shell: "bash",
+			want: []ports.RCFile{
+				{Path: filepath.Join(home, ".profile"), Content: "profile"},
+				{Path: filepath.Join(home, ".bashrc"), Content: "bashrc"},
+			},
+		},
+		{
+			name:  "zsh",
+			shell: "zsh",
+			want:  []ports.RCFile{{Path: filepath.Join(home, ".zshrc"), Content: "zshrc"}},
+		},
+		{
+			name:  "zsh with ZDOTDIR",
+			shell: "zsh",
+			env:   map[string]string{"ZDOTDIR": filepath.Join(home, "zdot")},
+			want:  []ports.RCFile{{Path: filepath.Join(home, "zdot", ".zshrc"), Content: "zdot zshrc"}},
+		},
+		{
+			name:  "fish reads conf.d first",
+			shell: "fish",
+			want: []ports.RCFile{
+				{Path: filepath.Join(home, ".config", "fish", "conf.d", "nicksh.fish"), Content: "conf.d"},
+				{Path: filepath.Join(home, ".config", "fish", "config.fish"), Content: "config.fish"},
+			},
+		},
+		{
+			name:  "nushell",
+			shell: "nu",
+			want:  []ports.RCFile{{Path: filepath.Join(home, ".config", "nushell", "config.nu"), Content: "config.nu"}},
+		},
+		{
+			name:  "PowerShell with XDG_CONFIG_HOME",
+			shell: "pwsh",
+			env:   map[string]string{"XDG_CONFIG_HOME": filepath.Join(home, "xdg")},
+			want:  []ports.RCFile{{Path: filepath.Join(home, "xdg", "powershell", "Microsoft.PowerShell_profile.ps1"), Content: "profile"}},
+		},
+		{
+			name:  "other shells read .profile",
+			shell: "ksh",
+			want:  []ports.RCFile{{Path: filepath.Join(home, ".profile"), Content: "profile"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &OSEnvironment{homeDir: home, lookupEnv: func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			}}
+			got, err := env.RCFiles(tt.shell)
+			if err != nil {
+				t.Fatalf("RCFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RCFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
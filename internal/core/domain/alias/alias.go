@@ -3,11 +3,225 @@ Package alias defines the core domain entity for an alias.
 */
 package alias
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 /*
 Alias represents a suggested alias, consisting of a short name and the
 full command it expands to. This is a core domain entity.
+
+Group optionally names the alias group (file) the alias belongs to.
+An empty Group means the default group.
+
+Kind is one of the Kind* constants. Global and suffix aliases are zsh
+features: a global alias (alias -g G='| grep') is expanded anywhere on the
+command line, and a suffix alias (alias -s md=code) opens files with the
+given extension, e.g. "README.md" runs "code README.md". Names are unique
+across kinds.
+
+IsCorrection marks a suggestion that corrects a common misspelling of a
+command (e.g. "gti" -> "git") rather than shortening it.
+
+Frequency, Source and Examples describe a suggestion: how often the commands
+it covers were found in the history, which rule suggested it (one of the
+Source* constants) and some of the history lines it covers. They are not
+persisted.
+
+Secrets lists the kinds of secrets (e.g. "AWS access key ID") found in the
+command of a suggestion. Such a suggestion has its command and examples
+redacted, and must not be added.
+
+Only Command, Name, Group and Kind are persisted; the YAML and JSON encodings
+share the same field names, so alias files can use either format.
 */
 type Alias struct {
-	Command string `yaml:"command"`
-	Name    string `yaml:"alias"`
+	Command      string   `yaml:"command" json:"command"`
+	Name         string   `yaml:"alias" json:"alias"`
+	Group        string   `yaml:"group,omitempty" json:"group,omitempty"`
+	Kind         string   `yaml:"kind,omitempty" json:"kind,omitempty"`
+	IsCorrection bool     `yaml:"-" json:"-"`
+	Frequency    int      `yaml:"-" json:"-"`
+	Source       string   `yaml:"-" json:"-"`
+	Examples     []string `yaml:"-" json:"-"`
+	Secrets      []string `yaml:"-" json:"-"`
+}
+
+// Alias kinds, see Alias.Kind.
+const (
+	KindRegular = ""
+	KindGlobal  = "global"
+	KindSuffix  = "suffix"
+)
+
+// Suggestion sources, see Alias.Source.
+const (
+	SourceSubcommand   = "command + argument"
+	SourceExactCommand = "exact command"
+	SourceCorrection   = "typo correction"
+	SourcePredefined   = "predefined"
+	SourcePipelineTail = "pipeline tail"
+)
+
+/*
+Definition is an alias as found in a managed alias file, together with
+the file and the line number (1-based) it is defined on.
+*/
+type Definition struct {
+	Alias
+	File string
+	Line int
+}
+
+/*
+DuplicateNameError reports an alias name that is defined in more than one
+managed alias file. Definitions lists every place the name was found.
+*/
+type DuplicateNameError struct {
+	Name        string
+	Definitions []Definition
+}
+
+func (e *DuplicateNameError) Error() string {
+	locations := make([]string, 0, len(e.Definitions))
+	for _, def := range e.Definitions {
+		locations = append(locations, fmt.Sprintf("%s:%d", def.File, def.Line))
+	}
+	return fmt.Sprintf("alias '%s' is defined in multiple files: %s", e.Name, strings.Join(locations, ", "))
+}
+
+/*
+SecretError reports an alias that was not written because its command
+contains secrets. Kinds describes each kind of secret found.
+*/
+type SecretError struct {
+	Name  string
+	Kinds []string
+}
+
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("refusing to write alias '%s': its command contains a secret (%s); keep it in an environment variable or a credentials file instead", e.Name, strings.Join(e.Kinds, ", "))
+}
+
+// Sentinel errors, matched with errors.Is by the typed errors below, so callers
+// (and the CLI's exit codes) can react to a kind of failure whatever its details.
+var (
+	ErrAliasExists = errors.New("alias already exists")
+	ErrInvalidName = errors.New("invalid alias name")
+	ErrConfigWrite = errors.New("failed to write alias configuration")
+)
+
+/*
+ExistsError reports an alias name that is already defined, with the command
+it is defined as. It matches ErrAliasExists.
+*/
+type ExistsError struct {
+	Name    string
+	Command string
+}
+
+func (e *ExistsError) Error() string {
+	return fmt.Sprintf("'%s' is already an alias for '%s'", e.Name, e.Command)
+}
+
+func (e *ExistsError) Is(target error) bool { return target == ErrAliasExists }
+
+/*
+InvalidNameError reports a name that cannot be used as an alias name, and
+why. It matches ErrInvalidName.
+*/
+type InvalidNameError struct {
+	Name   string
+	Reason string
+}
+
+func (e *InvalidNameError) Error() string {
+	return fmt.Sprintf("'%s' is not a valid alias name: %s", e.Name, e.Reason)
+}
+
+func (e *InvalidNameError) Is(target error) bool { return target == ErrInvalidName }
+
+/*
+ConfigWriteError reports an alias file or directory that could not be
+written. Path is meant for display. It matches ErrConfigWrite, and unwraps
+to the underlying error.
+*/
+type ConfigWriteError struct {
+	Path string
+	Err  error
+}
+
+func (e *ConfigWriteError) Error() string {
+	return fmt.Sprintf("failed to write %s: %v", e.Path, e.Err)
+}
+
+func (e *ConfigWriteError) Is(target error) bool { return target == ErrConfigWrite }
+
+func (e *ConfigWriteError) Unwrap() error { return e.Err }
+
+// Issue kinds, see Issue.Kind.
+const (
+	IssueCycle         = "cycle"
+	IssueSelfReference = "self-reference"
+	IssueDangling      = "dangling command"
+	IssueOverride      = "cross-file override"
+)
+
+/*
+Issue is a problem found in a set of alias definitions as a whole, by
+following what each alias expands to.
+
+Kind is one of the Issue* constants. Name is the alias the issue is about.
+Chain is the chain of aliases that leads back to Name, for a cycle (e.g. a,
+b, a) or a self-reference (e.g. ls, ls). Command is the command that cannot
+be found, for a dangling command. Definitions lists the definitions of Name
+involved, for an override.
+
+Only cycles break an alias: the shell stops expanding at the alias it started
+from, and runs it as a command. The other issues are worth a warning.
+*/
+type Issue struct {
+	Kind        string
+	Name        string
+	Chain       []string
+	Command     string
+	Definitions []Definition
+}
+
+// IsError reports whether the issue breaks the alias, rather than being worth a warning.
+func (i Issue) IsError() bool {
+	return i.Kind == IssueCycle
+}
+
+func (i Issue) String() string {
+	switch i.Kind {
+	case IssueCycle:
+		return fmt.Sprintf("alias '%s' loops: %s", i.Name, strings.Join(i.Chain, " -> "))
+	case IssueSelfReference:
+		return fmt.Sprintf("alias '%s' expands to its own name; the shell runs the '%s' command rather than the alias there", i.Name, i.Name)
+	case IssueDangling:
+		return fmt.Sprintf("alias '%s' runs '%s', which is neither an alias, an executable on PATH nor a shell builtin", i.Name, i.Command)
+	case IssueOverride:
+		locations := make([]string, 0, len(i.Definitions))
+		for _, def := range i.Definitions {
+			locations = append(locations, fmt.Sprintf("%s:%d", def.File, def.Line))
+		}
+		return fmt.Sprintf("alias '%s' is defined in multiple files, the last one loaded wins: %s", i.Name, strings.Join(locations, ", "))
+	}
+	return fmt.Sprintf("alias '%s': %s", i.Name, i.Kind)
+}
+
+/*
+CycleError reports an alias that was not written because its expansion
+would lead back to it through other aliases. Chain is the cycle, e.g. a, b, a.
+*/
+type CycleError struct {
+	Name  string
+	Chain []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("refusing to write alias '%s': its expansion would loop (%s)", e.Name, strings.Join(e.Chain, " -> "))
 }
@@ -3,6 +3,15 @@ Package history defines core domain entities related to command history.
 */
 package history
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrHistoryNotFound is matched (with errors.Is) by the errors of history sources
+// that do not exist or could not be located, e.g. no history file for the shell.
+var ErrHistoryNotFound = errors.New("no shell history found")
+
 /*
 CommandFrequency represents a command and its execution count.
 This is a core domain entity.
@@ -11,3 +20,45 @@ type CommandFrequency struct {
 	Command string
 	Count   int
 }
+
+/*
+Entry represents a single command from a shell history, together with the
+context recorded by the history source. Plain history files usually only
+record the command; structured sources (e.g. atuin) also record the
+directory, exit code, duration and host. Zero values mean "not recorded".
+*/
+type Entry struct {
+	Command     string
+	Directory   string
+	ExitCode    int
+	HasExitCode bool // ExitCode is only meaningful when this is true.
+	Duration    time.Duration
+	Host        string
+	Timestamp   time.Time
+}
+
+// Failed reports whether the command is known to have exited with a non-zero status.
+// Entries without a recorded exit code are never considered failed.
+func (e Entry) Failed() bool {
+	return e.HasExitCode && e.ExitCode != 0
+}
+
+// CommandNotFound reports whether the shell could not find the command (exit code 127),
+// which usually means the command name was mistyped.
+func (e Entry) CommandNotFound() bool {
+	return e.HasExitCode && e.ExitCode == 127
+}
+
+// ExclusionStat records how many history entries an exclusion rule removed.
+type ExclusionStat struct {
+	Origin  string // Where the rule comes from, e.g. "HISTIGNORE" or "regex".
+	Pattern string // The rule as configured.
+	Removed int
+}
+
+// ExclusionReport describes how exclusion rules filtered a scan of the history.
+// An entry matched by several rules is only counted for the first one.
+type ExclusionReport struct {
+	ScannedEntries int
+	Rules          []ExclusionStat
+}
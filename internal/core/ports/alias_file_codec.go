@@ -0,0 +1,23 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+// Alias file formats supported by AliasFileCodec.
+const (
+	AliasFileFormatYAML = "yaml"
+	AliasFileFormatJSON = "json"
+)
+
+/*
+AliasFileCodec defines the contract for encoding and decoding alias sets, in the
+same schema as the predefined aliases file (a list of entries with "alias",
+"command" and an optional "group"). This is a driven port.
+*/
+type AliasFileCodec interface {
+	// Encode encodes aliases in the given format.
+	Encode(aliases []alias.Alias, format string) ([]byte, error)
+
+	// Decode decodes the aliases of data, in the given format.
+	// Unknown fields are an error.
+	Decode(data []byte, format string) ([]alias.Alias, error)
+}
@@ -1,12 +1,46 @@
 package ports
 
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
 // AliasManagementService defines the contract for managing shell aliases.
 type AliasManagementService interface {
-	// AddAliasToConfig adds a new alias to the shell configuration.
-	// It returns true if the alias was newly added, false if it was skipped (e.g., already exists),
-	// and an error if the operation failed.
-	AddAliasToConfig(aliasName, aliasCommand string) (bool, error)
+	// AddAliasToConfig adds a new alias of the given kind (one of the alias.Kind* constants)
+	// to the shell configuration, in the given group. An empty group means the default group.
+	// The result tells whether the alias was added or skipped (e.g., already exists), and where
+	// it is defined; an error is returned if the operation failed.
+	AddAliasToConfig(aliasName, aliasCommand, group, kind string) (AddAliasResult, error)
 
 	// ListAliases retrieves all existing aliases from the shell configuration.
 	ListAliases() (map[string]string, error)
+
+	// ValidateAliases checks the managed aliases as a whole: cycles, self-references,
+	// commands that cannot be found and names defined in more than one file.
+	ValidateAliases() ([]alias.Issue, error)
+
+	// ListAliasesInGroup retrieves the aliases defined in a single group.
+	ListAliasesInGroup(group string) (map[string]string, error)
+
+	// MoveAlias moves an existing alias into the given group.
+	MoveAlias(aliasName, group string) error
+
+	// RemoveAlias removes an existing alias, from whichever group defines it.
+	RemoveAlias(aliasName string) error
+
+	// AliasLoader returns the code that loads the managed alias files, for the user
+	// to add to a startup file of their shell.
+	AliasLoader() string
+
+	// StartupFile returns the startup file the alias loader goes in, and the command
+	// reloading it in a running shell, or "" if the shell has none.
+	StartupFile() (path string, reload string)
+
+	// QuoteString quotes s as a literal string for the named shell, or for the
+	// user's shell if shell is empty.
+	QuoteString(shell, s string) (string, error)
+
+	// FormatDefinition renders a as the line written to its alias file, newline included.
+	FormatDefinition(a alias.Alias) string
+
+	// UnloadCommand returns the command removing the alias name from a running shell.
+	UnloadCommand(name string) string
 }
@@ -0,0 +1,17 @@
+package ports
+
+/*
+AliasRegistryServer defines the contract for publishing a directory of alias
+packs as a versioned alias catalog, the one read by the HTTP registry
+PredefinedAliasProvider. Each pack is an alias file, in the schema of
+predefined_aliases.yaml. This is a driving adapter's dependency, typically
+implemented by an HTTP server.
+*/
+type AliasRegistryServer interface {
+	// Describe loads the packs of packsDir as a catalog and returns its version and pack names.
+	Describe(packsDir string) (version string, packs []string, err error)
+
+	// Serve serves the catalog of packsDir on addr (e.g. "127.0.0.1:8383"), until the
+	// server fails. Packs are reloaded on every request, so edits are served right away.
+	Serve(addr, packsDir string) error
+}
@@ -0,0 +1,50 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+// AliasLookup describes what a name resolves to in the user's shell.
+type AliasLookup struct {
+	Name string
+	// Definitions lists every definition of the name, in the order the shell loads them:
+	// the startup files first, then the managed alias files. The last one is in effect.
+	Definitions []alias.Definition
+	Path        string // The executable on PATH with the same name, if any.
+	IsBuiltin   bool   // Whether the name is a shell builtin or reserved word.
+}
+
+// IsAlias reports whether the name is defined as an alias.
+func (l AliasLookup) IsAlias() bool {
+	return len(l.Definitions) > 0
+}
+
+// Effective returns the definition in effect. It must only be called if IsAlias.
+func (l AliasLookup) Effective() alias.Definition {
+	return l.Definitions[len(l.Definitions)-1]
+}
+
+// ExpansionStep is one alias replaced while expanding a command line.
+type ExpansionStep struct {
+	Name    string
+	Command string
+	Depth   int // 0 for an alias of the command line itself, 1 for an alias in its expansion, etc.
+}
+
+// Expansion is the result of expanding the aliases of a command line.
+type Expansion struct {
+	Original string
+	Expanded string
+	Steps    []ExpansionStep // In the order the aliases were replaced.
+	// Loops lists the alias chains that lead back to an alias being expanded, e.g. ["a", "b", "a"].
+	// The shell stops expanding there, as Expanded does. An alias expanding to its own name
+	// (e.g. ls='ls --color') is the usual way to add options to a command, not a loop.
+	Loops [][]string
+}
+
+// AliasResolutionService defines the contract for finding out what aliases resolve to.
+type AliasResolutionService interface {
+	// Which looks name up among the aliases, the executables on PATH and the shell builtins.
+	Which(name string) (AliasLookup, error)
+	// Expand performs alias expansion on a command line the way bash and zsh do: the first word
+	// of each command is expanded, recursively, and so is the word after an alias whose value ends with a blank.
+	Expand(line string) (Expansion, error)
+}
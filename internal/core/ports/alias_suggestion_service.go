@@ -1,17 +1,31 @@
 package ports
 
-import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+import (
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
 
 // SuggestionResult holds the suggestions and any relevant metadata.
+// Withheld holds the suggestions left out because their command contains a secret;
+// their commands are redacted and their Secrets field says what was found.
 type SuggestionResult struct {
 	Suggestions   []alias.Alias
+	Withheld      []alias.Alias
 	SourceDetails string
 }
 
 // AliasSuggestionService defines the contract for generating alias suggestions.
 type AliasSuggestionService interface {
 	GetSuggestions(minFrequency, scanLimit, outputLimit int) (SuggestionResult, error)
+	// GetSuggestionsForDirectory is like GetSuggestions, but only analyzes commands run in dir.
+	GetSuggestionsForDirectory(dir string, minFrequency, scanLimit, outputLimit int) (SuggestionResult, error)
 	GetSuggestionContextDetails() (string, error)
+	// ExplainHistoryExclusions reports how many of the scanLimit most recent history entries
+	// were left out of suggestions by each exclusion rule.
+	ExplainHistoryExclusions(scanLimit int) (history.ExclusionReport, error)
+	// ValidateAliasName checks whether name can be used for a new alias. It returns nil
+	// if it can, or an error describing why not (e.g. the name is already an alias).
+	ValidateAliasName(name string) error
 	// GetFilteredPredefinedAliases loads predefined aliases and filters them based on validity
 	// and conflicts with the provided currentShellAliases.
 	// It returns the list of valid aliases, the list of all aliases originally loaded, and any error encountered.
@@ -0,0 +1,43 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+// Sides of a sync, see SyncConflict.Kept.
+const (
+	SyncSideLocal  = "local"
+	SyncSideRemote = "remote"
+)
+
+// AliasChange describes how a sync changed an alias. Before is nil for an added
+// alias and After is nil for a removed one.
+type AliasChange struct {
+	Name   string
+	Before *alias.Alias
+	After  *alias.Alias
+}
+
+// SyncConflict is an alias changed differently on both sides since the last sync.
+// A nil Local or Remote means the alias was removed on that side.
+type SyncConflict struct {
+	Name   string
+	Local  *alias.Alias
+	Remote *alias.Alias
+	Kept   string // SyncSideLocal or SyncSideRemote.
+}
+
+// SyncResult reports what a sync changed on each side.
+type SyncResult struct {
+	LocalChanges  []AliasChange // Applied to the managed aliases, from the sync store.
+	RemoteChanges []AliasChange // Saved to the sync store, from the managed aliases.
+	Conflicts     []SyncConflict
+	Committed     bool // Whether the remote changes were recorded.
+	Pushed        bool // Whether they were shared with the store's remote.
+}
+
+// AliasSyncService defines the contract for synchronizing the managed aliases with a shared store.
+type AliasSyncService interface {
+	// Sync pulls the store found at dir, merges it with the managed aliases (three-way,
+	// by alias name) and publishes the result. Conflicts are resolved in favor of prefer,
+	// SyncSideLocal or SyncSideRemote.
+	Sync(dir, prefer string) (SyncResult, error)
+}
@@ -0,0 +1,31 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+/*
+AliasSyncStore defines the contract for a shared copy of the alias set, such as
+an alias file in a git working copy. It also keeps the base of the three-way
+merge: the alias set as it was after the last successful sync.
+This is a driven port.
+*/
+type AliasSyncStore interface {
+	// Pull brings in the changes published from other machines.
+	Pull() error
+
+	// LoadAliases returns the alias set of the store. A store without an alias file has no aliases.
+	LoadAliases() ([]alias.Alias, error)
+
+	// SaveAliases replaces the alias set of the store.
+	SaveAliases(aliases []alias.Alias) error
+
+	// LoadBase returns the alias set saved by SaveBase, and false if there is none (first sync).
+	LoadBase() ([]alias.Alias, bool, error)
+
+	// SaveBase records the alias set both sides agree on after a sync.
+	SaveBase(aliases []alias.Alias) error
+
+	// Publish records the saved alias set with the given message and shares it.
+	// It reports whether there was anything to record and whether it was shared
+	// (a store without a remote only records changes).
+	Publish(message string) (committed bool, pushed bool, err error)
+}
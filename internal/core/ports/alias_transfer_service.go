@@ -0,0 +1,36 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+// ImportConflict is an imported alias whose name is already defined with another command.
+type ImportConflict struct {
+	Alias           alias.Alias // The imported alias.
+	ExistingCommand string      // The command the name is currently defined with.
+}
+
+// ImportPlan sorts the aliases of an import file by what importing them would do.
+type ImportPlan struct {
+	New       []alias.Alias    // Not defined yet; can be added as they are.
+	Unchanged []alias.Alias    // Already defined with the same command; nothing to do.
+	Conflicts []ImportConflict // Already defined with another command.
+	Invalid   []alias.Alias    // Rejected by validation (invalid name, no command, or a duplicate in the file).
+}
+
+// AliasTransferService defines the contract for moving the managed alias set between machines.
+type AliasTransferService interface {
+	// ExportAliases encodes every managed alias, with its group, in the given format.
+	ExportAliases(format string) ([]byte, error)
+
+	// PlanImport decodes an alias file in the given format and compares its aliases
+	// with the managed ones.
+	PlanImport(data []byte, format string) (ImportPlan, error)
+
+	// ValidateImportName checks whether name can be used to rename a conflicting imported alias.
+	// It returns nil if it can, or an error describing why not.
+	ValidateImportName(name string) error
+
+	// ImportAlias writes an imported alias to its group. If overwrite is true, any
+	// existing definition of the name is replaced; otherwise an existing alias is kept
+	// and false is returned.
+	ImportAlias(imported alias.Alias, overwrite bool) (bool, error)
+}
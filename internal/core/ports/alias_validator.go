@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+/*
+AliasValidator defines the contract for checking a set of alias definitions
+as a whole: what each alias expands to, and whether the commands it runs
+exist. It is run on the managed alias files before they are written to, so
+a write cannot break them.
+*/
+type AliasValidator interface {
+	// Validate returns the issues found in definitions, given in the order the shell loads them.
+	// It returns nil if there are none.
+	Validate(definitions []alias.Definition) []alias.Issue
+}
@@ -0,0 +1,20 @@
+package ports
+
+/*
+CommandResolver defines the contract for checking whether a command name can
+be run by the user's shell. This is a driven port, typically implemented by an
+adapter that looks at the executables on PATH and the shell builtins.
+*/
+type CommandResolver interface {
+	// IsResolvable reports whether name is an executable on PATH or a shell builtin.
+	// Names that cannot be judged in isolation (paths, variable assignments) are
+	// reported as resolvable.
+	IsResolvable(name string) bool
+
+	// KnownCommands returns the names of all executables on PATH and the shell builtins.
+	KnownCommands() []string
+
+	// Locate returns the path of the executable name on PATH ("" if there is none),
+	// and whether name is a shell builtin or reserved word. A name can be both.
+	Locate(name string) (path string, isBuiltin bool)
+}
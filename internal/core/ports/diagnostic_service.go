@@ -0,0 +1,24 @@
+package ports
+
+// Outcomes of a diagnostic check, see DiagnosticCheck.Status.
+const (
+	DiagnosticPass = "pass"
+	DiagnosticWarn = "warn" // nicksh works, but not as well as it could.
+	DiagnosticFail = "fail" // nicksh cannot work as expected until this is fixed.
+)
+
+// DiagnosticCheck is the outcome of one check of the user's setup.
+type DiagnosticCheck struct {
+	Name        string // Short, stable name of the check, e.g. "history file".
+	Status      string // DiagnosticPass, DiagnosticWarn or DiagnosticFail.
+	Detail      string // What was found.
+	Remediation string // How to fix a warning or failure; empty when the check passed.
+}
+
+// DiagnosticService defines the contract for checking why nicksh may not work as expected,
+// e.g. why suggestions come back empty.
+type DiagnosticService interface {
+	// RunDiagnostics runs every check, in a fixed order. A check that cannot be run
+	// is reported as failed, so the other checks still run.
+	RunDiagnostics() []DiagnosticCheck
+}
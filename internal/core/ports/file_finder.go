@@ -3,4 +3,6 @@ package ports
 // HistoryFileFinder defines the contract for finding a history file.
 type HistoryFileFinder interface {
 	Find() (string, error)
+	// FindAll returns every history file found in the standard locations, most relevant first.
+	FindAll() ([]string, error)
 }
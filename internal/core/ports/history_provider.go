@@ -4,6 +4,31 @@ import "github.com/AntonioJCosta/nicksh/internal/core/domain/history"
 
 type HistoryProvider interface {
 	GetCommandFrequencies(scanLimit int, outputLimit int) ([]history.CommandFrequency, error)
+	// GetCommandFrequenciesInDir is like GetCommandFrequencies, but only counts commands
+	// that were run in the given directory. Providers without directory information return an error.
+	GetCommandFrequenciesInDir(dir string, scanLimit int, outputLimit int) ([]history.CommandFrequency, error)
+	// GetEntries returns the scanLimit most recent history entries, oldest first,
+	// with whatever context (directory, exit code, ...) the source records.
+	GetEntries(scanLimit int) ([]history.Entry, error)
+	// ExplainExclusions reports how many of the scanLimit most recent history entries
+	// each exclusion rule (HISTIGNORE, HISTORY_IGNORE, user patterns) removed.
+	ExplainExclusions(scanLimit int) (history.ExclusionReport, error)
 	GetHistoryFilePath() string
 	GetSourceIdentifier() string
 }
+
+// HistoryBackendSelector selects which history source a HistoryProvider reads from.
+type HistoryBackendSelector interface {
+	// SelectHistoryBackend selects a backend by name. "auto" (or "") detects the best available one.
+	SelectHistoryBackend(name string) error
+	// AvailableHistoryBackends lists the backend names that can be selected.
+	AvailableHistoryBackends() []string
+}
+
+// HistorySourceSelector selects the history files a HistoryProvider merges.
+type HistorySourceSelector interface {
+	// SelectHistorySources selects the history files to analyze, each given as "PATH" or
+	// "PATH:WEIGHT" (e.g. "~/laptop_history:0.5"); each file's counts are multiplied by its weight.
+	// With no specs, every history file found in the standard locations is used.
+	SelectHistorySources(specs []string) error
+}
@@ -0,0 +1,46 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+
+// TypingVolume counts the commands typed, and their keystrokes, in a period of time.
+type TypingVolume struct {
+	Commands   int
+	Keystrokes int
+}
+
+// AliasCoverage describes how much of the typing the existing aliases cover.
+type AliasCoverage struct {
+	CoveredKeystrokes int // Keystrokes typed in commands that use an alias, or that an alias expands to.
+	SavedKeystrokes   int // Keystrokes saved by the aliases used.
+	MissedKeystrokes  int // Keystrokes that would have been saved by using an alias for commands typed in full.
+}
+
+// SuggestionSavings estimates the keystrokes the current suggestions would save.
+type SuggestionSavings struct {
+	Suggestions int
+	Keystrokes  int // Saved over the scanned history, had the suggestions been used.
+}
+
+// HistoryStats holds analytics about the scanned history entries.
+// Keystrokes are the characters of the commands as typed.
+type HistoryStats struct {
+	SourceDetails  string
+	Commands       int // Number of history entries scanned.
+	Keystrokes     int
+	TopCommands    []history.CommandFrequency // Command names, e.g. "git", most used first.
+	TopSubcommands []history.CommandFrequency // Command names with their first argument, e.g. "git status".
+	AliasCoverage  AliasCoverage
+	// HasTimestamps tells whether the history records when commands were run;
+	// VolumeByHour and VolumeByWeekday are empty otherwise.
+	HasTimestamps     bool
+	VolumeByHour      [24]TypingVolume // Indexed by hour of the day, local time.
+	VolumeByWeekday   [7]TypingVolume  // Indexed by time.Weekday, Sunday first.
+	SuggestionSavings SuggestionSavings
+}
+
+// HistoryStatsService defines the contract for computing analytics about the command history.
+type HistoryStatsService interface {
+	// GetHistoryStats analyzes the scanLimit most recent history entries. The top lists hold
+	// at most topLimit items, and suggestions are estimated for commands used at least minFrequency times.
+	GetHistoryStats(scanLimit, topLimit, minFrequency int) (HistoryStats, error)
+}
@@ -0,0 +1,38 @@
+package ports
+
+import "log/slog"
+
+/*
+Logger defines the interface for reporting diagnostics that are not errors,
+such as an alias file that could not be read and was skipped. Adapters and
+services that need it get one injected instead of printing themselves.
+Its method set is a subset of *slog.Logger's, args being alternating keys
+and values.
+*/
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// NopLogger discards everything. Constructors use it when they are given a nil Logger.
+var NopLogger Logger = slog.New(slog.DiscardHandler)
+
+// LogVerbosity is how much is logged, as chosen with the CLI's --quiet, --verbose and --debug flags.
+type LogVerbosity int
+
+const (
+	LogQuiet   LogVerbosity = iota - 1 // Errors only.
+	LogNormal                          // Warnings and errors.
+	LogVerbose                         // Also what nicksh is doing, e.g. which history file it reads.
+	LogDebug                           // Also the decisions it makes, e.g. why a candidate alias name was rejected.
+)
+
+// LogConfigurator configures the Logger handed to the adapters and services once the
+// command line is parsed, as they are all created before.
+type LogConfigurator interface {
+	// ConfigureLogging sets the verbosity of the console log. If logFile is set, every
+	// record at that verbosity, and at least LogVerbose, is also appended to it as JSON.
+	ConfigureLogging(verbosity LogVerbosity, logFile string) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+/*
+NamingConventionProvider defines the interface for sourcing well-known community
+alias names, such as the ones of the oh-my-zsh git and kubectl plugins
+("gst" for "git status"). The conventions are grouped by tool, and each tool
+can be turned off.
+*/
+type NamingConventionProvider interface {
+	// GetConventionalNames returns the conventional alias name of each command,
+	// keyed by command, for the enabled tools only.
+	GetConventionalNames() (map[string]string, error)
+
+	// AvailableTools returns the names of the tools that have conventions.
+	AvailableTools() []string
+
+	// DisableTools turns off the conventions of the given tools; "all" turns off every tool.
+	// It returns an error for an unknown tool name.
+	DisableTools(tools []string) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+// ProjectAliasProvider defines the interface for sourcing project-local aliases,
+// such as the ones defined in a .nicksh.yaml file at the root of a project.
+type ProjectAliasProvider interface {
+	// FindProjectFile looks for the project alias file governing dir, walking up
+	// towards the filesystem root. It returns an empty path if there is none.
+	FindProjectFile(dir string) (string, error)
+
+	// GetProjectAliases loads the aliases defined in the given project alias file.
+	GetProjectAliases(projectFile string) ([]alias.Alias, error)
+}
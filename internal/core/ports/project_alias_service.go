@@ -0,0 +1,25 @@
+package ports
+
+import "github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+
+// ProjectAliases holds the aliases that apply to a directory and where they come from.
+type ProjectAliases struct {
+	ProjectFile string        // Empty if no project alias file governs the directory.
+	Allowed     bool          // Whether the user allowed ProjectFile as it is now. Aliases of other files must not be loaded.
+	Aliases     []alias.Alias // Aliases that passed validation.
+	Skipped     []alias.Alias // Aliases rejected by validation (e.g., invalid names).
+}
+
+// ProjectAliasService defines the contract for resolving per-directory project aliases.
+type ProjectAliasService interface {
+	// GetProjectAliases returns the project aliases that apply to the given directory.
+	GetProjectAliases(dir string) (ProjectAliases, error)
+
+	// AllowProject allows the project alias file governing dir to be loaded, with its
+	// current content, and returns its path. It returns an error if there is none.
+	AllowProject(dir string) (string, error)
+
+	// DenyProject revokes the permission to load the project alias file governing dir,
+	// and returns its path. It returns an error if there is none.
+	DenyProject(dir string) (string, error)
+}
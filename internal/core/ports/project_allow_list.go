@@ -0,0 +1,14 @@
+package ports
+
+// ProjectAllowList records the project alias files the user allowed to be loaded into their shell.
+// A file is allowed as it was when allowed: once its content changes, it must be allowed again.
+type ProjectAllowList interface {
+	// Allow allows the project alias file at projectFile, with its current content.
+	Allow(projectFile string) error
+
+	// Deny removes projectFile from the allowed files. Denying a file that is not allowed is not an error.
+	Deny(projectFile string) error
+
+	// IsAllowed reports whether projectFile was allowed and has not changed since.
+	IsAllowed(projectFile string) (bool, error)
+}
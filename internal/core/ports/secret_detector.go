@@ -0,0 +1,16 @@
+package ports
+
+/*
+SecretDetector defines the contract for finding secrets (API tokens, passwords,
+private keys, ...) in commands, so they are never written to alias files.
+This is a driven port, typically implemented by an adapter matching known
+token formats.
+*/
+type SecretDetector interface {
+	// DetectSecrets describes each kind of secret found in command (e.g. "AWS access key ID"),
+	// or returns nil if command contains none.
+	DetectSecrets(command string) []string
+
+	// Redact returns command with every secret replaced by a placeholder.
+	Redact(command string) string
+}
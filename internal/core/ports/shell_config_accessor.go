@@ -12,15 +12,116 @@ type ShellConfigAccessor interface {
 	   GetExistingAliases retrieves all aliases currently defined in the relevant
 	   shell configuration file(s).
 	   It returns a map where the key is the alias name and the value is the command,
-	   and an error if one occurred.
+	   and an error if one occurred. An alias name defined in more than one file
+	   is reported as an *alias.DuplicateNameError.
 	*/
 	GetExistingAliases() (map[string]string, error)
 
+	/*
+	   GetAliasDefinitions retrieves every alias definition found in the managed
+	   alias files, including the group, file and line each one comes from.
+	   Duplicates are returned as-is and are not treated as an error.
+	*/
+	GetAliasDefinitions() ([]alias.Definition, error)
+
+	/*
+	   GetStartupAliasDefinitions retrieves the alias definitions found in the startup
+	   files of the user's shell (e.g. ~/.zshrc), which the shell loads besides the
+	   managed alias files. Their group is empty.
+	*/
+	GetStartupAliasDefinitions() ([]alias.Definition, error)
+
+	/*
+	   SupportsKind reports whether the user's shell supports aliases of the given
+	   kind (one of the alias.Kind* constants). Aliases of other kinds are refused
+	   by AddAlias and ReplaceAlias.
+	*/
+	SupportsKind(kind string) bool
+
+	/*
+	   AliasLoader returns the code that loads the managed alias files, for the user
+	   to add to a startup file of their shell.
+	*/
+	AliasLoader() string
+
+	/*
+	   StartupFile returns the startup file the alias loader goes in, e.g. "~/.zshrc",
+	   and the command reloading it in a running shell, or "" if the shell has none.
+	*/
+	StartupFile() (path string, reload string)
+
+	/*
+	   QuoteString quotes s as a literal string for the named shell (e.g. "fish"),
+	   or for the selected dialect if shell is empty. It returns an error for unknown shells.
+	*/
+	QuoteString(shell, s string) (string, error)
+
+	/*
+	   FormatDefinition renders a as the line AddAlias writes for it, newline
+	   included, e.g. "alias gs='git status'\n" or "abbr -a gs 'git status'\n".
+	*/
+	FormatDefinition(a alias.Alias) string
+
+	/*
+	   UnloadCommand returns the command removing the alias name from a running
+	   shell, e.g. "unalias gs" or, for fish abbreviations, "abbr --erase gs".
+	*/
+	UnloadCommand(name string) string
+
 	/*
 	   AddAlias appends a new alias to the appropriate shell configuration file.
 	   newAlias is the Alias struct containing the name and command for the new alias.
-	   It returns true if the alias was successfully added, false if it already exists,
-	   and an error if one occurred.
+	   The alias is written to the file of newAlias.Group, or to the default group if empty.
+	   Nothing is written if the name is already defined. The result describes
+	   what was done, for the caller to present; an error is returned if one occurred.
+	*/
+	AddAlias(newAlias alias.Alias) (AddAliasResult, error)
+
+	/*
+	   MoveAlias moves the definition of the alias with the given name into targetGroup.
+	   It returns an error if the alias does not exist or the group name is invalid.
+	*/
+	MoveAlias(name, targetGroup string) error
+
+	/*
+	   ReplaceAlias writes newAlias to the file of its group, replacing every existing
+	   definition of its name, in any group. Commands containing secrets are refused
+	   as in AddAlias.
+	*/
+	ReplaceAlias(newAlias alias.Alias) error
+
+	/*
+	   RemoveAlias removes every definition of the alias with the given name.
+	   It returns an error if the alias does not exist.
 	*/
-	AddAlias(newAlias alias.Alias) (bool, error)
+	RemoveAlias(name string) error
+}
+
+/*
+AddAliasResult describes what ShellConfigAccessor.AddAlias did.
+*/
+type AddAliasResult struct {
+	Added           bool     // False when the name was already defined: nothing was written.
+	File            string   // The file the alias was written to, or the file already defining the name.
+	Line            int      // The line (1-based) of that definition in File.
+	ExistingCommand string   // The command already defined for the name, when not Added.
+	Warnings        []string // Problems that did not prevent the operation, e.g. alias files that could not be read.
+}
+
+// AliasDialectSelector selects the shell dialect a ShellConfigAccessor reads and writes alias definitions in.
+type AliasDialectSelector interface {
+	// SelectAliasDialect selects a dialect by name, e.g. "nu" or "pwsh".
+	// "" selects the dialect of the user's shell ($SHELL).
+	SelectAliasDialect(name string) error
+	// AvailableAliasDialects lists the dialect names that can be selected.
+	AvailableAliasDialects() []string
+}
+
+// AliasStyleSelector selects how a ShellConfigAccessor writes alias definitions.
+type AliasStyleSelector interface {
+	// SelectAliasStyle selects a style by name: "alias", or "abbr" for fish abbreviations.
+	// "" selects the default style of the user's shell.
+	SelectAliasStyle(name string) error
+	// AvailableAliasStyles lists the style names that can be selected.
+	AvailableAliasStyles() []string
 }
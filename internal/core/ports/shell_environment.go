@@ -0,0 +1,20 @@
+package ports
+
+// RCFile is a shell startup file, such as ~/.zshrc.
+type RCFile struct {
+	Path    string
+	Content string
+}
+
+// ShellEnvironment gives access to the environment nicksh runs in: the user's shell,
+// environment variables, the commands on PATH and the shell's startup files.
+type ShellEnvironment interface {
+	// Shell returns the name of the user's shell, taken from $SHELL (e.g. "zsh"), or "" if it is not set.
+	Shell() string
+	// LookupEnv returns the value of an environment variable, and whether it is set.
+	LookupEnv(key string) (string, bool)
+	// LookPath returns the path of the executable name on PATH, or an error if there is none.
+	LookPath(name string) (string, error)
+	// RCFiles returns the startup files of the given shell that exist, in the order the shell reads them.
+	RCFiles(shell string) ([]RCFile, error)
+}
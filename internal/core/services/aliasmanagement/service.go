@@ -9,36 +9,47 @@ import (
 
 type service struct {
 	shellConfig ports.ShellConfigAccessor
+	validator   ports.AliasValidator
+	logger      ports.Logger
 }
 
 // NewService creates a new alias management service.
-// It panics if the shellConfigAccessor is nil.
-func NewService(sc ports.ShellConfigAccessor) ports.AliasManagementService {
+// It panics if the shellConfigAccessor or the validator is nil. logger can be nil if nothing should be logged.
+func NewService(sc ports.ShellConfigAccessor, validator ports.AliasValidator, logger ports.Logger) ports.AliasManagementService {
 	if sc == nil {
 		panic("shellConfig cannot be nil")
 	}
-	return &service{shellConfig: sc}
+	if validator == nil {
+		panic("validator cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{shellConfig: sc, validator: validator, logger: logger}
 }
 
-// AddAliasToConfig adds a new alias to the shell configuration.
-// It returns true if the alias was newly added, false if it already existed (and was not overwritten),
-// and an error if the operation failed.
-func (s *service) AddAliasToConfig(name, command string) (bool, error) {
+// AddAliasToConfig adds a new alias of the given kind to the shell configuration, in the given group.
+// The result tells whether the alias was newly added or already existed (and was not overwritten),
+// and an error is returned if the operation failed.
+func (s *service) AddAliasToConfig(name, command, group, kind string) (ports.AddAliasResult, error) {
 	if s.shellConfig == nil {
 		// This check is defensive; NewService should prevent s.shellConfig from being nil.
-		return false, fmt.Errorf("shellConfig is not initialized")
+		return ports.AddAliasResult{}, fmt.Errorf("shellConfig is not initialized")
 	}
 	newAlias := alias.Alias{
 		Name:    name,
 		Command: command,
+		Group:   group,
+		Kind:    kind,
 	}
-	// Assuming s.shellConfig.AddAlias now returns (bool, error)
-	// as per your internal/repositories/shellconfig/shell_config_accessor.go modification
-	wasAdded, err := s.shellConfig.AddAlias(newAlias)
+	result, err := s.shellConfig.AddAlias(newAlias)
 	if err != nil {
-		return false, fmt.Errorf("failed to add alias '%s': %w", name, err)
+		return ports.AddAliasResult{}, fmt.Errorf("failed to add alias '%s': %w", name, err)
 	}
-	return wasAdded, nil
+	if !result.Added {
+		s.logger.Debug("did not add alias: the name is already defined", "name", name, "file", result.File, "line", result.Line)
+	}
+	return result, nil
 }
 
 // ListAliases retrieves all aliases currently managed by the shell configuration.
@@ -53,3 +64,70 @@ func (s *service) ListAliases() (map[string]string, error) {
 	}
 	return aliases, nil
 }
+
+// ValidateAliases checks every definition of the managed alias files, duplicates included.
+func (s *service) ValidateAliases() ([]alias.Issue, error) {
+	definitions, err := s.shellConfig.GetAliasDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aliases to validate: %w", err)
+	}
+	issues := s.validator.Validate(definitions)
+	s.logger.Debug("validated aliases", "definitions", len(definitions), "issues", len(issues))
+	return issues, nil
+}
+
+// ListAliasesInGroup retrieves the aliases defined in the given group.
+func (s *service) ListAliasesInGroup(group string) (map[string]string, error) {
+	definitions, err := s.shellConfig.GetAliasDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases in group '%s': %w", group, err)
+	}
+	aliases := make(map[string]string)
+	for _, def := range definitions {
+		if def.Group == group {
+			aliases[def.Name] = def.Command
+		}
+	}
+	return aliases, nil
+}
+
+// MoveAlias moves an existing alias into the given group.
+func (s *service) MoveAlias(name, group string) error {
+	if err := s.shellConfig.MoveAlias(name, group); err != nil {
+		return fmt.Errorf("failed to move alias '%s' to group '%s': %w", name, group, err)
+	}
+	return nil
+}
+
+// RemoveAlias removes an existing alias.
+func (s *service) RemoveAlias(name string) error {
+	if err := s.shellConfig.RemoveAlias(name); err != nil {
+		return fmt.Errorf("failed to remove alias '%s': %w", name, err)
+	}
+	return nil
+}
+
+// AliasLoader returns the code that loads the managed alias files, in the dialect of the user's shell.
+func (s *service) AliasLoader() string {
+	return s.shellConfig.AliasLoader()
+}
+
+// StartupFile returns the startup file the alias loader goes in, and the command reloading it, for the user's shell.
+func (s *service) StartupFile() (string, string) {
+	return s.shellConfig.StartupFile()
+}
+
+// QuoteString quotes s as a literal string for the named shell, as the shell configuration writes it.
+func (s *service) QuoteString(shell, str string) (string, error) {
+	return s.shellConfig.QuoteString(shell, str)
+}
+
+// FormatDefinition renders a as the shell configuration writes it, in the dialect and style of the user's shell.
+func (s *service) FormatDefinition(a alias.Alias) string {
+	return s.shellConfig.FormatDefinition(a)
+}
+
+// UnloadCommand returns the command removing the alias name from a running shell, in the dialect and style of the user's shell.
+func (s *service) UnloadCommand(name string) string {
+	return s.shellConfig.UnloadCommand(name)
+}
@@ -7,13 +7,14 @@ import (
 	"testing"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/AntonioJCosta/nicksh/internal/core/testutil" // Assuming this path is correct
 )
 
 func TestNewService(t *testing.T) {
 	t.Run("should return a service if shellConfig is not nil", func(t *testing.T) {
 		mockSC := &testutil.MockShellConfigAccessor{}
-		svc := NewService(mockSC)
+		svc := NewService(mockSC, &testutil.MockAliasValidator{}, nil)
 		if svc == nil {
 			t.Fatal("NewService() returned nil, expected a service instance")
 		}
@@ -25,10 +26,60 @@ func TestNewService(t *testing.T) {
 				t.Error("NewService did not panic with nil shellConfig")
 			}
 		}()
-		_ = NewService(nil) // Panics if sc is nil
+		_ = NewService(nil, &testutil.MockAliasValidator{}, nil) // Panics if sc is nil
+	})
+
+	t.Run("should panic if validator is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewService did not panic with nil validator")
+			}
+		}()
+		_ = NewService(&testutil.MockShellConfigAccessor{}, nil, nil)
 	})
 }
 
+func TestService_ValidateAliases(t *testing.T) {
+	definitions := []alias.Definition{
+		{Alias: alias.Alias{Name: "a", Command: "b"}, File: "/home/u/.nicksh/generated_aliases", Line: 1},
+		{Alias: alias.Alias{Name: "b", Command: "a"}, File: "/home/u/.nicksh/generated_aliases", Line: 2},
+	}
+	cycle := alias.Issue{Kind: alias.IssueCycle, Name: "a", Chain: []string{"a", "b", "a"}}
+	readErr := errors.New("read error")
+
+	tests := []struct {
+		name       string
+		readErr    error
+		wantIssues []alias.Issue
+		wantErr    error
+	}{
+		{name: "issues of every definition", wantIssues: []alias.Issue{cycle}},
+		{name: "read error", readErr: readErr, wantErr: readErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSC := &testutil.MockShellConfigAccessor{GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+				return definitions, tt.readErr
+			}}
+			var validated []alias.Definition
+			validator := &testutil.MockAliasValidator{ValidateFunc: func(defs []alias.Definition) []alias.Issue {
+				validated = defs
+				return []alias.Issue{cycle}
+			}}
+			issues, err := NewService(mockSC, validator, nil).ValidateAliases()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateAliases() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(issues, tt.wantIssues) {
+				t.Errorf("ValidateAliases() = %+v, want %+v", issues, tt.wantIssues)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(validated, definitions) {
+				t.Errorf("ValidateAliases() validated %+v, want %+v", validated, definitions)
+			}
+		})
+	}
+}
+
 func TestService_AddAliasToConfig(t *testing.T) {
 	testAlias := alias.Alias{Name: "test", Command: "echo test"}
 
@@ -36,8 +87,9 @@ func TestService_AddAliasToConfig(t *testing.T) {
 		name          string
 		aliasName     string
 		aliasCommand  string
+		kind          string
 		setupMock     func(mockSC *testutil.MockShellConfigAccessor)
-		wantAdded     bool
+		wantResult    ports.AddAliasResult
 		wantErr       bool
 		expectedError error
 	}{
@@ -46,39 +98,53 @@ func TestService_AddAliasToConfig(t *testing.T) {
 			aliasName:    testAlias.Name,
 			aliasCommand: testAlias.Command,
 			setupMock: func(mockSC *testutil.MockShellConfigAccessor) {
-				mockSC.AddAliasFunc = func(newAlias alias.Alias) (bool, error) {
+				mockSC.AddAliasFunc = func(newAlias alias.Alias) (ports.AddAliasResult, error) {
 					if newAlias.Name != testAlias.Name || newAlias.Command != testAlias.Command {
 						t.Errorf("AddAlias received wrong alias. Got %+v, want %+v", newAlias, testAlias)
 					}
-					return true, nil // Simulate alias was newly added
+					return ports.AddAliasResult{Added: true, File: "/home/u/.nicksh/generated_aliases", Line: 3}, nil // Simulate alias was newly added
+				}
+			},
+			wantResult: ports.AddAliasResult{Added: true, File: "/home/u/.nicksh/generated_aliases", Line: 3},
+			wantErr:    false,
+		},
+		{
+			name:         "success - global alias",
+			aliasName:    "G",
+			aliasCommand: "| grep",
+			kind:         alias.KindGlobal,
+			setupMock: func(mockSC *testutil.MockShellConfigAccessor) {
+				mockSC.AddAliasFunc = func(newAlias alias.Alias) (ports.AddAliasResult, error) {
+					if newAlias.Kind != alias.KindGlobal {
+						t.Errorf("AddAlias received kind %q, want %q", newAlias.Kind, alias.KindGlobal)
+					}
+					return ports.AddAliasResult{Added: true, File: "/home/u/.nicksh/generated_aliases", Line: 4}, nil
 				}
 			},
-			wantAdded: true,
-			wantErr:   false,
+			wantResult: ports.AddAliasResult{Added: true, File: "/home/u/.nicksh/generated_aliases", Line: 4},
 		},
 		{
 			name:         "success - alias already existed (not overwritten, or updated)",
 			aliasName:    testAlias.Name,
 			aliasCommand: testAlias.Command,
 			setupMock: func(mockSC *testutil.MockShellConfigAccessor) {
-				mockSC.AddAliasFunc = func(newAlias alias.Alias) (bool, error) {
-					// Logic for checking newAlias can remain the same
-					return false, nil // Simulate alias already existed
+				mockSC.AddAliasFunc = func(newAlias alias.Alias) (ports.AddAliasResult, error) {
+					// Simulate alias already existed
+					return ports.AddAliasResult{File: "/home/u/.nicksh/git", Line: 1, ExistingCommand: "echo other"}, nil
 				}
 			},
-			wantAdded: false,
-			wantErr:   false,
+			wantResult: ports.AddAliasResult{File: "/home/u/.nicksh/git", Line: 1, ExistingCommand: "echo other"},
+			wantErr:    false,
 		},
 		{
 			name:         "failure - shellConfig returns error",
 			aliasName:    testAlias.Name,
 			aliasCommand: testAlias.Command,
 			setupMock: func(mockSC *testutil.MockShellConfigAccessor) {
-				mockSC.AddAliasFunc = func(newAlias alias.Alias) (bool, error) {
-					return false, errors.New("shell config error")
+				mockSC.AddAliasFunc = func(newAlias alias.Alias) (ports.AddAliasResult, error) {
+					return ports.AddAliasResult{}, errors.New("shell config error")
 				}
 			},
-			wantAdded:     false,
 			wantErr:       true,
 			expectedError: errors.New("shell config error"), // The specific error from the mock
 		},
@@ -90,9 +156,9 @@ func TestService_AddAliasToConfig(t *testing.T) {
 			if tt.setupMock != nil {
 				tt.setupMock(mockSC)
 			}
-			svc := NewService(mockSC)
+			svc := NewService(mockSC, &testutil.MockAliasValidator{}, nil)
 
-			gotAdded, err := svc.AddAliasToConfig(tt.aliasName, tt.aliasCommand)
+			gotResult, err := svc.AddAliasToConfig(tt.aliasName, tt.aliasCommand, "", tt.kind)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddAliasToConfig() error = %v, wantErr %v", err, tt.wantErr)
@@ -104,8 +170,8 @@ func TestService_AddAliasToConfig(t *testing.T) {
 					t.Errorf("AddAliasToConfig() error = %v, want error containing %v", err, tt.expectedError)
 				}
 			}
-			if gotAdded != tt.wantAdded {
-				t.Errorf("AddAliasToConfig() gotAdded = %v, want %v", gotAdded, tt.wantAdded)
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("AddAliasToConfig() result = %+v, want %+v", gotResult, tt.wantResult)
 			}
 		})
 	}
@@ -151,7 +217,7 @@ func TestService_ListAliases(t *testing.T) {
 			if tt.setupMock != nil {
 				tt.setupMock(mockSC)
 			}
-			svc := NewService(mockSC)
+			svc := NewService(mockSC, &testutil.MockAliasValidator{}, nil)
 
 			aliases, err := svc.ListAliases()
 
@@ -169,6 +235,144 @@ func TestService_ListAliases(t *testing.T) {
 	}
 }
 
+func TestService_ListAliasesInGroup(t *testing.T) {
+	definitions := []alias.Definition{
+		{Alias: alias.Alias{Name: "gs", Command: "git status", Group: "git"}, File: "/home/u/.nicksh/git", Line: 1},
+		{Alias: alias.Alias{Name: "k", Command: "kubectl", Group: "k8s"}, File: "/home/u/.nicksh/k8s", Line: 1},
+		{Alias: alias.Alias{Name: "gp", Command: "git push", Group: "git"}, File: "/home/u/.nicksh/git", Line: 2},
+	}
+
+	tests := []struct {
+		name           string
+		group          string
+		definitionsErr error
+		expectedResult map[string]string
+		wantErr        bool
+	}{
+		{
+			name:           "filters definitions by group",
+			group:          "git",
+			expectedResult: map[string]string{"gs": "git status", "gp": "git push"},
+		},
+		{
+			name:           "unknown group yields empty map",
+			group:          "work",
+			expectedResult: map[string]string{},
+		},
+		{
+			name:           "shellConfig returns error",
+			group:          "git",
+			definitionsErr: errors.New("read error"),
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSC := &testutil.MockShellConfigAccessor{
+				GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+					if tt.definitionsErr != nil {
+						return nil, tt.definitionsErr
+					}
+					return definitions, nil
+				},
+			}
+			svc := NewService(mockSC, &testutil.MockAliasValidator{}, nil)
+
+			aliases, err := svc.ListAliasesInGroup(tt.group)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListAliasesInGroup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.definitionsErr) {
+					t.Errorf("ListAliasesInGroup() error = %v, want wrapped %v", err, tt.definitionsErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(aliases, tt.expectedResult) {
+				t.Errorf("ListAliasesInGroup() = %v, want %v", aliases, tt.expectedResult)
+			}
+		})
+	}
+}
+
+func TestService_MoveAlias(t *testing.T) {
+	moveErr := errors.New("move error")
+
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{name: "success", mockErr: nil, wantErr: false},
+		{name: "failure - shellConfig returns error", mockErr: moveErr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotName, gotGroup string
+			mockSC := &testutil.MockShellConfigAccessor{
+				MoveAliasFunc: func(name, targetGroup string) error {
+					gotName, gotGroup = name, targetGroup
+					return tt.mockErr
+				},
+			}
+			svc := NewService(mockSC, &testutil.MockAliasValidator{}, nil)
+
+			err := svc.MoveAlias("gs", "git")
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, tt.mockErr) {
+				t.Errorf("MoveAlias() error = %v, want wrapped %v", err, tt.mockErr)
+			}
+			if gotName != "gs" || gotGroup != "git" {
+				t.Errorf("MoveAlias() forwarded (%q, %q), want (%q, %q)", gotName, gotGroup, "gs", "git")
+			}
+		})
+	}
+}
+
+func TestService_RemoveAlias(t *testing.T) {
+	removeErr := errors.New("remove error")
+
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{name: "success", mockErr: nil, wantErr: false},
+		{name: "failure - shellConfig returns error", mockErr: removeErr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotName string
+			mockSC := &testutil.MockShellConfigAccessor{
+				RemoveAliasFunc: func(name string) error {
+					gotName = name
+					return tt.mockErr
+				},
+			}
+			svc := NewService(mockSC, &testutil.MockAliasValidator{}, nil)
+
+			err := svc.RemoveAlias("gs")
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemoveAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, tt.mockErr) {
+				t.Errorf("RemoveAlias() error = %v, want wrapped %v", err, tt.mockErr)
+			}
+			if gotName != "gs" {
+				t.Errorf("RemoveAlias() forwarded %q, want %q", gotName, "gs")
+			}
+		})
+	}
+}
+
 // TestService_GetShellConfigPath assumes GetShellConfigPath is a method on your service.
 // If it's not, this test is for a non-existent method.
 // The provided service.go snippet does not show this method.
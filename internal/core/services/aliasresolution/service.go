@@ -0,0 +1,73 @@
+package aliasresolution
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+type service struct {
+	shellConfig ports.ShellConfigAccessor
+	resolver    ports.CommandResolver
+	logger      ports.Logger
+}
+
+// NewService creates a new alias resolution service.
+// It panics if shellConfig or resolver is nil. logger can be nil if nothing should be logged.
+func NewService(sc ports.ShellConfigAccessor, resolver ports.CommandResolver, logger ports.Logger) ports.AliasResolutionService {
+	if sc == nil {
+		panic("shellConfig cannot be nil")
+	}
+	if resolver == nil {
+		panic("resolver cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{shellConfig: sc, resolver: resolver, logger: logger}
+}
+
+// Which looks name up in the definitions loaded by the shell, and on PATH.
+func (s *service) Which(name string) (ports.AliasLookup, error) {
+	definitions, err := s.loadedDefinitions()
+	if err != nil {
+		return ports.AliasLookup{}, err
+	}
+	lookup := ports.AliasLookup{Name: name}
+	for _, def := range definitions {
+		if def.Name == name {
+			lookup.Definitions = append(lookup.Definitions, def)
+		}
+	}
+	lookup.Path, lookup.IsBuiltin = s.resolver.Locate(name)
+	return lookup, nil
+}
+
+// Expand expands the aliases of line with the definitions in effect.
+func (s *service) Expand(line string) (ports.Expansion, error) {
+	definitions, err := s.loadedDefinitions()
+	if err != nil {
+		return ports.Expansion{}, err
+	}
+	e := newExpander(definitions)
+	expanded, _ := e.expand(line, nil, true)
+	for _, loop := range e.loops {
+		s.logger.Debug("alias expansion loop", "aliases", loop)
+	}
+	return ports.Expansion{Original: line, Expanded: expanded, Steps: e.steps, Loops: e.loops}, nil
+}
+
+// loadedDefinitions returns the alias definitions the shell loads, in order: those of the
+// startup files first, since the loader of the managed alias files is usually at their end.
+func (s *service) loadedDefinitions() ([]alias.Definition, error) {
+	startup, err := s.shellConfig.GetStartupAliasDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the aliases of the startup files: %w", err)
+	}
+	managed, err := s.shellConfig.GetAliasDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the managed aliases: %w", err)
+	}
+	return append(startup, managed...), nil
+}
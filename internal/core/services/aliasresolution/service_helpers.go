@@ -0,0 +1,247 @@
+package aliasresolution
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// reservedWords are the reserved words after which the next word still starts a command.
+var reservedWords = map[string]bool{
+	"if": true, "then": true, "else": true, "elif": true, "do": true,
+	"while": true, "until": true, "!": true, "{": true, "time": true,
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	blankToken
+	operatorToken
+)
+
+// token is a piece of a command line: a word (quotes included), a run of blanks or a control operator.
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits a command line into tokens. Joining their texts gives back the line.
+func tokenize(line string) []token {
+	var tokens []token
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		start := i
+		switch r := runes[i]; {
+		case r == ' ' || r == '\t':
+			for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t') {
+				i++
+			}
+			tokens = append(tokens, token{kind: blankToken, text: string(runes[start:i])})
+		case strings.ContainsRune(";&|()\n", r):
+			i++
+			// Two-character operators: &&, ||, ;;, |&.
+			if i < len(runes) && ((r == '&' && runes[i] == '&') || (r == '|' && (runes[i] == '|' || runes[i] == '&')) || (r == ';' && runes[i] == ';')) {
+				i++
+			}
+			tokens = append(tokens, token{kind: operatorToken, text: string(runes[start:i])})
+		default:
+			i = wordEnd(runes, i)
+			tokens = append(tokens, token{kind: wordToken, text: string(runes[start:i])})
+		}
+	}
+	return tokens
+}
+
+// wordEnd returns the index just past the word starting at i, skipping over quoted and escaped characters.
+// An unterminated quote runs to the end of the line.
+func wordEnd(runes []rune, i int) int {
+	for i < len(runes) {
+		switch r := runes[i]; {
+		case r == '\\':
+			i += 2
+		case r == '\'':
+			i++
+			for i < len(runes) && runes[i] != '\'' {
+				i++
+			}
+			i++
+		case r == '"':
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+		case r == ' ' || r == '\t' || strings.ContainsRune(";&|()\n", r):
+			return i
+		default:
+			i++
+		}
+	}
+	return len(runes)
+}
+
+// expander expands the aliases of command lines, recording what it did.
+// aliases holds the regular and global aliases; globals tells which are global, and suffixes
+// maps the file extensions of the suffix aliases to their command.
+type expander struct {
+	aliases  map[string]string
+	globals  map[string]bool
+	suffixes map[string]string
+	steps    []ports.ExpansionStep
+	loops    [][]string
+}
+
+// newExpander returns an expander of the aliases of definitions. The last definition of a name wins, as in the shell.
+func newExpander(definitions []alias.Definition) *expander {
+	e := &expander{aliases: make(map[string]string), globals: make(map[string]bool), suffixes: make(map[string]string)}
+	for _, def := range definitions {
+		if def.Kind == alias.KindSuffix {
+			e.suffixes[def.Name] = def.Command
+			continue
+		}
+		e.aliases[def.Name] = def.Command
+		e.globals[def.Name] = def.Kind == alias.KindGlobal
+	}
+	return e
+}
+
+// expand expands the aliases of text. active holds the aliases being expanded, outermost first;
+// checkFirst tells whether the first word of text is to be checked for an alias.
+// It returns the expanded text, and whether the word that follows text is to be checked.
+func (e *expander) expand(text string, active []string, checkFirst bool) (string, bool) {
+	var out strings.Builder
+	check := checkFirst
+	for _, tok := range tokenize(text) {
+		switch tok.kind {
+		case blankToken:
+			out.WriteString(tok.text)
+		case operatorToken:
+			out.WriteString(tok.text)
+			check = tok.text != ")" // Every other operator starts a new command.
+		case wordToken:
+			value, isAlias := e.aliases[tok.text]
+			quoted := strings.ContainsAny(tok.text, `'"\`)
+			// Quoted words are never expanded; global aliases are expanded anywhere.
+			if quoted || !isAlias || (!check && !e.globals[tok.text]) {
+				if suffixCommand, ok := e.suffixes[suffix(tok.text)]; ok && check && !quoted {
+					// A suffix alias opens the file with its command, e.g. README.md runs code README.md.
+					e.steps = append(e.steps, ports.ExpansionStep{Name: suffix(tok.text), Command: suffixCommand, Depth: len(active)})
+					out.WriteString(suffixCommand + " " + tok.text)
+					check = false
+					continue
+				}
+				out.WriteString(tok.text)
+				check = check && reservedWords[tok.text]
+				continue
+			}
+			if i := slices.Index(active, tok.text); i >= 0 {
+				// Like the shell, an alias being expanded is not expanded again.
+				if i != len(active)-1 {
+					e.loops = append(e.loops, append(slices.Clone(active[i:]), tok.text))
+				}
+				out.WriteString(tok.text)
+				check = false
+				continue
+			}
+			e.steps = append(e.steps, ports.ExpansionStep{Name: tok.text, Command: value, Depth: len(active)})
+			expanded, checkNext := e.expand(value, append(slices.Clone(active), tok.text), check)
+			out.WriteString(expanded)
+			// A value ending with a blank makes the shell check the next word too (e.g. sudo='sudo ').
+			check = checkNext || strings.HasSuffix(value, " ") || strings.HasSuffix(value, "\t")
+		}
+	}
+	return out.String(), check
+}
+
+// suffix returns the extension of the file name word without its dot (e.g. "md"), or "" if it has none.
+func suffix(word string) string {
+	dot := strings.LastIndex(word, ".")
+	if dot <= 0 || strings.Contains(word[dot:], "/") {
+		return ""
+	}
+	return word[dot+1:]
+}
+
+// commandWords returns the words of value the shell would check for an alias: the first word
+// of each command, the word after an alias whose value ends with a blank, and the global aliases
+// anywhere. Quoted words and reserved words are left out, as they are never expanded nor run.
+func commandWords(value string, aliases map[string]string, globals map[string]bool) []string {
+	var words []string
+	check := true
+	for _, tok := range tokenize(value) {
+		switch tok.kind {
+		case operatorToken:
+			check = tok.text != ")"
+		case wordToken:
+			if strings.ContainsAny(tok.text, `'"\`) {
+				check = false
+				continue
+			}
+			if !check {
+				if globals[tok.text] && !slices.Contains(words, tok.text) {
+					words = append(words, tok.text)
+				}
+				continue
+			}
+			if reservedWords[tok.text] {
+				continue
+			}
+			if !slices.Contains(words, tok.text) {
+				words = append(words, tok.text)
+			}
+			aliasValue, isAlias := aliases[tok.text]
+			check = isAlias && (strings.HasSuffix(aliasValue, " ") || strings.HasSuffix(aliasValue, "\t"))
+		}
+	}
+	return words
+}
+
+// definedInFiles returns the number of files definitions come from.
+func definedInFiles(definitions []alias.Definition) int {
+	files := make(map[string]bool)
+	for _, def := range definitions {
+		files[def.File] = true
+	}
+	return len(files)
+}
+
+// findCycles returns a cycle issue for each cycle of the expansion graph found by a depth-first
+// search from each name, in order. A cycle is reported once, starting from its alias the search reached first.
+func findCycles(names []string, edges map[string][]string) []alias.Issue {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[string]int, len(names))
+	var stack []string
+	var issues []alias.Issue
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = onStack
+		stack = append(stack, name)
+		for _, next := range edges[name] {
+			switch state[next] {
+			case onStack:
+				chain := append(slices.Clone(stack[slices.Index(stack, next):]), next)
+				issues = append(issues, alias.Issue{Kind: alias.IssueCycle, Name: next, Chain: chain})
+			case unvisited:
+				visit(next)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,266 @@
+package aliasresolution
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// managed returns a mocked ShellConfigAccessor whose managed aliases are given as name=command pairs.
+// A name prefixed with "-g " or "-s " is that of a global or suffix alias, as in zsh.
+func managed(pairs ...string) *testutil.MockShellConfigAccessor {
+	var definitions []alias.Definition
+	for i, pair := range pairs {
+		name, command, _ := strings.Cut(pair, "=")
+		kind := alias.KindRegular
+		if option, rest, hasOption := strings.Cut(name, " "); hasOption {
+			name, kind = rest, map[string]string{"-g": alias.KindGlobal, "-s": alias.KindSuffix}[option]
+		}
+		definitions = append(definitions, alias.Definition{
+			Alias: alias.Alias{Name: name, Command: command, Group: "generated_aliases", Kind: kind},
+			File:  "/home/u/.nicksh/generated_aliases",
+			Line:  i + 1,
+		})
+	}
+	return &testutil.MockShellConfigAccessor{GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+		return definitions, nil
+	}}
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("nil shellConfig panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewService() did not panic with a nil shellConfig")
+			}
+		}()
+		NewService(nil, &testutil.MockCommandResolver{}, nil)
+	})
+	t.Run("nil resolver panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewService() did not panic with a nil resolver")
+			}
+		}()
+		NewService(&testutil.MockShellConfigAccessor{}, nil, nil)
+	})
+}
+
+func TestService_Which(t *testing.T) {
+	sc := managed("gs=git status", "ls=ls --color")
+	sc.GetStartupAliasDefinitionsFunc = func() ([]alias.Definition, error) {
+		return []alias.Definition{{Alias: alias.Alias{Name: "gs", Command: "git switch"}, File: "/home/u/.zshrc", Line: 12}}, nil
+	}
+	resolver := &testutil.MockCommandResolver{LocateFunc: func(name string) (string, bool) {
+		switch name {
+		case "ls":
+			return "/bin/ls", false
+		case "cd":
+			return "", true
+		}
+		return "", false
+	}}
+	svc := NewService(sc, resolver, nil)
+
+	tests := []struct {
+		name          string
+		lookup        string
+		wantFiles     []string
+		wantEffective string
+		wantPath      string
+		wantBuiltin   bool
+	}{
+		{
+			name:          "alias in a startup file and a managed file",
+			lookup:        "gs",
+			wantFiles:     []string{"/home/u/.zshrc:12", "/home/u/.nicksh/generated_aliases:1"},
+			wantEffective: "git status",
+		},
+		{name: "alias shadowing an executable", lookup: "ls", wantFiles: []string{"/home/u/.nicksh/generated_aliases:2"}, wantEffective: "ls --color", wantPath: "/bin/ls"},
+		{name: "builtin", lookup: "cd", wantBuiltin: true},
+		{name: "unknown name", lookup: "nope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.Which(tt.lookup)
+			if err != nil {
+				t.Fatalf("Which(%q) error = %v", tt.lookup, err)
+			}
+			var files []string
+			for _, def := range got.Definitions {
+				files = append(files, fmt.Sprintf("%s:%d", def.File, def.Line))
+			}
+			if !reflect.DeepEqual(files, tt.wantFiles) {
+				t.Errorf("Which(%q) definitions = %v, want %v", tt.lookup, files, tt.wantFiles)
+			}
+			if got.IsAlias() != (tt.wantEffective != "") {
+				t.Errorf("Which(%q).IsAlias() = %v, want %v", tt.lookup, got.IsAlias(), tt.wantEffective != "")
+			}
+			if got.IsAlias() && got.Effective().Command != tt.wantEffective {
+				t.Errorf("Which(%q).Effective() = %q, want %q", tt.lookup, got.Effective().Command, tt.wantEffective)
+			}
+			if got.Path != tt.wantPath || got.IsBuiltin != tt.wantBuiltin {
+				t.Errorf("Which(%q) path, builtin = %q, %v, want %q, %v", tt.lookup, got.Path, got.IsBuiltin, tt.wantPath, tt.wantBuiltin)
+			}
+		})
+	}
+}
+
+func TestService_Which_Errors(t *testing.T) {
+	readErr := errors.New("permission denied")
+	sc := &testutil.MockShellConfigAccessor{GetStartupAliasDefinitionsFunc: func() ([]alias.Definition, error) { return nil, readErr }}
+	svc := NewService(sc, &testutil.MockCommandResolver{}, nil)
+	if _, err := svc.Which("gs"); !errors.Is(err, readErr) {
+		t.Errorf("Which() error = %v, want %v", err, readErr)
+	}
+	if _, err := svc.Expand("gs"); !errors.Is(err, readErr) {
+		t.Errorf("Expand() error = %v, want %v", err, readErr)
+	}
+}
+
+func TestService_Expand(t *testing.T) {
+	tests := []struct {
+		name      string
+		aliases   []string
+		line      string
+		want      string
+		wantSteps []ports.ExpansionStep
+		wantLoops [][]string
+	}{
+		{
+			name:      "simple alias with arguments",
+			aliases:   []string{"gs=git status"},
+			line:      "gs -sb",
+			want:      "git status -sb",
+			wantSteps: []ports.ExpansionStep{{Name: "gs", Command: "git status"}},
+		},
+		{
+			name:    "only command words are expanded",
+			aliases: []string{"gs=git status"},
+			line:    "echo gs",
+			want:    "echo gs",
+		},
+		{
+			name:    "every command of a list and pipeline",
+			aliases: []string{"gs=git status", "g=grep"},
+			line:    "gs && gs|g x; (gs) || echo gs",
+			want:    "git status && git status|grep x; (git status) || echo gs",
+		},
+		{
+			name:    "after reserved words",
+			aliases: []string{"gs=git status"},
+			line:    "if gs; then gs; fi",
+			want:    "if git status; then git status; fi",
+		},
+		{
+			name:    "quoted words are not expanded",
+			aliases: []string{"gs=git status"},
+			line:    `'gs'; \gs; "gs"`,
+			want:    `'gs'; \gs; "gs"`,
+		},
+		{
+			name:    "quoted separators do not start a command",
+			aliases: []string{"gs=git status"},
+			line:    "echo 'a; gs' \"b | gs\"",
+			want:    "echo 'a; gs' \"b | gs\"",
+		},
+		{
+			name:    "recursive aliases",
+			aliases: []string{"k=kubectl", "kg=k get"},
+			line:    "kg pods",
+			want:    "kubectl get pods",
+			wantSteps: []ports.ExpansionStep{
+				{Name: "kg", Command: "k get"},
+				{Name: "k", Command: "kubectl", Depth: 1},
+			},
+		},
+		{
+			name:    "trailing space chains to the next word",
+			aliases: []string{"sudo=sudo ", "gs=git status"},
+			line:    "sudo gs",
+			want:    "sudo  git status", // The blank of the value is kept.
+			wantSteps: []ports.ExpansionStep{
+				{Name: "sudo", Command: "sudo "},
+				{Name: "gs", Command: "git status"},
+			},
+		},
+		{
+			name:    "without trailing space the next word is not expanded",
+			aliases: []string{"s=sudo", "gs=git status"},
+			line:    "s gs",
+			want:    "sudo gs",
+		},
+		{
+			name:      "alias of its own name is not a loop",
+			aliases:   []string{"ls=ls --color"},
+			line:      "ls -l",
+			want:      "ls --color -l",
+			wantSteps: []ports.ExpansionStep{{Name: "ls", Command: "ls --color"}},
+		},
+		{
+			name:    "cycle is detected and stops",
+			aliases: []string{"a=b -x", "b=a -y"},
+			line:    "a",
+			want:    "a -y -x",
+			wantSteps: []ports.ExpansionStep{
+				{Name: "a", Command: "b -x"},
+				{Name: "b", Command: "a -y", Depth: 1},
+			},
+			wantLoops: [][]string{{"a", "b", "a"}},
+		},
+		{
+			name:      "global alias anywhere",
+			aliases:   []string{"-g G=| grep -i", "g=git"},
+			line:      "g log G fix",
+			want:      "git log | grep -i fix",
+			wantSteps: []ports.ExpansionStep{{Name: "g", Command: "git"}, {Name: "G", Command: "| grep -i"}},
+		},
+		{
+			name:    "global alias runs an alias after its pipe",
+			aliases: []string{"-g L=| l", "l=less -R"},
+			line:    "cat x L",
+			want:    "cat x | less -R",
+			wantSteps: []ports.ExpansionStep{
+				{Name: "L", Command: "| l"},
+				{Name: "l", Command: "less -R", Depth: 1},
+			},
+		},
+		{
+			name:      "suffix alias opens the file",
+			aliases:   []string{"-s md=code"},
+			line:      "README.md && cat NOTES.md",
+			want:      "code README.md && cat NOTES.md",
+			wantSteps: []ports.ExpansionStep{{Name: "md", Command: "code"}},
+		},
+		{
+			name:    "last definition wins",
+			aliases: []string{"gs=git show", "gs=git status"},
+			line:    "gs",
+			want:    "git status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(managed(tt.aliases...), &testutil.MockCommandResolver{}, nil)
+			got, err := svc.Expand(tt.line)
+			if err != nil {
+				t.Fatalf("Expand(%q) error = %v", tt.line, err)
+			}
+			if got.Original != tt.line || got.Expanded != tt.want {
+				t.Errorf("Expand(%q) = %q, want %q", tt.line, got.Expanded, tt.want)
+			}
+			if tt.wantSteps != nil && !reflect.DeepEqual(got.Steps, tt.wantSteps) {
+				t.Errorf("Expand(%q) steps = %+v, want %+v", tt.line, got.Steps, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(got.Loops, tt.wantLoops) {
+				t.Errorf("Expand(%q) loops = %v, want %v", tt.line, got.Loops, tt.wantLoops)
+			}
+		})
+	}
+}
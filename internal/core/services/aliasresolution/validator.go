@@ -0,0 +1,71 @@
+package aliasresolution
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+type validator struct {
+	resolver ports.CommandResolver
+}
+
+// NewValidator creates an alias validator, which expands aliases the way Expand does.
+// It panics if resolver is nil.
+func NewValidator(resolver ports.CommandResolver) ports.AliasValidator {
+	if resolver == nil {
+		panic("resolver cannot be nil")
+	}
+	return &validator{resolver: resolver}
+}
+
+// Validate builds the expansion graph of definitions, where an alias points to the aliases
+// its value runs, and reports its cycles and self-references, the commands run that cannot
+// be found, and the names defined in more than one file. Issues are sorted by alias name.
+func (v *validator) Validate(definitions []alias.Definition) []alias.Issue {
+	var names []string // In the order they were first defined.
+	byName := make(map[string][]alias.Definition)
+	for _, def := range definitions {
+		if _, seen := byName[def.Name]; !seen {
+			names = append(names, def.Name)
+		}
+		byName[def.Name] = append(byName[def.Name], def)
+	}
+	// Suffix aliases are not words of the command line: they run their command, but cannot be run by other aliases.
+	e := newExpander(definitions)
+	aliases := e.aliases
+
+	var issues []alias.Issue
+	edges := make(map[string][]string, len(names))
+	for _, name := range names {
+		if definedInFiles(byName[name]) > 1 {
+			issues = append(issues, alias.Issue{Kind: alias.IssueOverride, Name: name, Definitions: byName[name]})
+		}
+		value, isAlias := aliases[name]
+		if !isAlias {
+			value = e.suffixes[name]
+		}
+		for _, word := range commandWords(value, aliases, e.globals) {
+			if word == name && isAlias {
+				issues = append(issues, alias.Issue{Kind: alias.IssueSelfReference, Name: name, Chain: []string{name, name}})
+			} else if _, runsAlias := aliases[word]; runsAlias {
+				if !slices.Contains(edges[name], word) {
+					edges[name] = append(edges[name], word)
+				}
+				continue
+			}
+			// The shell runs a self-reference as a command too.
+			if !v.resolver.IsResolvable(word) {
+				issues = append(issues, alias.Issue{Kind: alias.IssueDangling, Name: name, Command: word})
+			}
+		}
+	}
+	issues = append(issues, findCycles(names, edges)...)
+
+	slices.SortStableFunc(issues, func(a, b alias.Issue) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return issues
+}
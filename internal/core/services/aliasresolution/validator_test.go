@@ -0,0 +1,134 @@
+package aliasresolution
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+func TestNewValidator(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewValidator() did not panic with a nil resolver")
+		}
+	}()
+	NewValidator(nil)
+}
+
+func TestValidator_Validate(t *testing.T) {
+	// Only these commands are on PATH.
+	resolver := &testutil.MockCommandResolver{IsResolvableFunc: func(name string) bool {
+		switch name {
+		case "git", "ls", "sudo", "grep", "kubectl", "cd", "fi", "less":
+			return true
+		}
+		return false
+	}}
+
+	tests := []struct {
+		name    string
+		aliases []string // name=command pairs, in the default group file.
+		extra   []alias.Definition
+		want    []alias.Issue
+	}{
+		{
+			name:    "no issues",
+			aliases: []string{"gs=git status", "k=kubectl", "kg=k get", "ll=ls -l | grep x"},
+		},
+		{
+			name:    "self-reference",
+			aliases: []string{"ls=ls --color"},
+			want:    []alias.Issue{{Kind: alias.IssueSelfReference, Name: "ls", Chain: []string{"ls", "ls"}}},
+		},
+		{
+			name:    "self-reference to a missing command",
+			aliases: []string{"foo=foo -x"},
+			want: []alias.Issue{
+				{Kind: alias.IssueSelfReference, Name: "foo", Chain: []string{"foo", "foo"}},
+				{Kind: alias.IssueDangling, Name: "foo", Command: "foo"},
+			},
+		},
+		{
+			name:    "cycle",
+			aliases: []string{"a=b -x", "b=c", "c=a"},
+			want:    []alias.Issue{{Kind: alias.IssueCycle, Name: "a", Chain: []string{"a", "b", "c", "a"}}},
+		},
+		{
+			name:    "cycle through a later command of the value",
+			aliases: []string{"a=git pull && b", "b=a"},
+			want:    []alias.Issue{{Kind: alias.IssueCycle, Name: "a", Chain: []string{"a", "b", "a"}}},
+		},
+		{
+			name:    "arguments are not followed",
+			aliases: []string{"a=git b", "b=git a"},
+		},
+		{
+			name:    "trailing blank makes the next word a command",
+			aliases: []string{"s=sudo ", "x=s y", "y=x"},
+			want:    []alias.Issue{{Kind: alias.IssueCycle, Name: "x", Chain: []string{"x", "y", "x"}}},
+		},
+		{
+			name:    "dangling commands",
+			aliases: []string{"t=terraform plan", "ok=cd /tmp; if true; then ls; fi | nope"},
+			want: []alias.Issue{
+				{Kind: alias.IssueDangling, Name: "ok", Command: "true"},
+				{Kind: alias.IssueDangling, Name: "ok", Command: "nope"},
+				{Kind: alias.IssueDangling, Name: "t", Command: "terraform"},
+			},
+		},
+		{
+			name:    "quoted words are not commands",
+			aliases: []string{`q='terraform' plan`, `r=\terraform`},
+		},
+		{
+			name:    "cross-file override",
+			aliases: []string{"gs=git status"},
+			extra: []alias.Definition{
+				{Alias: alias.Alias{Name: "gs", Command: "git switch", Group: "git"}, File: "/home/u/.nicksh/git", Line: 3},
+			},
+			want: []alias.Issue{{Kind: alias.IssueOverride, Name: "gs", Definitions: []alias.Definition{
+				{Alias: alias.Alias{Name: "gs", Command: "git status", Group: "generated_aliases"}, File: "/home/u/.nicksh/generated_aliases", Line: 1},
+				{Alias: alias.Alias{Name: "gs", Command: "git switch", Group: "git"}, File: "/home/u/.nicksh/git", Line: 3},
+			}}},
+		},
+		{
+			name:    "global aliases are followed anywhere",
+			aliases: []string{"-g G=| grep -i X", "-g X=G", "ll=ls -l G"},
+			want:    []alias.Issue{{Kind: alias.IssueCycle, Name: "G", Chain: []string{"G", "X", "G"}}},
+		},
+		{
+			name:    "suffix alias runs its command",
+			aliases: []string{"-s md=typora", "-s txt=less"},
+			want:    []alias.Issue{{Kind: alias.IssueDangling, Name: "md", Command: "typora"}},
+		},
+		{
+			name:    "redefinition in the same file is no override",
+			aliases: []string{"gs=git show", "gs=git status"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definitions, _ := managed(tt.aliases...).GetAliasDefinitions()
+			got := NewValidator(resolver).Validate(append(definitions, tt.extra...))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_Validate_CycleMessage(t *testing.T) {
+	issues := NewValidator(&testutil.MockCommandResolver{}).Validate([]alias.Definition{
+		{Alias: alias.Alias{Name: "a", Command: "b"}},
+		{Alias: alias.Alias{Name: "b", Command: "a"}},
+	})
+	if len(issues) != 1 || !issues[0].IsError() {
+		t.Fatalf("Validate() = %+v, want a single cycle", issues)
+	}
+	if got := issues[0].String(); !strings.Contains(got, "a -> b -> a") {
+		t.Errorf("String() = %q, want it to contain the chain", got)
+	}
+}
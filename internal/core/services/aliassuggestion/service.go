@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 )
 
@@ -12,16 +13,18 @@ type service struct {
 	aliasGenerator          ports.AliasGenerator
 	shellConfig             ports.ShellConfigAccessor
 	predefinedAliasProvider ports.PredefinedAliasProvider // Can be nil if no predefined aliases are configured.
+	logger                  ports.Logger
 }
 
 // NewService creates a new alias suggestion service.
 // It panics if historyProvider, aliasGenerator, or shellConfigAccessor are nil.
-// predefinedAliasProvider can be nil if not used.
+// predefinedAliasProvider can be nil if not used, and logger if nothing should be logged.
 func NewService(
 	hp ports.HistoryProvider,
 	ag ports.AliasGenerator,
 	sc ports.ShellConfigAccessor,
 	pap ports.PredefinedAliasProvider,
+	logger ports.Logger,
 ) ports.AliasSuggestionService {
 	if hp == nil {
 		panic("historyProvider cannot be nil")
@@ -33,11 +36,15 @@ func NewService(
 		panic("shellConfig cannot be nil")
 	}
 	// predefinedAliasProvider is allowed to be nil.
+	if logger == nil {
+		logger = ports.NopLogger
+	}
 	return &service{
 		historyProvider:         hp,
 		aliasGenerator:          ag,
 		shellConfig:             sc,
 		predefinedAliasProvider: pap,
+		logger:                  logger,
 	}
 }
 
@@ -62,7 +69,28 @@ func (s *service) GetFilteredPredefinedAliases(currentShellAliases map[string]st
 	}
 	return validAliases, allLoaded, nil
 }
+
+// GetSuggestions generates alias suggestions from the whole command history.
 func (s *service) GetSuggestions(minFrequency, scanLimit, outputLimit int) (ports.SuggestionResult, error) {
+	return s.generateSuggestions(minFrequency, func() ([]history.CommandFrequency, error) {
+		return s.historyProvider.GetCommandFrequencies(scanLimit, outputLimit)
+	}, "")
+}
+
+// GetSuggestionsForDirectory generates alias suggestions from the commands run in dir only.
+func (s *service) GetSuggestionsForDirectory(dir string, minFrequency, scanLimit, outputLimit int) (ports.SuggestionResult, error) {
+	return s.generateSuggestions(minFrequency, func() ([]history.CommandFrequency, error) {
+		return s.historyProvider.GetCommandFrequenciesInDir(dir, scanLimit, outputLimit)
+	}, fmt.Sprintf("; restricted to commands run in %s", dir))
+}
+
+// generateSuggestions runs the suggestion pipeline over the frequencies returned by fetchFrequencies.
+// extraDetails is appended to the source details, inside the parenthesis.
+func (s *service) generateSuggestions(
+	minFrequency int,
+	fetchFrequencies func() ([]history.CommandFrequency, error),
+	extraDetails string,
+) (ports.SuggestionResult, error) {
 	var result ports.SuggestionResult
 
 	existingShellAliases, err := s.shellConfig.GetExistingAliases()
@@ -85,17 +113,20 @@ func (s *service) GetSuggestions(minFrequency, scanLimit, outputLimit int) (port
 	// This includes names from existing shell aliases AND valid predefined aliases.
 	forbiddenNamesForDynamicGen := s.buildForbiddenNamesMap(existingShellAliases, validPredefined)
 
-	frequencies, err := s.historyProvider.GetCommandFrequencies(scanLimit, outputLimit)
+	frequencies, err := fetchFrequencies()
 	if err != nil {
 		return result, fmt.Errorf("failed to get command frequencies: %w", err)
 	}
 
+	s.logger.Info("generating suggestions", "commands", len(frequencies), "existingAliases", len(existingShellAliases), "minFrequency", minFrequency)
 	dynamicSuggestions := s.aliasGenerator.GenerateSuggestions(frequencies, forbiddenNamesForDynamicGen, minFrequency)
+	dynamicSuggestions = s.withoutUnsupportedKinds(dynamicSuggestions)
 
 	// Pass an empty slice for predefined aliases to combineSuggestions,
 	// ensuring only dynamic suggestions are processed for the final list.
 	// The combineSuggestions method will handle de-duplication of dynamic suggestions if any (though ideally none).
 	result.Suggestions = s.combineSuggestions([]alias.Alias{}, dynamicSuggestions)
+	result.Suggestions, result.Withheld = withholdSecrets(result.Suggestions)
 
 	result.SourceDetails = s.historyProvider.GetSourceIdentifier()
 	result.SourceDetails += " (suggestions from command history" // Base part of the message
@@ -109,6 +140,7 @@ func (s *service) GetSuggestions(minFrequency, scanLimit, outputLimit int) (port
 			result.SourceDetails += "; predefined aliases configured but none loaded/found for conflict avoidance"
 		}
 	}
+	result.SourceDetails += extraDetails
 	result.SourceDetails += ")" // Close the parenthesis
 
 	return result, nil
@@ -129,3 +161,27 @@ func (s *service) GetSuggestionContextDetails() (string, error) {
 	}
 	return details, nil
 }
+
+// ExplainHistoryExclusions reports how many history entries each exclusion rule removed.
+func (s *service) ExplainHistoryExclusions(scanLimit int) (history.ExclusionReport, error) {
+	report, err := s.historyProvider.ExplainExclusions(scanLimit)
+	if err != nil {
+		return history.ExclusionReport{}, fmt.Errorf("failed to explain history exclusions: %w", err)
+	}
+	return report, nil
+}
+
+// ValidateAliasName checks name against the existing aliases and the alias generator's naming rules.
+func (s *service) ValidateAliasName(name string) error {
+	existingShellAliases, err := s.shellConfig.GetExistingAliases()
+	if err != nil {
+		return fmt.Errorf("failed to get existing aliases for name validation: %w", err)
+	}
+	if command, exists := existingShellAliases[name]; exists {
+		return &alias.ExistsError{Name: name, Command: command}
+	}
+	if !s.aliasGenerator.IsValidAliasName(name, existingShellAliases) {
+		return &alias.InvalidNameError{Name: name, Reason: "use letters, digits and dots, and avoid names of commands on PATH"}
+	}
+	return nil
+}
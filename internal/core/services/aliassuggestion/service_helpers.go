@@ -8,8 +8,7 @@ import (
 // and filters them against existing shell aliases to ensure validity.
 // It returns the list of valid predefined aliases and the original list of all loaded predefined aliases.
 // If the predefined alias provider is not set, it returns empty lists and no error.
-// If loading from the provider fails, it currently returns empty lists and a nil error
-// (consider changing to return the error if strict error handling is preferred).
+// If loading from the provider fails, it logs a warning and returns empty lists and a nil error.
 func (s *service) loadAndFilterPredefined(existingShellAliases map[string]string) ([]alias.Alias, []alias.Alias, error) {
 	if s.predefinedAliasProvider == nil {
 		return []alias.Alias{}, []alias.Alias{}, nil // No provider, so no predefined aliases.
@@ -17,10 +16,9 @@ func (s *service) loadAndFilterPredefined(existingShellAliases map[string]string
 
 	loadedPredefined, loadErr := s.predefinedAliasProvider.GetPredefinedAliases()
 	if loadErr != nil {
-		// Current behavior: treat as no predefined aliases if loading fails.
-		// log.Printf("Warning: could not load predefined aliases: %v\n", loadErr) // Optional logging
-		return []alias.Alias{}, []alias.Alias{}, nil // Return empty lists on load error.
-		// Alternative: return []alias.Alias{}, []alias.Alias{}, loadErr // To propagate the error.
+		// Treat as no predefined aliases if loading fails.
+		s.logger.Warn("could not load predefined aliases", "error", loadErr)
+		return []alias.Alias{}, []alias.Alias{}, nil
 	}
 
 	validPredefinedAliases := make([]alias.Alias, 0, len(loadedPredefined))
@@ -28,6 +26,8 @@ func (s *service) loadAndFilterPredefined(existingShellAliases map[string]string
 		// The aliasGenerator's IsValidAliasName checks against system commands and other rules.
 		if s.aliasGenerator.IsValidAliasName(pa.Name, existingShellAliases) {
 			validPredefinedAliases = append(validPredefinedAliases, pa)
+		} else {
+			s.logger.Debug("left out predefined alias with an unusable name", "name", pa.Name, "command", pa.Command)
 		}
 	}
 	return validPredefinedAliases, loadedPredefined, nil
@@ -46,6 +46,20 @@ func (s *service) buildForbiddenNamesMap(existingShellAliases map[string]string,
 	return forbiddenNames
 }
 
+// withoutUnsupportedKinds leaves out the suggestions of a kind the user's shell does not support,
+// e.g. global aliases outside zsh.
+func (s *service) withoutUnsupportedKinds(suggestions []alias.Alias) []alias.Alias {
+	supported := make([]alias.Alias, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if !s.shellConfig.SupportsKind(suggestion.Kind) {
+			s.logger.Debug("left suggestion out: the shell does not support its kind", "name", suggestion.Name, "kind", suggestion.Kind)
+			continue
+		}
+		supported = append(supported, suggestion)
+	}
+	return supported
+}
+
 // combineSuggestions merges predefined and dynamic suggestions.
 // Predefined suggestions take precedence if there are name conflicts.
 func (s *service) combineSuggestions(predefined []alias.Alias, dynamic []alias.Alias) []alias.Alias {
@@ -71,3 +85,16 @@ func (s *service) combineSuggestions(predefined []alias.Alias, dynamic []alias.A
 	// e.g., sort.Slice(combined, func(i, j int) bool { return combined[i].Name < combined[j].Name })
 	return combined
 }
+
+// withholdSecrets separates the suggestions whose command contains a secret from the others.
+func withholdSecrets(suggestions []alias.Alias) (safe, withheld []alias.Alias) {
+	safe = make([]alias.Alias, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if len(suggestion.Secrets) > 0 {
+			withheld = append(withheld, suggestion)
+		} else {
+			safe = append(safe, suggestion)
+		}
+	}
+	return safe, withheld
+}
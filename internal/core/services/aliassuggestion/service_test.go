@@ -28,14 +28,14 @@ func TestNewService(t *testing.T) {
 	mockPAP := &testutil.MockPredefinedAliasProvider{}
 
 	t.Run("success with all providers", func(t *testing.T) {
-		svc := NewService(mockHP, mockAG, mockSCA, mockPAP)
+		svc := NewService(mockHP, mockAG, mockSCA, mockPAP, nil)
 		if svc == nil {
 			t.Fatal("NewService() returned nil, expected a service instance")
 		}
 	})
 
 	t.Run("success with nil predefinedAliasProvider", func(t *testing.T) {
-		svc := NewService(mockHP, mockAG, mockSCA, nil)
+		svc := NewService(mockHP, mockAG, mockSCA, nil, nil)
 		if svc == nil {
 			t.Fatal("NewService() returned nil, expected a service instance")
 		}
@@ -78,7 +78,7 @@ func TestNewService(t *testing.T) {
 					t.Errorf("NewService panicked unexpectedly: %v", r)
 				}
 			}()
-			_ = NewService(tt.hp, tt.ag, tt.sc, tt.pap)
+			_ = NewService(tt.hp, tt.ag, tt.sc, tt.pap, nil)
 		})
 	}
 }
@@ -106,6 +106,7 @@ func TestService_GetFilteredPredefinedAliases(t *testing.T) {
 		wantAllLoadedAliases  []alias.Alias
 		wantErr               bool
 		expectedErrorContains string
+		wantWarnings          int
 	}{
 		{
 			name:                 "predefinedAliasProvider is nil",
@@ -130,6 +131,7 @@ func TestService_GetFilteredPredefinedAliases(t *testing.T) {
 			wantValidAliases:     []alias.Alias{},
 			wantAllLoadedAliases: []alias.Alias{}, // Because loadAndFilterPredefined returns empty on error
 			wantErr:              false,           // GetFilteredPredefinedAliases doesn't return error for this case
+			wantWarnings:         1,               // The load error is logged instead.
 		},
 		{
 			name:                "predefined aliases loaded, some valid, some conflict",
@@ -202,8 +204,12 @@ func TestService_GetFilteredPredefinedAliases(t *testing.T) {
 				tt.setupMocks(currentAG, papToSetup)
 			}
 
-			svc := NewService(mockHP, currentAG, mockSCA, tt.pap) // Pass original tt.pap (interface)
+			logger := &testutil.MockLogger{}
+			svc := NewService(mockHP, currentAG, mockSCA, tt.pap, logger) // Pass original tt.pap (interface)
 			valid, all, err := svc.GetFilteredPredefinedAliases(tt.currentShellAliases)
+			if warnings := logger.EntriesAt("WARN"); len(warnings) != tt.wantWarnings {
+				t.Errorf("GetFilteredPredefinedAliases() logged %q, want %d warning(s)", warnings, tt.wantWarnings)
+			}
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetFilteredPredefinedAliases() error = %v, wantErr %v", err, tt.wantErr)
@@ -261,6 +267,44 @@ func TestService_GetSuggestions(t *testing.T) {
 			wantErr:               true,
 			expectedErrorContains: "failed to get command frequencies",
 		},
+		{
+			name: "suggestions containing secrets are withheld",
+			pap:  nil,
+			setupMocks: func(hp *testutil.MockHistoryProvider, ag *testutil.MockAliasGenerator, sc *testutil.MockShellConfigAccessor, pap *testutil.MockPredefinedAliasProvider) {
+				sc.GetExistingAliasesFunc = func() (map[string]string, error) { return defaultExistingShellAliases, nil }
+				hp.GetSourceIdentifierFunc = func() string { return "File: ~/.bash_history" }
+				ag.GenerateSuggestionsFunc = func([]history.CommandFrequency, map[string]string, int) []alias.Alias {
+					return []alias.Alias{
+						{Name: "gs", Command: "git status"},
+						{Name: "ep", Command: "export PASSWORD=<redacted>", Secrets: []string{"password or token assignment"}},
+					}
+				}
+			},
+			wantResult: ports.SuggestionResult{
+				Suggestions:   []alias.Alias{{Name: "gs", Command: "git status"}},
+				Withheld:      []alias.Alias{{Name: "ep", Command: "export PASSWORD=<redacted>", Secrets: []string{"password or token assignment"}}},
+				SourceDetails: "File: ~/.bash_history (suggestions from command history)",
+			},
+		},
+		{
+			name: "suggestions of kinds the shell does not support are left out",
+			pap:  nil,
+			setupMocks: func(hp *testutil.MockHistoryProvider, ag *testutil.MockAliasGenerator, sc *testutil.MockShellConfigAccessor, pap *testutil.MockPredefinedAliasProvider) {
+				sc.GetExistingAliasesFunc = func() (map[string]string, error) { return defaultExistingShellAliases, nil }
+				sc.SupportsKindFunc = func(kind string) bool { return kind != alias.KindGlobal }
+				hp.GetSourceIdentifierFunc = func() string { return "File: ~/.bash_history" }
+				ag.GenerateSuggestionsFunc = func([]history.CommandFrequency, map[string]string, int) []alias.Alias {
+					return []alias.Alias{
+						{Name: "gs", Command: "git status"},
+						{Name: "GI", Command: "| grep -i", Kind: alias.KindGlobal},
+					}
+				}
+			},
+			wantResult: ports.SuggestionResult{
+				Suggestions:   []alias.Alias{{Name: "gs", Command: "git status"}},
+				SourceDetails: "File: ~/.bash_history (suggestions from command history)",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -277,7 +321,7 @@ func TestService_GetSuggestions(t *testing.T) {
 				tt.setupMocks(mockHP, mockAG, mockSC, concretePAP)
 			}
 
-			svc := NewService(mockHP, mockAG, mockSC, tt.pap) // Use tt.pap (interface) for NewService
+			svc := NewService(mockHP, mockAG, mockSC, tt.pap, nil) // Use tt.pap (interface) for NewService
 			result, err := svc.GetSuggestions(minFreq, scanLimit, outputLimit)
 
 			if (err != nil) != tt.wantErr {
@@ -293,6 +337,9 @@ func TestService_GetSuggestions(t *testing.T) {
 				if !reflect.DeepEqual(result.Suggestions, tt.wantResult.Suggestions) {
 					t.Errorf("GetSuggestions() suggestions = %v, want %v", result.Suggestions, tt.wantResult.Suggestions)
 				}
+				if !reflect.DeepEqual(result.Withheld, tt.wantResult.Withheld) {
+					t.Errorf("GetSuggestions() withheld = %v, want %v", result.Withheld, tt.wantResult.Withheld)
+				}
 				if result.SourceDetails != tt.wantResult.SourceDetails {
 					t.Errorf("GetSuggestions() sourceDetails = %q, want %q", result.SourceDetails, tt.wantResult.SourceDetails)
 				}
@@ -301,6 +348,80 @@ func TestService_GetSuggestions(t *testing.T) {
 	}
 }
 
+func TestService_GetSuggestionsForDirectory(t *testing.T) {
+	const dir = "/work/app"
+	historySourceID := "File: /path/to/zsh_history"
+
+	tests := []struct {
+		name                  string
+		inDirErr              error
+		wantSuggestions       []alias.Alias
+		wantSourceDetails     string
+		wantErr               bool
+		expectedErrorContains string
+	}{
+		{
+			name:              "success - uses directory-restricted frequencies",
+			wantSuggestions:   []alias.Alias{{Name: "mti", Command: "make test-integration"}},
+			wantSourceDetails: historySourceID + " (suggestions from command history; restricted to commands run in /work/app)",
+		},
+		{
+			name:                  "error - provider has no directory information",
+			inDirErr:              errors.New("no per-directory history found"),
+			wantErr:               true,
+			expectedErrorContains: "no per-directory history found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHP := &testutil.MockHistoryProvider{
+				GetSourceIdentifierFunc: func() string { return historySourceID },
+				GetCommandFrequenciesFunc: func(sl, ol int) ([]history.CommandFrequency, error) {
+					t.Error("GetCommandFrequencies should not be called for directory suggestions")
+					return nil, nil
+				},
+				GetCommandFrequenciesInDirFunc: func(gotDir string, sl, ol int) ([]history.CommandFrequency, error) {
+					if gotDir != dir {
+						t.Errorf("GetCommandFrequenciesInDir() dir = %q, want %q", gotDir, dir)
+					}
+					if tt.inDirErr != nil {
+						return nil, tt.inDirErr
+					}
+					return []history.CommandFrequency{{Command: "make test-integration", Count: 5}}, nil
+				},
+			}
+			mockAG := &testutil.MockAliasGenerator{
+				GenerateSuggestionsFunc: func(freqs []history.CommandFrequency, existing map[string]string, minFreq int) []alias.Alias {
+					return []alias.Alias{{Name: "mti", Command: freqs[0].Command}}
+				},
+			}
+			mockSC := &testutil.MockShellConfigAccessor{
+				GetExistingAliasesFunc: func() (map[string]string, error) { return map[string]string{}, nil },
+			}
+
+			svc := NewService(mockHP, mockAG, mockSC, nil, nil)
+			result, err := svc.GetSuggestionsForDirectory(dir, 3, 100, 10)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSuggestionsForDirectory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.expectedErrorContains) {
+					t.Errorf("GetSuggestionsForDirectory() error = %q, want error containing %q", err.Error(), tt.expectedErrorContains)
+				}
+				return
+			}
+			if !reflect.DeepEqual(result.Suggestions, tt.wantSuggestions) {
+				t.Errorf("GetSuggestionsForDirectory() suggestions = %v, want %v", result.Suggestions, tt.wantSuggestions)
+			}
+			if result.SourceDetails != tt.wantSourceDetails {
+				t.Errorf("GetSuggestionsForDirectory() sourceDetails = %q, want %q", result.SourceDetails, tt.wantSourceDetails)
+			}
+		})
+	}
+}
+
 func TestService_GetSuggestionContextDetails(t *testing.T) {
 	mockAG := &testutil.MockAliasGenerator{}      // Needed for NewService
 	mockSC := &testutil.MockShellConfigAccessor{} // Needed for NewService
@@ -365,7 +486,7 @@ func TestService_GetSuggestionContextDetails(t *testing.T) {
 				tt.setupMocks(mockHP, concretePAP)
 			}
 
-			svc := NewService(mockHP, mockAG, mockSC, tt.pap) // Use tt.pap (interface) for NewService
+			svc := NewService(mockHP, mockAG, mockSC, tt.pap, nil) // Use tt.pap (interface) for NewService
 			details, err := svc.GetSuggestionContextDetails()
 
 			if (err != nil) != tt.wantErr {
@@ -383,3 +504,73 @@ func TestService_GetSuggestionContextDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ValidateAliasName(t *testing.T) {
+	tests := []struct {
+		name            string
+		aliasName       string
+		existingAliases map[string]string
+		existingErr     error
+		validName       bool
+		wantErrContains string
+		wantErrIs       error
+	}{
+		{name: "valid name", aliasName: "gs", existingAliases: map[string]string{"ll": "ls -l"}, validName: true},
+		{name: "existing alias", aliasName: "ll", existingAliases: map[string]string{"ll": "ls -l"}, validName: true, wantErrContains: "'ll' is already an alias for 'ls -l'", wantErrIs: alias.ErrAliasExists},
+		{name: "rejected by the generator rules", aliasName: "ls", validName: false, wantErrContains: "'ls' is not a valid alias name", wantErrIs: alias.ErrInvalidName},
+		{name: "existing aliases cannot be read", aliasName: "gs", existingErr: errors.New("boom"), wantErrContains: "failed to get existing aliases for name validation: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &testutil.MockShellConfigAccessor{GetExistingAliasesFunc: func() (map[string]string, error) {
+				return tt.existingAliases, tt.existingErr
+			}}
+			ag := &testutil.MockAliasGenerator{IsValidAliasNameFunc: func(string, map[string]string) bool { return tt.validName }}
+			svc := NewService(&testutil.MockHistoryProvider{}, ag, sc, nil, nil)
+
+			err := svc.ValidateAliasName(tt.aliasName)
+			if tt.wantErrContains == "" {
+				if err != nil {
+					t.Errorf("ValidateAliasName(%q) unexpected error: %v", tt.aliasName, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+				t.Errorf("ValidateAliasName(%q) error = %v, want it to contain %q", tt.aliasName, err, tt.wantErrContains)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("ValidateAliasName(%q) error = %v, want errors.Is(%v)", tt.aliasName, err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestService_ExplainHistoryExclusions(t *testing.T) {
+	wantReport := history.ExclusionReport{
+		ScannedEntries: 100,
+		Rules:          []history.ExclusionStat{{Origin: "HISTIGNORE", Pattern: "ls", Removed: 7}},
+	}
+	hp := &testutil.MockHistoryProvider{ExplainExclusionsFunc: func(scanLimit int) (history.ExclusionReport, error) {
+		if scanLimit != 100 {
+			t.Errorf("ExplainExclusions() called with scanLimit %d, want 100", scanLimit)
+		}
+		return wantReport, nil
+	}}
+	svc := NewService(hp, &testutil.MockAliasGenerator{}, &testutil.MockShellConfigAccessor{}, nil, nil)
+
+	report, err := svc.ExplainHistoryExclusions(100)
+	if err != nil {
+		t.Fatalf("ExplainHistoryExclusions() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Errorf("ExplainHistoryExclusions() = %+v, want %+v", report, wantReport)
+	}
+
+	hp.ExplainExclusionsFunc = func(int) (history.ExclusionReport, error) {
+		return history.ExclusionReport{}, errors.New("no history")
+	}
+	if _, err := svc.ExplainHistoryExclusions(100); err == nil || !strings.Contains(err.Error(), "failed to explain history exclusions: no history") {
+		t.Errorf("ExplainHistoryExclusions() error = %v, want wrapped provider error", err)
+	}
+}
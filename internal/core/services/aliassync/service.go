@@ -0,0 +1,137 @@
+package aliassync
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// StoreOpener opens the sync store found at dir.
+type StoreOpener func(dir string) (ports.AliasSyncStore, error)
+
+type service struct {
+	shellConfig ports.ShellConfigAccessor
+	openStore   StoreOpener
+	logger      ports.Logger
+}
+
+// NewService creates a new alias sync service.
+// It panics if shellConfig or openStore are nil. logger can be nil if nothing should be logged.
+func NewService(sc ports.ShellConfigAccessor, openStore StoreOpener, logger ports.Logger) ports.AliasSyncService {
+	if sc == nil {
+		panic("shellConfig cannot be nil")
+	}
+	if openStore == nil {
+		panic("openStore cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{shellConfig: sc, openStore: openStore, logger: logger}
+}
+
+/*
+Sync merges the managed aliases with the alias set of the store at dir.
+
+Each alias name is merged on its own, against the alias set of the last sync
+(the base): a side that left an alias as it was in the base takes the other
+side's change, including a removal. When both sides changed an alias
+differently, the side named by prefer wins and the conflict is reported.
+Without a base (first sync), the two sides are simply combined.
+*/
+func (s *service) Sync(dir, prefer string) (ports.SyncResult, error) {
+	var result ports.SyncResult
+	if prefer != ports.SyncSideLocal && prefer != ports.SyncSideRemote {
+		return result, fmt.Errorf("invalid conflict preference '%s': use %s or %s", prefer, ports.SyncSideLocal, ports.SyncSideRemote)
+	}
+
+	store, err := s.openStore(dir)
+	if err != nil {
+		return result, fmt.Errorf("failed to open sync store: %w", err)
+	}
+	if err := store.Pull(); err != nil {
+		return result, fmt.Errorf("failed to pull sync store: %w", err)
+	}
+
+	remote, err := store.LoadAliases()
+	if err != nil {
+		return result, fmt.Errorf("failed to load synced aliases: %w", err)
+	}
+	base, _, err := store.LoadBase()
+	if err != nil {
+		return result, fmt.Errorf("failed to load the state of the last sync: %w", err)
+	}
+	local, err := s.managedAliases()
+	if err != nil {
+		return result, err
+	}
+
+	s.logger.Info("merging alias sets", "local", len(local), "remote", len(remote), "base", len(base))
+	merged, conflicts := mergeAliasSets(indexByName(base), indexByName(local), indexByName(remote), prefer)
+	for _, conflict := range conflicts {
+		s.logger.Debug("resolved sync conflict", "name", conflict.Name, "kept", conflict.Kept)
+	}
+	result.Conflicts = conflicts
+	result.LocalChanges = diffAliasSets(indexByName(local), merged)
+	result.RemoteChanges = diffAliasSets(indexByName(remote), merged)
+
+	for _, change := range result.LocalChanges {
+		if err := s.applyLocalChange(change); err != nil {
+			return result, err
+		}
+	}
+
+	mergedList := sortedAliases(merged)
+	if len(result.RemoteChanges) > 0 {
+		if err := store.SaveAliases(mergedList); err != nil {
+			return result, fmt.Errorf("failed to save synced aliases: %w", err)
+		}
+	}
+	// Publishing also pushes changes a previous sync committed but could not push.
+	result.Committed, result.Pushed, err = store.Publish(syncMessage(len(result.RemoteChanges)))
+	if err != nil {
+		return result, fmt.Errorf("failed to publish synced aliases: %w", err)
+	}
+	if err := store.SaveBase(mergedList); err != nil {
+		return result, fmt.Errorf("failed to save the state of this sync: %w", err)
+	}
+	return result, nil
+}
+
+// managedAliases returns the managed aliases, with the effective definition of each name.
+func (s *service) managedAliases() ([]alias.Alias, error) {
+	definitions, err := s.shellConfig.GetAliasDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read managed aliases: %w", err)
+	}
+	aliases := make([]alias.Alias, 0, len(definitions))
+	for _, def := range definitions {
+		aliases = append(aliases, alias.Alias{Name: def.Name, Command: def.Command, Group: def.Group})
+	}
+	return aliases, nil
+}
+
+// applyLocalChange writes a change coming from the sync store to the managed aliases.
+func (s *service) applyLocalChange(change ports.AliasChange) error {
+	if change.After == nil {
+		if err := s.shellConfig.RemoveAlias(change.Name); err != nil {
+			return fmt.Errorf("failed to remove alias '%s': %w", change.Name, err)
+		}
+		return nil
+	}
+	if err := s.shellConfig.ReplaceAlias(*change.After); err != nil {
+		return fmt.Errorf("failed to update alias '%s': %w", change.Name, err)
+	}
+	return nil
+}
+
+// syncMessage describes a sync that changed changes aliases of the store, e.g. as a commit message.
+func syncMessage(changes int) string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown host"
+	}
+	return fmt.Sprintf("nicksh: sync %d alias change(s) from %s", changes, host)
+}
@@ -0,0 +1,116 @@
+package aliassync
+
+import (
+	"sort"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// indexByName maps each alias name to its alias. A name listed more than once keeps its last alias.
+func indexByName(aliases []alias.Alias) map[string]alias.Alias {
+	index := make(map[string]alias.Alias, len(aliases))
+	for _, a := range aliases {
+		index[a.Name] = alias.Alias{Name: a.Name, Command: a.Command, Group: a.Group}
+	}
+	return index
+}
+
+// lookup returns a pointer to the alias named name in set, or nil if there is none.
+func lookup(set map[string]alias.Alias, name string) *alias.Alias {
+	if a, ok := set[name]; ok {
+		return &a
+	}
+	return nil
+}
+
+// sameAlias reports whether a and b are both absent, or both present with the same command and group.
+func sameAlias(a, b *alias.Alias) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Name == b.Name && a.Command == b.Command && a.Group == b.Group
+}
+
+// mergeAliasSets merges local and remote against base, name by name. See service.Sync.
+func mergeAliasSets(base, local, remote map[string]alias.Alias, prefer string) (map[string]alias.Alias, []ports.SyncConflict) {
+	names := make(map[string]bool, len(local)+len(remote))
+	for name := range local {
+		names[name] = true
+	}
+	for name := range remote {
+		names[name] = true
+	}
+
+	merged := make(map[string]alias.Alias, len(names))
+	var conflicts []ports.SyncConflict
+	for _, name := range sortedNames(names) {
+		b, l, r := lookup(base, name), lookup(local, name), lookup(remote, name)
+
+		var result *alias.Alias
+		switch {
+		case sameAlias(l, r):
+			result = l
+		case sameAlias(l, b):
+			result = r // Only the remote side changed.
+		case sameAlias(r, b):
+			result = l // Only the local side changed.
+		default:
+			result = l
+			if prefer == ports.SyncSideRemote {
+				result = r
+			}
+			conflicts = append(conflicts, ports.SyncConflict{Name: name, Local: l, Remote: r, Kept: prefer})
+		}
+		if result != nil {
+			merged[name] = *result
+		}
+	}
+	return merged, conflicts
+}
+
+// diffAliasSets lists the changes that turn from into to, by alias name.
+func diffAliasSets(from, to map[string]alias.Alias) []ports.AliasChange {
+	names := make(map[string]bool, len(from)+len(to))
+	for name := range from {
+		names[name] = true
+	}
+	for name := range to {
+		names[name] = true
+	}
+
+	var changes []ports.AliasChange
+	for _, name := range sortedNames(names) {
+		before, after := lookup(from, name), lookup(to, name)
+		if !sameAlias(before, after) {
+			changes = append(changes, ports.AliasChange{Name: name, Before: before, After: after})
+		}
+	}
+	return changes
+}
+
+// sortedNames returns the names of the set in alphabetical order.
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// sortedAliases returns the aliases of set ordered by group, then name, so the
+// stored alias file changes as little as possible from one sync to the next.
+func sortedAliases(set map[string]alias.Alias) []alias.Alias {
+	aliases := make([]alias.Alias, 0, len(set))
+	for _, a := range set {
+		aliases = append(aliases, a)
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].Group != aliases[j].Group {
+			return aliases[i].Group < aliases[j].Group
+		}
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases
+}
@@ -0,0 +1,228 @@
+package aliassync
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// definitions turns aliases into the definitions of a mocked ShellConfigAccessor.
+func definitions(aliases ...alias.Alias) []alias.Definition {
+	defs := make([]alias.Definition, 0, len(aliases))
+	for i, a := range aliases {
+		defs = append(defs, alias.Definition{Alias: a, File: "/home/u/.nicksh/" + a.Group, Line: i + 1})
+	}
+	return defs
+}
+
+func TestNewService(t *testing.T) {
+	open := func(dir string) (ports.AliasSyncStore, error) { return &testutil.MockAliasSyncStore{}, nil }
+	t.Run("nil shellConfig panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewService() did not panic with a nil shellConfig")
+			}
+		}()
+		NewService(nil, open, nil)
+	})
+	t.Run("nil openStore panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewService() did not panic with a nil openStore")
+			}
+		}()
+		NewService(&testutil.MockShellConfigAccessor{}, nil, nil)
+	})
+}
+
+func TestMergeAliasSets(t *testing.T) {
+	gs := alias.Alias{Name: "gs", Command: "git status", Group: "git"}
+	gsShort := alias.Alias{Name: "gs", Command: "git status -sb", Group: "git"}
+	gsLong := alias.Alias{Name: "gs", Command: "git status --long", Group: "git"}
+	ll := alias.Alias{Name: "ll", Command: "ls -l", Group: "generated_aliases"}
+	k := alias.Alias{Name: "k", Command: "kubectl", Group: "k8s"}
+
+	tests := []struct {
+		name          string
+		base          []alias.Alias
+		local         []alias.Alias
+		remote        []alias.Alias
+		prefer        string
+		wantMerged    []alias.Alias
+		wantConflicts []ports.SyncConflict
+	}{
+		{
+			name:       "first sync combines both sides",
+			local:      []alias.Alias{gs},
+			remote:     []alias.Alias{ll},
+			prefer:     ports.SyncSideLocal,
+			wantMerged: []alias.Alias{ll, gs},
+		},
+		{
+			name:       "one-sided changes win",
+			base:       []alias.Alias{gs, ll, k},
+			local:      []alias.Alias{gsShort, ll, k}, // gs changed locally.
+			remote:     []alias.Alias{gs, ll},         // k removed remotely.
+			prefer:     ports.SyncSideRemote,
+			wantMerged: []alias.Alias{ll, gsShort},
+		},
+		{
+			name:       "same change on both sides",
+			base:       []alias.Alias{gs},
+			local:      []alias.Alias{gsShort},
+			remote:     []alias.Alias{gsShort},
+			prefer:     ports.SyncSideLocal,
+			wantMerged: []alias.Alias{gsShort},
+		},
+		{
+			name:          "conflicting changes keep the preferred side",
+			base:          []alias.Alias{gs},
+			local:         []alias.Alias{gsShort},
+			remote:        []alias.Alias{gsLong},
+			prefer:        ports.SyncSideRemote,
+			wantMerged:    []alias.Alias{gsLong},
+			wantConflicts: []ports.SyncConflict{{Name: "gs", Local: &gsShort, Remote: &gsLong, Kept: ports.SyncSideRemote}},
+		},
+		{
+			name:          "removal against a change is a conflict",
+			base:          []alias.Alias{gs},
+			local:         []alias.Alias{},
+			remote:        []alias.Alias{gsShort},
+			prefer:        ports.SyncSideLocal,
+			wantMerged:    []alias.Alias{},
+			wantConflicts: []ports.SyncConflict{{Name: "gs", Local: nil, Remote: &gsShort, Kept: ports.SyncSideLocal}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := mergeAliasSets(indexByName(tt.base), indexByName(tt.local), indexByName(tt.remote), tt.prefer)
+			if got := sortedAliases(merged); !reflect.DeepEqual(got, tt.wantMerged) {
+				t.Errorf("mergeAliasSets() merged = %+v, want %+v", got, tt.wantMerged)
+			}
+			if !reflect.DeepEqual(conflicts, tt.wantConflicts) {
+				t.Errorf("mergeAliasSets() conflicts = %+v, want %+v", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestService_Sync(t *testing.T) {
+	gs := alias.Alias{Name: "gs", Command: "git status", Group: "git"}
+	gp := alias.Alias{Name: "gp", Command: "git push", Group: "git"}
+	ll := alias.Alias{Name: "ll", Command: "ls -l", Group: "generated_aliases"}
+	k := alias.Alias{Name: "k", Command: "kubectl", Group: "k8s"}
+
+	var replaced []alias.Alias
+	var removed []string
+	sc := &testutil.MockShellConfigAccessor{
+		GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+			return definitions(ll, gs, gp), nil // gp was added locally, k removed locally.
+		},
+		ReplaceAliasFunc: func(newAlias alias.Alias) error {
+			replaced = append(replaced, newAlias)
+			return nil
+		},
+		RemoveAliasFunc: func(name string) error {
+			removed = append(removed, name)
+			return nil
+		},
+	}
+	remoteK := alias.Alias{Name: "k", Command: "kubectl", Group: "k8s"}
+	remoteLL := alias.Alias{Name: "ll", Command: "ls -lh", Group: "generated_aliases"} // Changed remotely.
+	var saved, savedBase []alias.Alias
+	var message string
+	store := &testutil.MockAliasSyncStore{
+		LoadAliasesFunc: func() ([]alias.Alias, error) { return []alias.Alias{gs, remoteK, remoteLL}, nil },
+		LoadBaseFunc:    func() ([]alias.Alias, bool, error) { return []alias.Alias{gs, k, ll}, true, nil },
+		SaveAliasesFunc: func(aliases []alias.Alias) error { saved = aliases; return nil },
+		SaveBaseFunc:    func(aliases []alias.Alias) error { savedBase = aliases; return nil },
+		PublishFunc: func(msg string) (bool, bool, error) {
+			message = msg
+			return true, true, nil
+		},
+	}
+	var openedDir string
+	svc := NewService(sc, func(dir string) (ports.AliasSyncStore, error) {
+		openedDir = dir
+		return store, nil
+	}, nil)
+
+	result, err := svc.Sync("/home/u/dotfiles", ports.SyncSideLocal)
+	if err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if openedDir != "/home/u/dotfiles" {
+		t.Errorf("Sync() opened %q, want the given directory", openedDir)
+	}
+
+	wantMerged := []alias.Alias{remoteLL, gp, gs}
+	if !reflect.DeepEqual(saved, wantMerged) || !reflect.DeepEqual(savedBase, wantMerged) {
+		t.Errorf("Sync() saved %+v and base %+v, want both %+v", saved, savedBase, wantMerged)
+	}
+	if !reflect.DeepEqual(replaced, []alias.Alias{remoteLL}) || len(removed) != 0 {
+		t.Errorf("Sync() replaced %+v and removed %v locally, want only the remote change of ll", replaced, removed)
+	}
+	wantRemote := []ports.AliasChange{{Name: "gp", After: &gp}, {Name: "k", Before: &remoteK}}
+	if !reflect.DeepEqual(result.RemoteChanges, wantRemote) {
+		t.Errorf("Sync() RemoteChanges = %+v, want %+v", result.RemoteChanges, wantRemote)
+	}
+	if len(result.LocalChanges) != 1 || len(result.Conflicts) != 0 || !result.Committed || !result.Pushed {
+		t.Errorf("Sync() result = %+v, want 1 local change, no conflicts, committed and pushed", result)
+	}
+	if !strings.HasPrefix(message, "nicksh: sync 2 alias change(s) from ") {
+		t.Errorf("Sync() published with message %q", message)
+	}
+}
+
+func TestService_Sync_Errors(t *testing.T) {
+	sc := &testutil.MockShellConfigAccessor{
+		GetAliasDefinitionsFunc: func() ([]alias.Definition, error) { return nil, nil },
+	}
+
+	tests := []struct {
+		name            string
+		prefer          string
+		store           *testutil.MockAliasSyncStore
+		openErr         error
+		wantErrContains string
+	}{
+		{name: "invalid preference", prefer: "theirs", store: &testutil.MockAliasSyncStore{}, wantErrContains: "invalid conflict preference 'theirs'"},
+		{name: "open error", prefer: ports.SyncSideLocal, openErr: errors.New("not a git working copy"), wantErrContains: "failed to open sync store: not a git working copy"},
+		{
+			name:            "pull error",
+			prefer:          ports.SyncSideLocal,
+			store:           &testutil.MockAliasSyncStore{PullFunc: func() error { return errors.New("rejected") }},
+			wantErrContains: "failed to pull sync store: rejected",
+		},
+		{
+			name:   "publish error keeps the base",
+			prefer: ports.SyncSideLocal,
+			store: &testutil.MockAliasSyncStore{
+				PublishFunc:  func(string) (bool, bool, error) { return false, false, errors.New("push rejected") },
+				SaveBaseFunc: func([]alias.Alias) error { return errors.New("base must not be saved") },
+			},
+			wantErrContains: "failed to publish synced aliases: push rejected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(sc, func(dir string) (ports.AliasSyncStore, error) {
+				if tt.openErr != nil {
+					return nil, tt.openErr
+				}
+				return tt.store, nil
+			}, nil)
+			_, err := svc.Sync("dir", tt.prefer)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+				t.Errorf("Sync() error = %v, want error containing %q", err, tt.wantErrContains)
+			}
+		})
+	}
+}
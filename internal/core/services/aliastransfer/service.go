@@ -0,0 +1,127 @@
+package aliastransfer
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+type service struct {
+	shellConfig    ports.ShellConfigAccessor
+	codec          ports.AliasFileCodec
+	aliasGenerator ports.AliasGenerator
+	logger         ports.Logger
+}
+
+// NewService creates a new alias transfer service.
+// It panics if any of its dependencies is nil, except logger, which can be nil if nothing should be logged.
+func NewService(sc ports.ShellConfigAccessor, codec ports.AliasFileCodec, ag ports.AliasGenerator, logger ports.Logger) ports.AliasTransferService {
+	if sc == nil {
+		panic("shellConfig cannot be nil")
+	}
+	if codec == nil {
+		panic("codec cannot be nil")
+	}
+	if ag == nil {
+		panic("aliasGenerator cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{shellConfig: sc, codec: codec, aliasGenerator: ag, logger: logger}
+}
+
+// ExportAliases encodes every managed alias, in the order of the alias files.
+// An alias defined more than once in a file is exported with its last (effective) definition.
+func (s *service) ExportAliases(format string) ([]byte, error) {
+	definitions, err := s.shellConfig.GetAliasDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read managed aliases: %w", err)
+	}
+
+	exported := make([]alias.Alias, 0, len(definitions))
+	positions := make(map[string]int, len(definitions))
+	for _, def := range definitions {
+		a := alias.Alias{Name: def.Name, Command: def.Command, Group: def.Group, Kind: def.Kind}
+		if i, seen := positions[def.Name]; seen {
+			exported[i] = a
+			continue
+		}
+		positions[def.Name] = len(exported)
+		exported = append(exported, a)
+	}
+
+	data, err := s.codec.Encode(exported, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export aliases: %w", err)
+	}
+	return data, nil
+}
+
+// PlanImport validates every imported alias and compares it with the managed aliases.
+// Names must pass IsValidAliasName; a name repeated in the file is only imported once.
+func (s *service) PlanImport(data []byte, format string) (ports.ImportPlan, error) {
+	var plan ports.ImportPlan
+
+	imported, err := s.codec.Decode(data, format)
+	if err != nil {
+		return plan, fmt.Errorf("failed to read import file: %w", err)
+	}
+	existing, err := s.shellConfig.GetExistingAliases()
+	if err != nil {
+		return plan, fmt.Errorf("failed to get existing aliases: %w", err)
+	}
+
+	seen := make(map[string]string)
+	for _, a := range imported {
+		if a.Command == "" || !s.aliasGenerator.IsValidAliasName(a.Name, seen) {
+			s.logger.Debug("invalid imported alias", "name", a.Name, "command", a.Command)
+			plan.Invalid = append(plan.Invalid, a)
+			continue
+		}
+		seen[a.Name] = a.Command
+
+		existingCommand, exists := existing[a.Name]
+		switch {
+		case !exists:
+			plan.New = append(plan.New, a)
+		case existingCommand == a.Command:
+			plan.Unchanged = append(plan.Unchanged, a)
+		default:
+			plan.Conflicts = append(plan.Conflicts, ports.ImportConflict{Alias: a, ExistingCommand: existingCommand})
+		}
+	}
+	return plan, nil
+}
+
+// ValidateImportName checks name against the current managed aliases, so aliases
+// imported earlier in the same run count as taken.
+func (s *service) ValidateImportName(name string) error {
+	existing, err := s.shellConfig.GetExistingAliases()
+	if err != nil {
+		return fmt.Errorf("failed to get existing aliases for name validation: %w", err)
+	}
+	if command, exists := existing[name]; exists {
+		return &alias.ExistsError{Name: name, Command: command}
+	}
+	if !s.aliasGenerator.IsValidAliasName(name, existing) {
+		return &alias.InvalidNameError{Name: name, Reason: "use letters, digits and dots, and avoid names of commands on PATH"}
+	}
+	return nil
+}
+
+// ImportAlias writes imported to its group, replacing any existing definition if overwrite is set.
+func (s *service) ImportAlias(imported alias.Alias, overwrite bool) (bool, error) {
+	if !overwrite {
+		result, err := s.shellConfig.AddAlias(imported)
+		if err != nil {
+			return false, fmt.Errorf("failed to import alias '%s': %w", imported.Name, err)
+		}
+		return result.Added, nil
+	}
+	if err := s.shellConfig.ReplaceAlias(imported); err != nil {
+		return false, fmt.Errorf("failed to overwrite alias '%s': %w", imported.Name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,238 @@
+package aliastransfer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		name      string
+		sc        ports.ShellConfigAccessor
+		codec     ports.AliasFileCodec
+		ag        ports.AliasGenerator
+		wantPanic bool
+	}{
+		{name: "all dependencies", sc: &testutil.MockShellConfigAccessor{}, codec: &testutil.MockAliasFileCodec{}, ag: &testutil.MockAliasGenerator{}},
+		{name: "nil shellConfig", codec: &testutil.MockAliasFileCodec{}, ag: &testutil.MockAliasGenerator{}, wantPanic: true},
+		{name: "nil codec", sc: &testutil.MockShellConfigAccessor{}, ag: &testutil.MockAliasGenerator{}, wantPanic: true},
+		{name: "nil aliasGenerator", sc: &testutil.MockShellConfigAccessor{}, codec: &testutil.MockAliasFileCodec{}, wantPanic: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("NewService() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			if svc := NewService(tt.sc, tt.codec, tt.ag, nil); svc == nil {
+				t.Error("NewService() returned nil")
+			}
+		})
+	}
+}
+
+func TestService_ExportAliases(t *testing.T) {
+	var encoded []alias.Alias
+	sc := &testutil.MockShellConfigAccessor{
+		GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+			return []alias.Definition{
+				{Alias: alias.Alias{Name: "gs", Command: "git status", Group: "git"}, File: "/home/u/.nicksh/git", Line: 1},
+				{Alias: alias.Alias{Name: "ll", Command: "ls -l"}, File: "/home/u/.nicksh/generated_aliases", Line: 1},
+				{Alias: alias.Alias{Name: "gs", Command: "git status -sb", Group: "git"}, File: "/home/u/.nicksh/git", Line: 2},
+				{Alias: alias.Alias{Name: "G", Command: "| grep -i", Kind: alias.KindGlobal}, File: "/home/u/.nicksh/generated_aliases", Line: 2},
+			}, nil
+		},
+	}
+	codec := &testutil.MockAliasFileCodec{
+		EncodeFunc: func(aliases []alias.Alias, format string) ([]byte, error) {
+			if format != ports.AliasFileFormatJSON {
+				t.Errorf("Encode() format = %q, want %q", format, ports.AliasFileFormatJSON)
+			}
+			encoded = aliases
+			return []byte("encoded"), nil
+		},
+	}
+	svc := NewService(sc, codec, &testutil.MockAliasGenerator{}, nil)
+
+	data, err := svc.ExportAliases(ports.AliasFileFormatJSON)
+	if err != nil {
+		t.Fatalf("ExportAliases() unexpected error: %v", err)
+	}
+	if string(data) != "encoded" {
+		t.Errorf("ExportAliases() = %q, want the codec's output", data)
+	}
+	want := []alias.Alias{
+		{Name: "gs", Command: "git status -sb", Group: "git"},
+		{Name: "ll", Command: "ls -l"},
+		{Name: "G", Command: "| grep -i", Kind: alias.KindGlobal},
+	}
+	if !reflect.DeepEqual(encoded, want) {
+		t.Errorf("ExportAliases() encoded %+v, want %+v", encoded, want)
+	}
+
+	t.Run("definitions error", func(t *testing.T) {
+		sc := &testutil.MockShellConfigAccessor{GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+			return nil, errors.New("unreadable")
+		}}
+		_, err := NewService(sc, codec, &testutil.MockAliasGenerator{}, nil).ExportAliases(ports.AliasFileFormatYAML)
+		if err == nil || !strings.Contains(err.Error(), "unreadable") {
+			t.Errorf("ExportAliases() error = %v, want the definitions error", err)
+		}
+	})
+}
+
+func TestService_PlanImport(t *testing.T) {
+	imported := []alias.Alias{
+		{Name: "gs", Command: "git status"},
+		{Name: "gp", Command: "git push", Group: "git"},
+		{Name: "k", Command: "kubectl"},
+		{Name: "bad name", Command: "echo"},
+		{Name: "empty"},
+		{Name: "gp", Command: "git pull"},
+	}
+	sc := &testutil.MockShellConfigAccessor{
+		GetExistingAliasesFunc: func() (map[string]string, error) {
+			return map[string]string{"gs": "git status", "k": "kubecolor"}, nil
+		},
+	}
+	codec := &testutil.MockAliasFileCodec{
+		DecodeFunc: func(data []byte, format string) ([]alias.Alias, error) {
+			return imported, nil
+		},
+	}
+	ag := &testutil.MockAliasGenerator{
+		IsValidAliasNameFunc: func(name string, existingAliases map[string]string) bool {
+			_, taken := existingAliases[name]
+			return !strings.Contains(name, " ") && !taken
+		},
+	}
+
+	plan, err := NewService(sc, codec, ag, nil).PlanImport([]byte("data"), ports.AliasFileFormatYAML)
+	if err != nil {
+		t.Fatalf("PlanImport() unexpected error: %v", err)
+	}
+	want := ports.ImportPlan{
+		New:       []alias.Alias{{Name: "gp", Command: "git push", Group: "git"}},
+		Unchanged: []alias.Alias{{Name: "gs", Command: "git status"}},
+		Conflicts: []ports.ImportConflict{{Alias: alias.Alias{Name: "k", Command: "kubectl"}, ExistingCommand: "kubecolor"}},
+		Invalid:   []alias.Alias{{Name: "bad name", Command: "echo"}, {Name: "empty"}, {Name: "gp", Command: "git pull"}},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("PlanImport() = %+v, want %+v", plan, want)
+	}
+
+	t.Run("decode error", func(t *testing.T) {
+		codec := &testutil.MockAliasFileCodec{DecodeFunc: func(data []byte, format string) ([]alias.Alias, error) {
+			return nil, errors.New("bad yaml")
+		}}
+		_, err := NewService(sc, codec, ag, nil).PlanImport(nil, ports.AliasFileFormatYAML)
+		if err == nil || !strings.Contains(err.Error(), "failed to read import file: bad yaml") {
+			t.Errorf("PlanImport() error = %v, want decode error", err)
+		}
+	})
+}
+
+func TestService_ValidateImportName(t *testing.T) {
+	sc := &testutil.MockShellConfigAccessor{
+		GetExistingAliasesFunc: func() (map[string]string, error) {
+			return map[string]string{"k": "kubectl"}, nil
+		},
+	}
+	ag := &testutil.MockAliasGenerator{
+		IsValidAliasNameFunc: func(name string, existingAliases map[string]string) bool {
+			return name != "ls"
+		},
+	}
+	svc := NewService(sc, &testutil.MockAliasFileCodec{}, ag, nil)
+
+	tests := []struct {
+		name            string
+		wantErrContains string
+		wantErrIs       error
+	}{
+		{name: "kc"},
+		{name: "k", wantErrContains: "already an alias for 'kubectl'", wantErrIs: alias.ErrAliasExists},
+		{name: "ls", wantErrContains: "not a valid alias name", wantErrIs: alias.ErrInvalidName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ValidateImportName(tt.name)
+			if tt.wantErrContains == "" {
+				if err != nil {
+					t.Errorf("ValidateImportName(%q) unexpected error: %v", tt.name, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+				t.Errorf("ValidateImportName(%q) error = %v, want error containing %q", tt.name, err, tt.wantErrContains)
+			}
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("ValidateImportName(%q) error = %v, want errors.Is(%v)", tt.name, err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestService_ImportAlias(t *testing.T) {
+	imported := alias.Alias{Name: "k", Command: "kubectl", Group: "k8s"}
+
+	tests := []struct {
+		name            string
+		overwrite       bool
+		addErr          error
+		replaceErr      error
+		wantAdded       bool
+		wantCall        string
+		wantErrContains string
+	}{
+		{name: "add", wantAdded: true, wantCall: "add"},
+		{name: "overwrite", overwrite: true, wantAdded: true, wantCall: "replace"},
+		{name: "add error", addErr: errors.New("disk full"), wantCall: "add", wantErrContains: "failed to import alias 'k': disk full"},
+		{name: "overwrite error", overwrite: true, replaceErr: errors.New("disk full"), wantCall: "replace", wantErrContains: "failed to overwrite alias 'k': disk full"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var call string
+			sc := &testutil.MockShellConfigAccessor{
+				AddAliasFunc: func(newAlias alias.Alias) (ports.AddAliasResult, error) {
+					call = "add"
+					if !reflect.DeepEqual(newAlias, imported) {
+						t.Errorf("AddAlias() got %+v, want %+v", newAlias, imported)
+					}
+					return ports.AddAliasResult{Added: tt.addErr == nil}, tt.addErr
+				},
+				ReplaceAliasFunc: func(newAlias alias.Alias) error {
+					call = "replace"
+					if !reflect.DeepEqual(newAlias, imported) {
+						t.Errorf("ReplaceAlias() got %+v, want %+v", newAlias, imported)
+					}
+					return tt.replaceErr
+				},
+			}
+			svc := NewService(sc, &testutil.MockAliasFileCodec{}, &testutil.MockAliasGenerator{}, nil)
+
+			added, err := svc.ImportAlias(imported, tt.overwrite)
+			if call != tt.wantCall {
+				t.Errorf("ImportAlias() called %q, want %q", call, tt.wantCall)
+			}
+			if tt.wantErrContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+					t.Errorf("ImportAlias() error = %v, want error containing %q", err, tt.wantErrContains)
+				}
+				return
+			}
+			if err != nil || added != tt.wantAdded {
+				t.Errorf("ImportAlias() = %v, %v, want %v, nil", added, err, tt.wantAdded)
+			}
+		})
+	}
+}
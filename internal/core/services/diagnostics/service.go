@@ -0,0 +1,69 @@
+package diagnostics
+
+import (
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// historyScanLimit is how many recent history entries the history checks read.
+const historyScanLimit = 1000
+
+type service struct {
+	historyFileFinder ports.HistoryFileFinder
+	historyProvider   ports.HistoryProvider
+	shellConfig       ports.ShellConfigAccessor
+	env               ports.ShellEnvironment
+	logger            ports.Logger
+}
+
+// NewService creates a new diagnostic service.
+// It panics if any dependency but the logger is nil. logger can be nil if nothing should be logged.
+func NewService(
+	finder ports.HistoryFileFinder,
+	hp ports.HistoryProvider,
+	sc ports.ShellConfigAccessor,
+	env ports.ShellEnvironment,
+	logger ports.Logger,
+) ports.DiagnosticService {
+	if finder == nil {
+		panic("historyFileFinder cannot be nil")
+	}
+	if hp == nil {
+		panic("historyProvider cannot be nil")
+	}
+	if sc == nil {
+		panic("shellConfig cannot be nil")
+	}
+	if env == nil {
+		panic("env cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{historyFileFinder: finder, historyProvider: hp, shellConfig: sc, env: env, logger: logger}
+}
+
+// RunDiagnostics runs the checks in the order a user would fix them: the shell first,
+// then its history, then how the aliases are loaded.
+func (s *service) RunDiagnostics() []ports.DiagnosticCheck {
+	shell := s.env.Shell()
+	rcFiles, rcErr := s.env.RCFiles(shell)
+	if rcErr != nil {
+		s.logger.Warn("could not read the shell's startup files", "shell", shell, "error", rcErr)
+	}
+	entries, historyCheck := s.checkHistoryEntries()
+
+	checks := []ports.DiagnosticCheck{
+		checkShell(shell),
+		s.checkHistoryFiles(shell),
+		historyCheck,
+		s.checkHistorySize(shell, rcFiles),
+		checkHistoryTimestamps(shell, entries),
+		checkAliasLoader(shell, s.shellConfig.AliasLoader(), rcFiles, rcErr),
+		s.checkFzf(),
+		s.checkAliasConflicts(rcFiles),
+	}
+	for _, check := range checks {
+		s.logger.Debug("ran diagnostic check", "check", check.Name, "status", check.Status, "detail", check.Detail)
+	}
+	return checks
+}
@@ -0,0 +1,270 @@
+package diagnostics
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// minHistorySize is the history size below which too few commands are kept to find aliases worth suggesting.
+const minHistorySize = 1000
+
+// rcAliasRegex matches the name of an alias defined in a startup file, e.g. "alias gs='git status'",
+// fish's "alias gs 'git status'", or a fish abbreviation, e.g. "abbr -a gs 'git status'".
+var rcAliasRegex = regexp.MustCompile(`(?m)^\s*(?:alias|abbr(?:\s+(?:-a|--add|-g|--global|-U|--universal))+)\s+(?:--\s+)?([A-Za-z0-9_.-]+)[=\s]`)
+
+// isSupportedShell reports whether nicksh knows the history and startup files of shell.
+func isSupportedShell(shell string) bool {
+	return shell == "bash" || shell == "zsh" || shell == "fish"
+}
+
+// rcFileHint names the startup file to edit for shell.
+func rcFileHint(shell string) string {
+	switch shell {
+	case "bash":
+		return "~/.bashrc"
+	case "zsh":
+		return "~/.zshrc"
+	case "fish":
+		return "~/.config/fish/config.fish"
+	case "nu":
+		return "~/.config/nushell/config.nu"
+	case "pwsh":
+		return "your PowerShell profile ($PROFILE)"
+	default:
+		return "your shell's startup file"
+	}
+}
+
+// passed, warned and failed build the outcome of the check name.
+func passed(name, detail string) ports.DiagnosticCheck {
+	return ports.DiagnosticCheck{Name: name, Status: ports.DiagnosticPass, Detail: detail}
+}
+
+func warned(name, detail, remediation string) ports.DiagnosticCheck {
+	return ports.DiagnosticCheck{Name: name, Status: ports.DiagnosticWarn, Detail: detail, Remediation: remediation}
+}
+
+func failed(name, detail, remediation string) ports.DiagnosticCheck {
+	return ports.DiagnosticCheck{Name: name, Status: ports.DiagnosticFail, Detail: detail, Remediation: remediation}
+}
+
+// checkShell checks that $SHELL names a shell nicksh supports.
+func checkShell(shell string) ports.DiagnosticCheck {
+	const name = "shell"
+	switch {
+	case shell == "":
+		return warned(name, "$SHELL is not set, so nicksh cannot tell which shell you use.",
+			"Set SHELL to the path of your shell, e.g. 'export SHELL=/bin/zsh'.")
+	case !isSupportedShell(shell):
+		return warned(name, fmt.Sprintf("Your shell is %s, which nicksh does not know: its history and startup files may be missed.", shell),
+			"Use bash, zsh or fish, or point nicksh at your history with --history.")
+	default:
+		return passed(name, fmt.Sprintf("Your shell is %s.", shell))
+	}
+}
+
+// checkHistoryFiles checks that a history file can be found, and that one looks like the history of shell.
+func (s *service) checkHistoryFiles(shell string) ports.DiagnosticCheck {
+	const name = "history file"
+	const remediation = "Set HISTFILE to your history file (e.g. 'export HISTFILE=~/.zsh_history'), or pass it with --history."
+	files, err := s.historyFileFinder.FindAll()
+	if err != nil {
+		return failed(name, fmt.Sprintf("Could not look for history files: %v.", err), remediation)
+	}
+	if len(files) == 0 {
+		return failed(name, "No history file found in $HISTFILE, ~/.zsh_history or ~/.bash_history.", remediation)
+	}
+	detail := "Found " + strings.Join(files, ", ") + "."
+	if histFile, _ := s.env.LookupEnv("HISTFILE"); histFile != "" {
+		return passed(name, detail) // The shell was told where to write its history.
+	}
+	if shell == "bash" || shell == "zsh" {
+		for _, file := range files {
+			if strings.Contains(filepath.Base(file), shell) {
+				return passed(name, detail)
+			}
+		}
+		return warned(name, detail+fmt.Sprintf(" None of them looks like a %s history, so it may be out of date.", shell),
+			fmt.Sprintf("Export HISTFILE in %s so nicksh reads the history your shell writes.", rcFileHint(shell)))
+	}
+	return passed(name, detail)
+}
+
+// checkHistoryEntries checks that history entries can be read. It also returns the entries read, for other checks.
+func (s *service) checkHistoryEntries() ([]history.Entry, ports.DiagnosticCheck) {
+	const name = "history entries"
+	source := s.historyProvider.GetSourceIdentifier()
+	entries, err := s.historyProvider.GetEntries(historyScanLimit)
+	if err != nil {
+		return nil, failed(name, fmt.Sprintf("Could not read the history (%s): %v.", source, err),
+			"Check that the history is readable, or choose another source with --history or --history-backend.")
+	}
+	if len(entries) == 0 {
+		return nil, failed(name, fmt.Sprintf("No history entries were read (%s).", source),
+			"Check that your shell saves its history (see the history size check), or that 'nicksh show --explain' does not exclude every entry.")
+	}
+	return entries, passed(name, fmt.Sprintf("Read %d recent entries (%s).", len(entries), source))
+}
+
+// checkHistorySize checks that the shell keeps enough history, from the environment or the startup files.
+func (s *service) checkHistorySize(shell string, rcFiles []ports.RCFile) ports.DiagnosticCheck {
+	const name = "history size"
+	var variables []string
+	var defaultSizes []int
+	switch shell {
+	case "bash":
+		variables, defaultSizes = []string{"HISTSIZE"}, []int{500}
+	case "zsh":
+		// SAVEHIST is how many entries are written to the history file, and defaults to none.
+		variables, defaultSizes = []string{"HISTSIZE", "SAVEHIST"}, []int{30, 0}
+	case "fish":
+		return passed(name, "fish keeps its history size itself.")
+	default:
+		return warned(name, "Cannot be checked without a known shell.", "Fix the shell check first.")
+	}
+
+	var problems []string
+	for i, variable := range variables {
+		value, found := s.historySizeSetting(variable, rcFiles)
+		size, err := strconv.Atoi(value)
+		switch {
+		case !found:
+			problems = append(problems, fmt.Sprintf("%s is not set, so %s keeps %d entries", variable, shell, defaultSizes[i]))
+		case err != nil:
+			// Not a literal number, e.g. a variable; trust it.
+		case size >= 0 && size < minHistorySize:
+			problems = append(problems, fmt.Sprintf("%s is %d", variable, size))
+		}
+	}
+	if len(problems) > 0 {
+		assignments := make([]string, 0, len(variables))
+		for _, variable := range variables {
+			assignments = append(assignments, fmt.Sprintf("%s=10000", variable))
+		}
+		return warned(name, strings.Join(problems, "; ")+": too few commands are kept to find good aliases.",
+			fmt.Sprintf("Add '%s' to %s.", strings.Join(assignments, " "), rcFileHint(shell)))
+	}
+	verb := "is"
+	if len(variables) > 1 {
+		verb = "are"
+	}
+	return passed(name, fmt.Sprintf("%s %s at least %d.", strings.Join(variables, " and "), verb, minHistorySize))
+}
+
+// historySizeSetting returns the value of a history size variable, from the environment or,
+// since it usually is not exported, from its last assignment in the startup files.
+func (s *service) historySizeSetting(variable string, rcFiles []ports.RCFile) (string, bool) {
+	if value, ok := s.env.LookupEnv(variable); ok && value != "" {
+		return value, true
+	}
+	assignment := regexp.MustCompile(`(?m)^\s*(?:export\s+|typeset\s+|declare\s+)?` + variable + `=["']?([^"'\s;]*)`)
+	value, found := "", false
+	for _, rcFile := range rcFiles {
+		for _, m := range assignment.FindAllStringSubmatch(rcFile.Content, -1) {
+			value, found = m[1], true
+		}
+	}
+	return value, found
+}
+
+// checkHistoryTimestamps checks that the history records when commands were run.
+func checkHistoryTimestamps(shell string, entries []history.Entry) ports.DiagnosticCheck {
+	const name = "history timestamps"
+	if len(entries) == 0 {
+		return warned(name, "Cannot be checked without history entries.", "Fix the history entries check first.")
+	}
+	for _, entry := range entries {
+		if !entry.Timestamp.IsZero() {
+			return passed(name, "The history records when commands were run.")
+		}
+	}
+	remediation := "Configure your shell to save a timestamp with each history entry."
+	switch shell {
+	case "bash":
+		remediation = "Add 'export HISTTIMEFORMAT=\"%F %T \"' to ~/.bashrc."
+	case "zsh":
+		remediation = "Add 'setopt EXTENDED_HISTORY' to ~/.zshrc."
+	}
+	return warned(name, "The history does not use the extended format, so nicksh cannot tell when commands were run.", remediation)
+}
+
+// checkAliasLoader checks that a startup file loads the alias files of $HOME/.nicksh.
+// loader is the code doing so, in the shell's dialect.
+func checkAliasLoader(shell, loader string, rcFiles []ports.RCFile, rcErr error) ports.DiagnosticCheck {
+	const name = "alias loader"
+	remediation := fmt.Sprintf("Add the following to %s, then open a new terminal session:\n%s", rcFileHint(shell), loader)
+	if rcErr != nil {
+		return failed(name, fmt.Sprintf("Could not read the startup files: %v.", rcErr), remediation)
+	}
+	for _, rcFile := range rcFiles {
+		if strings.Contains(rcFile.Content, ".nicksh") {
+			return passed(name, fmt.Sprintf("%s loads the aliases in $HOME/.nicksh.", rcFile.Path))
+		}
+	}
+	if len(rcFiles) == 0 {
+		return failed(name, "No startup file found, so the aliases in $HOME/.nicksh are never loaded.", remediation)
+	}
+	paths := make([]string, 0, len(rcFiles))
+	for _, rcFile := range rcFiles {
+		paths = append(paths, rcFile.Path)
+	}
+	return failed(name, fmt.Sprintf("None of %s loads the aliases in $HOME/.nicksh.", strings.Join(paths, ", ")), remediation)
+}
+
+// checkFzf checks whether fzf is available for fuzzy selection.
+func (s *service) checkFzf() ports.DiagnosticCheck {
+	const name = "fzf"
+	path, err := s.env.LookPath("fzf")
+	if err != nil {
+		return warned(name, "fzf is not installed: aliases are selected by number instead (with 'nicksh add --no-tui' and 'nicksh add-predefined').",
+			"Install fzf (https://github.com/junegunn/fzf) for fuzzy selection.")
+	}
+	return passed(name, fmt.Sprintf("fzf is installed at %s.", path))
+}
+
+// checkAliasConflicts checks that no alias is defined in more than one place:
+// in several managed alias files, or in a managed alias file and a startup file.
+func (s *service) checkAliasConflicts(rcFiles []ports.RCFile) ports.DiagnosticCheck {
+	const name = "alias conflicts"
+	definitions, err := s.shellConfig.GetAliasDefinitions()
+	if err != nil {
+		return failed(name, fmt.Sprintf("Could not read the alias files: %v.", err),
+			"Check that $HOME/.nicksh/ and the alias files in it are readable.")
+	}
+
+	locations := make(map[string][]string) // Alias name to the files defining it, in order.
+	for _, def := range definitions {
+		locations[def.Name] = append(locations[def.Name], fmt.Sprintf("%s:%d", def.File, def.Line))
+	}
+	managedCount := len(locations)
+	for _, rcFile := range rcFiles {
+		for _, m := range rcAliasRegex.FindAllStringSubmatch(rcFile.Content, -1) {
+			if _, managed := locations[m[1]]; managed {
+				locations[m[1]] = append(locations[m[1]], rcFile.Path)
+			}
+		}
+	}
+
+	var conflicts []string
+	for aliasName, places := range locations {
+		if len(places) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' is defined in %s", aliasName, strings.Join(places, ", ")))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return warned(name, strings.Join(conflicts, "; ")+". The definition loaded last wins.",
+			"Keep one definition of each alias, by editing or deleting the others.")
+	}
+	if managedCount == 0 {
+		return passed(name, "No aliases are managed yet.")
+	}
+	return passed(name, fmt.Sprintf("The %d managed aliases are each defined once.", managedCount))
+}
@@ -0,0 +1,259 @@
+package diagnostics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// posixAliasLoader loads the alias files in the startup files of a healthy setup.
+const posixAliasLoader = `if [ -d "$HOME/.nicksh" ]; then
+  for file in "$HOME/.nicksh"/*; do
+    [ -f "$file" ] && source "$file"
+  done
+fi`
+
+// setup holds the mocks of a diagnosed environment, healthy unless a test changes it.
+type setup struct {
+	finder      *testutil.MockHistoryFileFinder
+	history     *testutil.MockHistoryProvider
+	shellConfig *testutil.MockShellConfigAccessor
+	env         *testutil.MockShellEnvironment
+}
+
+func healthySetup() setup {
+	return setup{
+		finder: &testutil.MockHistoryFileFinder{FindAllFunc: func() ([]string, error) {
+			return []string{"/home/u/.zsh_history"}, nil
+		}},
+		history: &testutil.MockHistoryProvider{
+			GetEntriesFunc: func(scanLimit int) ([]history.Entry, error) {
+				return []history.Entry{{Command: "git status", Timestamp: time.Unix(1700000000, 0)}}, nil
+			},
+			GetSourceIdentifierFunc: func() string { return "~/.zsh_history" },
+		},
+		shellConfig: &testutil.MockShellConfigAccessor{GetAliasDefinitionsFunc: func() ([]alias.Definition, error) {
+			return []alias.Definition{{Alias: alias.Alias{Name: "gs", Command: "git status", Group: "git"}, File: "/home/u/.nicksh/git", Line: 1}}, nil
+		}},
+		env: &testutil.MockShellEnvironment{
+			ShellName:   "zsh",
+			Executables: map[string]string{"fzf": "/usr/bin/fzf"},
+			RCFilesByShell: map[string][]ports.RCFile{"zsh": {{
+				Path:    "/home/u/.zshrc",
+				Content: "HISTSIZE=10000\nSAVEHIST=10000\nsetopt EXTENDED_HISTORY\n" + posixAliasLoader + "\n",
+			}}},
+		},
+	}
+}
+
+func TestNewService(t *testing.T) {
+	s := healthySetup()
+	tests := []struct {
+		name  string
+		build func()
+	}{
+		{name: "nil finder", build: func() { NewService(nil, s.history, s.shellConfig, s.env, nil) }},
+		{name: "nil history provider", build: func() { NewService(s.finder, nil, s.shellConfig, s.env, nil) }},
+		{name: "nil shellConfig", build: func() { NewService(s.finder, s.history, nil, s.env, nil) }},
+		{name: "nil env", build: func() { NewService(s.finder, s.history, s.shellConfig, nil, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" panics", func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewService() did not panic with a %s", tt.name)
+				}
+			}()
+			tt.build()
+		})
+	}
+}
+
+func TestService_RunDiagnostics(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(s *setup)
+		wantStatus map[string]string // Expected status of the checks that are not passed.
+		wantDetail map[string]string // Substrings expected in the detail of some checks.
+	}{
+		{
+			name:   "healthy setup passes every check",
+			modify: func(s *setup) {},
+		},
+		{
+			name:       "SHELL not set",
+			modify:     func(s *setup) { s.env.ShellName = "" },
+			wantStatus: map[string]string{"shell": ports.DiagnosticWarn, "history size": ports.DiagnosticWarn, "alias loader": ports.DiagnosticFail},
+		},
+		{
+			name: "no history file",
+			modify: func(s *setup) {
+				s.finder.FindAllFunc = func() ([]string, error) { return nil, nil }
+				s.history.GetEntriesFunc = func(int) ([]history.Entry, error) {
+					return nil, history.ErrHistoryNotFound
+				}
+			},
+			wantStatus: map[string]string{
+				"history file":       ports.DiagnosticFail,
+				"history entries":    ports.DiagnosticFail,
+				"history timestamps": ports.DiagnosticWarn,
+			},
+		},
+		{
+			name: "only the history of another shell",
+			modify: func(s *setup) {
+				s.finder.FindAllFunc = func() ([]string, error) { return []string{"/home/u/.bash_history"}, nil }
+			},
+			wantStatus: map[string]string{"history file": ports.DiagnosticWarn},
+		},
+		{
+			name: "history file named by an exported HISTFILE",
+			modify: func(s *setup) {
+				s.finder.FindAllFunc = func() ([]string, error) { return []string{"/home/u/.histfile"}, nil }
+				s.env.Env = map[string]string{"HISTFILE": "/home/u/.histfile"}
+			},
+		},
+		{
+			name: "empty history",
+			modify: func(s *setup) {
+				s.history.GetEntriesFunc = func(int) ([]history.Entry, error) { return nil, nil }
+			},
+			wantStatus: map[string]string{"history entries": ports.DiagnosticFail, "history timestamps": ports.DiagnosticWarn},
+			wantDetail: map[string]string{"history entries": "~/.zsh_history"},
+		},
+		{
+			name: "SAVEHIST not set",
+			modify: func(s *setup) {
+				s.env.RCFilesByShell["zsh"][0].Content = "HISTSIZE=50000\n" + posixAliasLoader
+			},
+			wantStatus: map[string]string{"history size": ports.DiagnosticWarn},
+			wantDetail: map[string]string{"history size": "SAVEHIST is not set"},
+		},
+		{
+			name: "small exported HISTSIZE wins over the startup files",
+			modify: func(s *setup) {
+				s.env.Env = map[string]string{"HISTSIZE": "200"}
+			},
+			wantStatus: map[string]string{"history size": ports.DiagnosticWarn},
+			wantDetail: map[string]string{"history size": "HISTSIZE is 200"},
+		},
+		{
+			name: "bash with unlimited history",
+			modify: func(s *setup) {
+				s.env.ShellName = "bash"
+				s.finder.FindAllFunc = func() ([]string, error) { return []string{"/home/u/.bash_history"}, nil }
+				s.env.RCFilesByShell["bash"] = []ports.RCFile{{Path: "/home/u/.bashrc", Content: "export HISTSIZE=-1\n" + posixAliasLoader}}
+			},
+		},
+		{
+			name: "history without timestamps",
+			modify: func(s *setup) {
+				s.history.GetEntriesFunc = func(int) ([]history.Entry, error) {
+					return []history.Entry{{Command: "git status"}}, nil
+				}
+			},
+			wantStatus: map[string]string{"history timestamps": ports.DiagnosticWarn},
+		},
+		{
+			name: "loader missing from the startup files",
+			modify: func(s *setup) {
+				s.env.RCFilesByShell["zsh"][0].Content = "HISTSIZE=10000\nSAVEHIST=10000\n"
+			},
+			wantStatus: map[string]string{"alias loader": ports.DiagnosticFail},
+			wantDetail: map[string]string{"alias loader": "/home/u/.zshrc"},
+		},
+		{
+			name:       "unreadable startup files",
+			modify:     func(s *setup) { s.env.RCFilesErr = errors.New("permission denied") },
+			wantStatus: map[string]string{"history size": ports.DiagnosticWarn, "alias loader": ports.DiagnosticFail},
+		},
+		{
+			name:       "fzf not installed",
+			modify:     func(s *setup) { s.env.Executables = nil },
+			wantStatus: map[string]string{"fzf": ports.DiagnosticWarn},
+		},
+		{
+			name: "alias defined in two managed files",
+			modify: func(s *setup) {
+				s.shellConfig.GetAliasDefinitionsFunc = func() ([]alias.Definition, error) {
+					return []alias.Definition{
+						{Alias: alias.Alias{Name: "gs", Command: "git status", Group: "git"}, File: "/home/u/.nicksh/git", Line: 1},
+						{Alias: alias.Alias{Name: "gs", Command: "git show", Group: "generated_aliases"}, File: "/home/u/.nicksh/generated_aliases", Line: 4},
+					}, nil
+				}
+			},
+			wantStatus: map[string]string{"alias conflicts": ports.DiagnosticWarn},
+			wantDetail: map[string]string{"alias conflicts": "/home/u/.nicksh/git:1, /home/u/.nicksh/generated_aliases:4"},
+		},
+		{
+			name: "managed alias also defined in a startup file",
+			modify: func(s *setup) {
+				s.env.RCFilesByShell["zsh"][0].Content += "alias gs='git status -sb'\nalias ll='ls -l'\n"
+			},
+			wantStatus: map[string]string{"alias conflicts": ports.DiagnosticWarn},
+			wantDetail: map[string]string{"alias conflicts": "'gs' is defined in /home/u/.nicksh/git:1, /home/u/.zshrc"},
+		},
+		{
+			name: "managed alias also defined as a fish abbreviation",
+			modify: func(s *setup) {
+				s.env.RCFilesByShell["zsh"][0].Content += "abbr -a -g gs 'git status -sb'\nabbr --erase gs\n"
+			},
+			wantStatus: map[string]string{"alias conflicts": ports.DiagnosticWarn},
+			wantDetail: map[string]string{"alias conflicts": "'gs' is defined in /home/u/.nicksh/git:1, /home/u/.zshrc."},
+		},
+		{
+			name: "unreadable alias files",
+			modify: func(s *setup) {
+				s.shellConfig.GetAliasDefinitionsFunc = func() ([]alias.Definition, error) { return nil, errors.New("permission denied") }
+			},
+			wantStatus: map[string]string{"alias conflicts": ports.DiagnosticFail},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := healthySetup()
+			tt.modify(&s)
+			svc := NewService(s.finder, s.history, s.shellConfig, s.env, nil)
+
+			checks := svc.RunDiagnostics()
+			if len(checks) != 8 {
+				t.Fatalf("RunDiagnostics() returned %d checks, want 8", len(checks))
+			}
+			for _, check := range checks {
+				wantStatus, ok := tt.wantStatus[check.Name]
+				if !ok {
+					wantStatus = ports.DiagnosticPass
+				}
+				if check.Status != wantStatus {
+					t.Errorf("check %q status = %q, want %q (detail: %s)", check.Name, check.Status, wantStatus, check.Detail)
+				}
+				if check.Status != ports.DiagnosticPass && check.Remediation == "" {
+					t.Errorf("check %q is %q without a remediation", check.Name, check.Status)
+				}
+				if want, ok := tt.wantDetail[check.Name]; ok && !strings.Contains(check.Detail, want) {
+					t.Errorf("check %q detail = %q, want it to contain %q", check.Name, check.Detail, want)
+				}
+			}
+		})
+	}
+}
+
+func TestService_RunDiagnostics_LoaderOfTheDialect(t *testing.T) {
+	s := healthySetup()
+	s.env.RCFilesByShell["zsh"][0].Content = "HISTSIZE=10000\nSAVEHIST=10000\n"
+	s.shellConfig.AliasLoaderFunc = func() string { return "source ~/.nicksh/generated_aliases" }
+	svc := NewService(s.finder, s.history, s.shellConfig, s.env, nil)
+
+	for _, check := range svc.RunDiagnostics() {
+		if check.Name == "alias loader" && !strings.Contains(check.Remediation, "source ~/.nicksh/generated_aliases") {
+			t.Errorf("alias loader remediation = %q, want the shell config's loader", check.Remediation)
+		}
+	}
+}
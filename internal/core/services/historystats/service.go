@@ -0,0 +1,97 @@
+package historystats
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+type service struct {
+	historyProvider ports.HistoryProvider
+	analyzer        ports.CommandAnalyzer
+	aliasGenerator  ports.AliasGenerator
+	shellConfig     ports.ShellConfigAccessor
+	logger          ports.Logger
+}
+
+// NewService creates a new history stats service.
+// It panics if any dependency but the logger is nil. logger can be nil if nothing should be logged.
+func NewService(
+	hp ports.HistoryProvider,
+	analyzer ports.CommandAnalyzer,
+	ag ports.AliasGenerator,
+	sc ports.ShellConfigAccessor,
+	logger ports.Logger,
+) ports.HistoryStatsService {
+	if hp == nil {
+		panic("historyProvider cannot be nil")
+	}
+	if analyzer == nil {
+		panic("analyzer cannot be nil")
+	}
+	if ag == nil {
+		panic("aliasGenerator cannot be nil")
+	}
+	if sc == nil {
+		panic("shellConfig cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{historyProvider: hp, analyzer: analyzer, aliasGenerator: ag, shellConfig: sc, logger: logger}
+}
+
+// GetHistoryStats reads the history once, and derives every statistic from the same entries.
+func (s *service) GetHistoryStats(scanLimit, topLimit, minFrequency int) (ports.HistoryStats, error) {
+	var stats ports.HistoryStats
+
+	existingAliases, err := s.shellConfig.GetExistingAliases()
+	if err != nil {
+		return stats, fmt.Errorf("failed to get existing aliases for history stats: %w", err)
+	}
+	entries, err := s.historyProvider.GetEntries(scanLimit)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get history entries: %w", err)
+	}
+	s.logger.Info("computing history stats", "entries", len(entries), "existingAliases", len(existingAliases))
+
+	stats.SourceDetails = s.historyProvider.GetSourceIdentifier()
+	stats.Commands = len(entries)
+	commandNames := make(map[string]int)
+	subcommands := make(map[string]int)
+	fullCommands := make(map[string]int)
+	for _, entry := range entries {
+		keystrokes := typedKeystrokes(entry.Command)
+		stats.Keystrokes += keystrokes
+		fullCommands[entry.Command]++
+
+		analyzed := s.analyzer.Analyze(entry.Command)
+		if analyzed.CommandName != "" {
+			commandNames[analyzed.CommandName]++
+			if subcommand := firstNonFlagArg(analyzed.PotentialArgs); subcommand != "" {
+				subcommands[analyzed.CommandName+" "+subcommand]++
+			}
+		}
+
+		addAliasCoverage(&stats.AliasCoverage, entry.Command, keystrokes, existingAliases)
+
+		if !entry.Timestamp.IsZero() {
+			stats.HasTimestamps = true
+			local := entry.Timestamp.Local()
+			addVolume(&stats.VolumeByHour[local.Hour()], keystrokes)
+			addVolume(&stats.VolumeByWeekday[local.Weekday()], keystrokes)
+		}
+	}
+	stats.TopCommands = topCounts(commandNames, topLimit)
+	stats.TopSubcommands = topCounts(subcommands, topLimit)
+
+	var suggestions []alias.Alias
+	for _, suggestion := range s.aliasGenerator.GenerateSuggestions(topCounts(fullCommands, 0), existingAliases, minFrequency) {
+		if s.shellConfig.SupportsKind(suggestion.Kind) {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	stats.SuggestionSavings = estimateSavings(suggestions, fullCommands)
+	return stats, nil
+}
@@ -0,0 +1,129 @@
+package historystats
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// typedKeystrokes returns the number of characters typed for a command.
+func typedKeystrokes(cmd string) int {
+	return utf8.RuneCountInString(strings.TrimSpace(cmd))
+}
+
+// controlOperators separate commands: the arguments after them belong to another command.
+var controlOperators = []string{"|", "|&", "||", "&&", ";", "&"}
+
+// firstNonFlagArg returns the first argument that is not a flag, e.g. "status" in "git -C dir status",
+// or "" if there is none before the command ends, e.g. in "ls -la | grep x" or "make; make test".
+func firstNonFlagArg(args []string) string {
+	for _, arg := range args {
+		if slices.Contains(controlOperators, arg) {
+			return ""
+		}
+		// A semicolon can end an argument, as in "git status; ls".
+		arg, ended := strings.CutSuffix(arg, ";")
+		if arg != "" && !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+		if ended {
+			return ""
+		}
+	}
+	return ""
+}
+
+// addVolume counts a command of the given keystrokes in volume.
+func addVolume(volume *ports.TypingVolume, keystrokes int) {
+	volume.Commands++
+	volume.Keystrokes += keystrokes
+}
+
+// addAliasCoverage counts a command in coverage: either it starts with an alias name, and the
+// alias saved keystrokes, or it starts with the command of an alias, which could have been used.
+func addAliasCoverage(coverage *ports.AliasCoverage, cmd string, keystrokes int, aliases map[string]string) {
+	cmd = strings.TrimSpace(cmd)
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return
+	}
+	if expansion, isAlias := aliases[fields[0]]; isAlias {
+		coverage.CoveredKeystrokes += keystrokes
+		coverage.SavedKeystrokes += savedPerUse(fields[0], expansion)
+		return
+	}
+	name, aliasCommand := longestMatchingAlias(cmd, aliases)
+	if aliasCommand == "" {
+		return
+	}
+	coverage.CoveredKeystrokes += keystrokes
+	coverage.MissedKeystrokes += savedPerUse(name, aliasCommand)
+}
+
+// longestMatchingAlias returns the alias whose command cmd is, or starts with, as a whole word.
+// The alias with the longest command wins, as it saves the most keystrokes; ties go to the
+// shortest name. It returns empty strings if no alias matches.
+func longestMatchingAlias(cmd string, aliases map[string]string) (string, string) {
+	bestName, bestCommand := "", ""
+	for name, aliasCommand := range aliases {
+		if cmd != aliasCommand && !strings.HasPrefix(cmd, aliasCommand+" ") {
+			continue
+		}
+		if len(aliasCommand) > len(bestCommand) ||
+			(len(aliasCommand) == len(bestCommand) && (len(name) < len(bestName) || (len(name) == len(bestName) && name < bestName))) {
+			bestName, bestCommand = name, aliasCommand
+		}
+	}
+	return bestName, bestCommand
+}
+
+// savedPerUse returns the keystrokes saved each time name is typed instead of aliasCommand.
+func savedPerUse(name, aliasCommand string) int {
+	return max(utf8.RuneCountInString(aliasCommand)-utf8.RuneCountInString(name), 0)
+}
+
+// topCounts returns the limit most frequent items of counts, most frequent first (ties are
+// ordered by command). A limit of 0 or less returns them all.
+func topCounts(counts map[string]int, limit int) []history.CommandFrequency {
+	frequencies := make([]history.CommandFrequency, 0, len(counts))
+	for cmd, count := range counts {
+		frequencies = append(frequencies, history.CommandFrequency{Command: cmd, Count: count})
+	}
+	sort.Slice(frequencies, func(i, j int) bool {
+		if frequencies[i].Count != frequencies[j].Count {
+			return frequencies[i].Count > frequencies[j].Count
+		}
+		return frequencies[i].Command < frequencies[j].Command
+	})
+	if limit > 0 && len(frequencies) > limit {
+		frequencies = frequencies[:limit]
+	}
+	return frequencies
+}
+
+// estimateSavings adds up the keystrokes the suggestions would have saved over the commands
+// counted in fullCommands. Suggestions may overlap (e.g. "git commit" and "git commit -m fix"),
+// so each command is only credited to the suggestion saving the most on it.
+// Suggestions withheld for containing secrets cannot be added, and are not counted.
+func estimateSavings(suggestions []alias.Alias, fullCommands map[string]int) ports.SuggestionSavings {
+	var savings ports.SuggestionSavings
+	suggested := make(map[string]string, len(suggestions))
+	for _, suggestion := range suggestions {
+		if len(suggestion.Secrets) > 0 {
+			continue
+		}
+		savings.Suggestions++
+		suggested[suggestion.Name] = suggestion.Command
+	}
+	for cmd, count := range fullCommands {
+		if name, aliasCommand := longestMatchingAlias(strings.TrimSpace(cmd), suggested); aliasCommand != "" {
+			savings.Keystrokes += count * savedPerUse(name, aliasCommand)
+		}
+	}
+	return savings
+}
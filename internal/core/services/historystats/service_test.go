@@ -0,0 +1,203 @@
+package historystats
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/command"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// fieldsAnalyzer analyzes commands by splitting them on spaces.
+func fieldsAnalyzer() *testutil.MockCommandAnalyzer {
+	analyzer := testutil.NewMockCommandAnalyzer()
+	analyzer.AnalyzeFunc = func(commandStr string) command.AnalyzedCommand {
+		fields := strings.Fields(commandStr)
+		if len(fields) == 0 {
+			return command.AnalyzedCommand{Original: commandStr}
+		}
+		return command.AnalyzedCommand{Original: commandStr, CommandName: fields[0], PotentialArgs: fields[1:]}
+	}
+	return analyzer
+}
+
+func TestNewService(t *testing.T) {
+	hp, analyzer, ag, sc := &testutil.MockHistoryProvider{}, fieldsAnalyzer(), &testutil.MockAliasGenerator{}, &testutil.MockShellConfigAccessor{}
+	tests := []struct {
+		name  string
+		build func()
+	}{
+		{name: "nil history provider", build: func() { NewService(nil, analyzer, ag, sc, nil) }},
+		{name: "nil analyzer", build: func() { NewService(hp, nil, ag, sc, nil) }},
+		{name: "nil alias generator", build: func() { NewService(hp, analyzer, nil, sc, nil) }},
+		{name: "nil shellConfig", build: func() { NewService(hp, analyzer, ag, nil, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" panics", func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewService() did not panic with a %s", tt.name)
+				}
+			}()
+			tt.build()
+		})
+	}
+}
+
+func TestService_GetHistoryStats(t *testing.T) {
+	monday9am := time.Date(2024, time.January, 1, 9, 30, 0, 0, time.Local)
+	entries := []history.Entry{
+		{Command: "git status", Timestamp: monday9am},                       // 10 keystrokes, could have used gs.
+		{Command: "gs", Timestamp: monday9am.Add(time.Minute)},              // 2 keystrokes, gs saved 8.
+		{Command: "git commit -m fix", Timestamp: monday9am.Add(time.Hour)}, // 17 keystrokes.
+		{Command: "ls -la", Timestamp: monday9am.Add(24 * time.Hour)},       // 6 keystrokes, Tuesday.
+	}
+	var gotFrequencies []history.CommandFrequency
+	svc := NewService(
+		&testutil.MockHistoryProvider{
+			GetEntriesFunc:          func(scanLimit int) ([]history.Entry, error) { return entries, nil },
+			GetSourceIdentifierFunc: func() string { return "File: ~/.zsh_history" },
+		},
+		fieldsAnalyzer(),
+		&testutil.MockAliasGenerator{GenerateSuggestionsFunc: func(frequencies []history.CommandFrequency, existing map[string]string, minFrequency int) []alias.Alias {
+			gotFrequencies = frequencies
+			return []alias.Alias{
+				{Name: "gco", Command: "git commit", Frequency: 1},
+				{Name: "gcmf", Command: "git commit -m fix", Frequency: 1},
+				{Name: "ll", Command: "ls -la", Frequency: 1},
+				{Name: "tok", Command: "export TOKEN=[REDACTED]", Frequency: 5, Secrets: []string{"token"}},
+			}
+		}},
+		&testutil.MockShellConfigAccessor{GetExistingAliasesFunc: func() (map[string]string, error) {
+			return map[string]string{"gs": "git status", "g": "git"}, nil
+		}},
+		nil,
+	)
+
+	stats, err := svc.GetHistoryStats(500, 2, 3)
+	if err != nil {
+		t.Fatalf("GetHistoryStats() error = %v", err)
+	}
+
+	if stats.SourceDetails != "File: ~/.zsh_history" || stats.Commands != 4 || stats.Keystrokes != 35 {
+		t.Errorf("GetHistoryStats() source, commands, keystrokes = %q, %d, %d, want %q, 4, 35",
+			stats.SourceDetails, stats.Commands, stats.Keystrokes, "File: ~/.zsh_history")
+	}
+	wantTopCommands := []history.CommandFrequency{{Command: "git", Count: 2}, {Command: "gs", Count: 1}}
+	if !reflect.DeepEqual(stats.TopCommands, wantTopCommands) {
+		t.Errorf("TopCommands = %v, want %v", stats.TopCommands, wantTopCommands)
+	}
+	wantTopSubcommands := []history.CommandFrequency{{Command: "git commit", Count: 1}, {Command: "git status", Count: 1}}
+	if !reflect.DeepEqual(stats.TopSubcommands, wantTopSubcommands) {
+		t.Errorf("TopSubcommands = %v, want %v", stats.TopSubcommands, wantTopSubcommands)
+	}
+	// "git status" and "git commit -m fix" start with the command of g; the longer gs wins for "git status".
+	wantCoverage := ports.AliasCoverage{CoveredKeystrokes: 29, SavedKeystrokes: 8, MissedKeystrokes: 8 + 2}
+	if stats.AliasCoverage != wantCoverage {
+		t.Errorf("AliasCoverage = %+v, want %+v", stats.AliasCoverage, wantCoverage)
+	}
+	if !stats.HasTimestamps {
+		t.Error("HasTimestamps = false, want true")
+	}
+	if got := stats.VolumeByHour[9]; got != (ports.TypingVolume{Commands: 3, Keystrokes: 18}) {
+		t.Errorf("VolumeByHour[9] = %+v, want 3 commands, 18 keystrokes", got)
+	}
+	if got := stats.VolumeByWeekday[time.Monday]; got != (ports.TypingVolume{Commands: 3, Keystrokes: 29}) {
+		t.Errorf("VolumeByWeekday[Monday] = %+v, want 3 commands, 29 keystrokes", got)
+	}
+	// "git commit -m fix" is credited to gcmf (13) only, not to gco too; ls -la to ll (4).
+	// The suggestion with a secret is not counted.
+	wantSavings := ports.SuggestionSavings{Suggestions: 3, Keystrokes: 17}
+	if stats.SuggestionSavings != wantSavings {
+		t.Errorf("SuggestionSavings = %+v, want %+v", stats.SuggestionSavings, wantSavings)
+	}
+	if len(gotFrequencies) != 4 {
+		t.Errorf("GenerateSuggestions() got %d command frequencies, want every scanned command (4)", len(gotFrequencies))
+	}
+}
+
+func TestService_GetHistoryStats_WithoutTimestamps(t *testing.T) {
+	svc := NewService(
+		&testutil.MockHistoryProvider{GetEntriesFunc: func(int) ([]history.Entry, error) {
+			return []history.Entry{{Command: "make test"}}, nil
+		}},
+		fieldsAnalyzer(),
+		&testutil.MockAliasGenerator{},
+		&testutil.MockShellConfigAccessor{GetExistingAliasesFunc: func() (map[string]string, error) { return nil, nil }},
+		nil,
+	)
+	stats, err := svc.GetHistoryStats(500, 10, 3)
+	if err != nil {
+		t.Fatalf("GetHistoryStats() error = %v", err)
+	}
+	if stats.HasTimestamps || stats.VolumeByHour != [24]ports.TypingVolume{} {
+		t.Errorf("GetHistoryStats() reported volumes without timestamps: %+v", stats.VolumeByHour)
+	}
+}
+
+func TestService_GetHistoryStats_Errors(t *testing.T) {
+	historyErr := errors.New("history unreadable")
+	aliasesErr := errors.New("alias files unreadable")
+	tests := []struct {
+		name    string
+		hp      *testutil.MockHistoryProvider
+		sc      *testutil.MockShellConfigAccessor
+		wantErr error
+	}{
+		{
+			name:    "history error",
+			hp:      &testutil.MockHistoryProvider{GetEntriesFunc: func(int) ([]history.Entry, error) { return nil, historyErr }},
+			sc:      &testutil.MockShellConfigAccessor{GetExistingAliasesFunc: func() (map[string]string, error) { return nil, nil }},
+			wantErr: historyErr,
+		},
+		{
+			name:    "existing aliases error",
+			hp:      &testutil.MockHistoryProvider{},
+			sc:      &testutil.MockShellConfigAccessor{GetExistingAliasesFunc: func() (map[string]string, error) { return nil, aliasesErr }},
+			wantErr: aliasesErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(tt.hp, fieldsAnalyzer(), &testutil.MockAliasGenerator{}, tt.sc, nil)
+			if _, err := svc.GetHistoryStats(500, 10, 3); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetHistoryStats() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFirstNonFlagArg(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{name: "subcommand after flags", command: "git --no-pager log", want: "log"},
+		{name: "no arguments", command: "ls", want: ""},
+		{name: "only flags", command: "ls -la", want: ""},
+		{name: "pipe", command: "ls -la | grep x", want: ""},
+		{name: "pipe with stderr", command: "make -j8 |& tee log", want: ""},
+		{name: "or", command: "test -f x || touch x", want: "x"},
+		{name: "and", command: "make -s && make install", want: ""},
+		{name: "semicolon", command: "make -s ; ls", want: ""},
+		{name: "semicolon ending an argument", command: "git status; ls", want: "status"},
+		{name: "semicolon ending a flag", command: "make -s; ls", want: ""},
+		{name: "background", command: "sleep & wait", want: ""},
+		{name: "subcommand before a pipe", command: "git log | less", want: "log"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := strings.Fields(tt.command)
+			if got := firstNonFlagArg(fields[1:]); got != tt.want {
+				t.Errorf("firstNonFlagArg(%q) = %q, want %q", fields[1:], got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package projectaliases
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+type service struct {
+	projectAliasProvider ports.ProjectAliasProvider
+	allowList            ports.ProjectAllowList
+	aliasGenerator       ports.AliasGenerator
+	logger               ports.Logger
+}
+
+// NewService creates a new project alias service.
+// It panics if projectAliasProvider, allowList or aliasGenerator are nil.
+// logger can be nil if nothing should be logged.
+func NewService(pap ports.ProjectAliasProvider, allowList ports.ProjectAllowList, ag ports.AliasGenerator, logger ports.Logger) ports.ProjectAliasService {
+	if pap == nil {
+		panic("projectAliasProvider cannot be nil")
+	}
+	if allowList == nil {
+		panic("allowList cannot be nil")
+	}
+	if ag == nil {
+		panic("aliasGenerator cannot be nil")
+	}
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &service{projectAliasProvider: pap, allowList: allowList, aliasGenerator: ag, logger: logger}
+}
+
+// GetProjectAliases finds the project alias file governing dir and returns its aliases.
+// Aliases with invalid names are returned separately in Skipped.
+// Project aliases are meant to override global ones, so they are not checked against existing aliases.
+// The aliases are returned even if the file is not allowed, for the caller to show them.
+func (s *service) GetProjectAliases(dir string) (ports.ProjectAliases, error) {
+	var result ports.ProjectAliases
+
+	projectFile, err := s.projectAliasProvider.FindProjectFile(dir)
+	if err != nil {
+		return result, fmt.Errorf("failed to find project alias file for %s: %w", dir, err)
+	}
+	if projectFile == "" {
+		return result, nil // No project aliases apply to this directory.
+	}
+	result.ProjectFile = projectFile
+
+	result.Allowed, err = s.allowList.IsAllowed(projectFile)
+	if err != nil {
+		return result, fmt.Errorf("failed to check whether %s is allowed: %w", projectFile, err)
+	}
+
+	loaded, err := s.projectAliasProvider.GetProjectAliases(projectFile)
+	if err != nil {
+		return result, fmt.Errorf("failed to load project aliases: %w", err)
+	}
+
+	seen := make(map[string]string)
+	for _, pa := range loaded {
+		if pa.Command == "" || !s.aliasGenerator.IsValidAliasName(pa.Name, seen) {
+			s.logger.Debug("skipped project alias", "name", pa.Name, "command", pa.Command, "file", projectFile)
+			result.Skipped = append(result.Skipped, pa)
+			continue
+		}
+		seen[pa.Name] = pa.Command
+		result.Aliases = append(result.Aliases, alias.Alias{Name: pa.Name, Command: pa.Command})
+	}
+	return result, nil
+}
+
+// AllowProject allows the project alias file governing dir, as it is now.
+func (s *service) AllowProject(dir string) (string, error) {
+	projectFile, err := s.findProjectFile(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := s.allowList.Allow(projectFile); err != nil {
+		return "", fmt.Errorf("failed to allow %s: %w", projectFile, err)
+	}
+	return projectFile, nil
+}
+
+// DenyProject revokes the permission to load the project alias file governing dir.
+func (s *service) DenyProject(dir string) (string, error) {
+	projectFile, err := s.findProjectFile(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := s.allowList.Deny(projectFile); err != nil {
+		return "", fmt.Errorf("failed to deny %s: %w", projectFile, err)
+	}
+	return projectFile, nil
+}
+
+// findProjectFile returns the project alias file governing dir, or an error if there is none.
+func (s *service) findProjectFile(dir string) (string, error) {
+	projectFile, err := s.projectAliasProvider.FindProjectFile(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find project alias file for %s: %w", dir, err)
+	}
+	if projectFile == "" {
+		return "", fmt.Errorf("no project alias file found in %s or any of its parents", dir)
+	}
+	return projectFile, nil
+}
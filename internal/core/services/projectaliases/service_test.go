@@ -0,0 +1,192 @@
+package projectaliases
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+func TestNewService(t *testing.T) {
+	t.Run("should panic if projectAliasProvider is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewService did not panic with nil projectAliasProvider")
+			}
+		}()
+		_ = NewService(nil, &testutil.MockProjectAllowList{}, &testutil.MockAliasGenerator{}, nil)
+	})
+
+	t.Run("should panic if allowList is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewService did not panic with nil allowList")
+			}
+		}()
+		_ = NewService(&testutil.MockProjectAliasProvider{}, nil, &testutil.MockAliasGenerator{}, nil)
+	})
+
+	t.Run("should panic if aliasGenerator is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("NewService did not panic with nil aliasGenerator")
+			}
+		}()
+		_ = NewService(&testutil.MockProjectAliasProvider{}, &testutil.MockProjectAllowList{}, nil, nil)
+	})
+}
+
+func TestService_GetProjectAliases(t *testing.T) {
+	const projectFile = "/work/app/.nicksh.yaml"
+
+	tests := []struct {
+		name              string
+		provider          *testutil.MockProjectAliasProvider
+		allowList         *testutil.MockProjectAllowList
+		isValidName       func(name string, existing map[string]string) bool
+		want              ports.ProjectAliases
+		wantErrorContains string
+	}{
+		{
+			name:     "no project file",
+			provider: &testutil.MockProjectAliasProvider{},
+			want:     ports.ProjectAliases{},
+		},
+		{
+			name: "valid and invalid aliases",
+			provider: &testutil.MockProjectAliasProvider{
+				FindProjectFileFunc: func(dir string) (string, error) { return projectFile, nil },
+				GetProjectAliasesFunc: func(string) ([]alias.Alias, error) {
+					return []alias.Alias{
+						{Name: "ti", Command: "make test-integration"},
+						{Name: "ls", Command: "ls -G"},
+						{Name: "ti", Command: "make test"}, // Duplicate in the same file.
+						{Name: "empty", Command: ""},
+					}, nil
+				},
+			},
+			isValidName: func(name string, existing map[string]string) bool {
+				_, taken := existing[name]
+				return name != "ls" && !taken
+			},
+			want: ports.ProjectAliases{
+				ProjectFile: projectFile,
+				Allowed:     true,
+				Aliases:     []alias.Alias{{Name: "ti", Command: "make test-integration"}},
+				Skipped: []alias.Alias{
+					{Name: "ls", Command: "ls -G"},
+					{Name: "ti", Command: "make test"},
+					{Name: "empty", Command: ""},
+				},
+			},
+		},
+		{
+			name: "file not allowed",
+			provider: &testutil.MockProjectAliasProvider{
+				FindProjectFileFunc: func(dir string) (string, error) { return projectFile, nil },
+				GetProjectAliasesFunc: func(string) ([]alias.Alias, error) {
+					return []alias.Alias{{Name: "ls", Command: "rm -rf ~"}}, nil
+				},
+			},
+			allowList: &testutil.MockProjectAllowList{
+				IsAllowedFunc: func(string) (bool, error) { return false, nil },
+			},
+			want: ports.ProjectAliases{
+				ProjectFile: projectFile,
+				Allowed:     false,
+				Aliases:     []alias.Alias{{Name: "ls", Command: "rm -rf ~"}},
+			},
+		},
+		{
+			name: "allow list error",
+			provider: &testutil.MockProjectAliasProvider{
+				FindProjectFileFunc: func(dir string) (string, error) { return projectFile, nil },
+			},
+			allowList: &testutil.MockProjectAllowList{
+				IsAllowedFunc: func(string) (bool, error) { return false, errors.New("corrupt allow list") },
+			},
+			wantErrorContains: "failed to check whether",
+		},
+		{
+			name: "find error",
+			provider: &testutil.MockProjectAliasProvider{
+				FindProjectFileFunc: func(dir string) (string, error) { return "", errors.New("permission denied") },
+			},
+			wantErrorContains: "failed to find project alias file",
+		},
+		{
+			name: "load error",
+			provider: &testutil.MockProjectAliasProvider{
+				FindProjectFileFunc:   func(dir string) (string, error) { return projectFile, nil },
+				GetProjectAliasesFunc: func(string) ([]alias.Alias, error) { return nil, errors.New("bad yaml") },
+			},
+			wantErrorContains: "failed to load project aliases",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowList := tt.allowList
+			if allowList == nil {
+				allowList = &testutil.MockProjectAllowList{}
+			}
+			svc := NewService(tt.provider, allowList, &testutil.MockAliasGenerator{IsValidAliasNameFunc: tt.isValidName}, nil)
+
+			got, err := svc.GetProjectAliases("/work/app/src")
+
+			if tt.wantErrorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrorContains) {
+					t.Fatalf("GetProjectAliases() error = %v, want error containing %q", err, tt.wantErrorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetProjectAliases() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetProjectAliases() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_AllowAndDenyProject(t *testing.T) {
+	const projectFile = "/work/app/.nicksh.yaml"
+	var allowed, denied []string
+	allowList := &testutil.MockProjectAllowList{
+		AllowFunc: func(f string) error { allowed = append(allowed, f); return nil },
+		DenyFunc:  func(f string) error { denied = append(denied, f); return nil },
+	}
+	provider := &testutil.MockProjectAliasProvider{
+		FindProjectFileFunc: func(dir string) (string, error) {
+			if strings.HasPrefix(dir, "/work/app") {
+				return projectFile, nil
+			}
+			return "", nil
+		},
+	}
+	svc := NewService(provider, allowList, &testutil.MockAliasGenerator{}, nil)
+
+	if got, err := svc.AllowProject("/work/app/src"); err != nil || got != projectFile {
+		t.Errorf("AllowProject() = %q, %v, want %q", got, err, projectFile)
+	}
+	if got, err := svc.DenyProject("/work/app"); err != nil || got != projectFile {
+		t.Errorf("DenyProject() = %q, %v, want %q", got, err, projectFile)
+	}
+	if !reflect.DeepEqual(allowed, []string{projectFile}) || !reflect.DeepEqual(denied, []string{projectFile}) {
+		t.Errorf("allowed %v and denied %v, want %s once each", allowed, denied, projectFile)
+	}
+
+	if _, err := svc.AllowProject("/tmp"); err == nil || !strings.Contains(err.Error(), "no project alias file found") {
+		t.Errorf("AllowProject() without a project file error = %v, want no project alias file found", err)
+	}
+
+	allowList.AllowFunc = func(string) error { return errors.New("read-only file system") }
+	if _, err := svc.AllowProject("/work/app"); err == nil || !strings.Contains(err.Error(), "read-only file system") {
+		t.Errorf("AllowProject() error = %v, want the allow list error", err)
+	}
+}
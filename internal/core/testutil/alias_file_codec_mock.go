@@ -0,0 +1,33 @@
+package testutil
+
+import (
+	"errors"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MockAliasFileCodec is a mock implementation of ports.AliasFileCodec.
+type MockAliasFileCodec struct {
+	EncodeFunc func(aliases []alias.Alias, format string) ([]byte, error)
+	DecodeFunc func(data []byte, format string) ([]alias.Alias, error)
+}
+
+// Encode implements the ports.AliasFileCodec interface.
+func (m *MockAliasFileCodec) Encode(aliases []alias.Alias, format string) ([]byte, error) {
+	if m.EncodeFunc != nil {
+		return m.EncodeFunc(aliases, format)
+	}
+	return nil, errors.New("MockAliasFileCodec: EncodeFunc not implemented")
+}
+
+// Decode implements the ports.AliasFileCodec interface.
+func (m *MockAliasFileCodec) Decode(data []byte, format string) ([]alias.Alias, error) {
+	if m.DecodeFunc != nil {
+		return m.DecodeFunc(data, format)
+	}
+	return nil, errors.New("MockAliasFileCodec: DecodeFunc not implemented")
+}
+
+// Ensure MockAliasFileCodec implements the ports.AliasFileCodec interface.
+var _ ports.AliasFileCodec = (*MockAliasFileCodec)(nil)
@@ -0,0 +1,68 @@
+package testutil
+
+import (
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MockAliasSyncStore is a mock implementation of ports.AliasSyncStore.
+// Without the XxxFunc fields, it behaves as an empty store that accepts every change.
+type MockAliasSyncStore struct {
+	PullFunc        func() error
+	LoadAliasesFunc func() ([]alias.Alias, error)
+	SaveAliasesFunc func(aliases []alias.Alias) error
+	LoadBaseFunc    func() ([]alias.Alias, bool, error)
+	SaveBaseFunc    func(aliases []alias.Alias) error
+	PublishFunc     func(message string) (bool, bool, error)
+}
+
+// Pull implements the ports.AliasSyncStore interface.
+func (m *MockAliasSyncStore) Pull() error {
+	if m.PullFunc != nil {
+		return m.PullFunc()
+	}
+	return nil
+}
+
+// LoadAliases implements the ports.AliasSyncStore interface.
+func (m *MockAliasSyncStore) LoadAliases() ([]alias.Alias, error) {
+	if m.LoadAliasesFunc != nil {
+		return m.LoadAliasesFunc()
+	}
+	return nil, nil
+}
+
+// SaveAliases implements the ports.AliasSyncStore interface.
+func (m *MockAliasSyncStore) SaveAliases(aliases []alias.Alias) error {
+	if m.SaveAliasesFunc != nil {
+		return m.SaveAliasesFunc(aliases)
+	}
+	return nil
+}
+
+// LoadBase implements the ports.AliasSyncStore interface.
+func (m *MockAliasSyncStore) LoadBase() ([]alias.Alias, bool, error) {
+	if m.LoadBaseFunc != nil {
+		return m.LoadBaseFunc()
+	}
+	return nil, false, nil
+}
+
+// SaveBase implements the ports.AliasSyncStore interface.
+func (m *MockAliasSyncStore) SaveBase(aliases []alias.Alias) error {
+	if m.SaveBaseFunc != nil {
+		return m.SaveBaseFunc(aliases)
+	}
+	return nil
+}
+
+// Publish implements the ports.AliasSyncStore interface.
+func (m *MockAliasSyncStore) Publish(message string) (bool, bool, error) {
+	if m.PublishFunc != nil {
+		return m.PublishFunc(message)
+	}
+	return false, false, nil
+}
+
+// Ensure MockAliasSyncStore implements the ports.AliasSyncStore interface.
+var _ ports.AliasSyncStore = (*MockAliasSyncStore)(nil)
@@ -0,0 +1,23 @@
+package testutil
+
+import (
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MockAliasValidator is a mock implementation of the ports.AliasValidator interface.
+type MockAliasValidator struct {
+	ValidateFunc func(definitions []alias.Definition) []alias.Issue
+}
+
+// Validate mocks the Validate method.
+func (m *MockAliasValidator) Validate(definitions []alias.Definition) []alias.Issue {
+	if m.ValidateFunc != nil {
+		return m.ValidateFunc(definitions)
+	}
+	// Default behavior: no issues.
+	return nil
+}
+
+// Ensure MockAliasValidator implements the ports.AliasValidator interface.
+var _ ports.AliasValidator = (*MockAliasValidator)(nil)
@@ -0,0 +1,39 @@
+package testutil
+
+import "github.com/AntonioJCosta/nicksh/internal/core/ports"
+
+// MockCommandResolver is a mock implementation of ports.CommandResolver.
+type MockCommandResolver struct {
+	IsResolvableFunc  func(name string) bool
+	KnownCommandsFunc func() []string
+	LocateFunc        func(name string) (string, bool)
+}
+
+// IsResolvable mocks the IsResolvable method.
+func (m *MockCommandResolver) IsResolvable(name string) bool {
+	if m.IsResolvableFunc != nil {
+		return m.IsResolvableFunc(name)
+	}
+	// Default behavior: every command is resolvable.
+	return true
+}
+
+// KnownCommands mocks the KnownCommands method.
+func (m *MockCommandResolver) KnownCommands() []string {
+	if m.KnownCommandsFunc != nil {
+		return m.KnownCommandsFunc()
+	}
+	return nil
+}
+
+// Locate mocks the Locate method.
+func (m *MockCommandResolver) Locate(name string) (string, bool) {
+	if m.LocateFunc != nil {
+		return m.LocateFunc(name)
+	}
+	// Default behavior: nothing is on PATH or a builtin.
+	return "", false
+}
+
+// Ensure MockCommandResolver implements the ports.CommandResolver interface.
+var _ ports.CommandResolver = (*MockCommandResolver)(nil)
@@ -7,9 +7,12 @@ import (
 
 // MockHistoryProvider is a mock implementation of the ports.HistoryProvider interface.
 type MockHistoryProvider struct {
-	GetCommandFrequenciesFunc func(scanLimit int, outputLimit int) ([]history.CommandFrequency, error)
-	GetHistoryFilePathFunc    func() string
-	GetSourceIdentifierFunc   func() string
+	GetCommandFrequenciesFunc      func(scanLimit int, outputLimit int) ([]history.CommandFrequency, error)
+	GetCommandFrequenciesInDirFunc func(dir string, scanLimit int, outputLimit int) ([]history.CommandFrequency, error)
+	GetEntriesFunc                 func(scanLimit int) ([]history.Entry, error)
+	ExplainExclusionsFunc          func(scanLimit int) (history.ExclusionReport, error)
+	GetHistoryFilePathFunc         func() string
+	GetSourceIdentifierFunc        func() string
 }
 
 // GetCommandFrequencies mocks the GetCommandFrequencies method.
@@ -21,6 +24,30 @@ func (m *MockHistoryProvider) GetCommandFrequencies(scanLimit int, outputLimit i
 	return nil, nil
 }
 
+// GetCommandFrequenciesInDir mocks the GetCommandFrequenciesInDir method.
+func (m *MockHistoryProvider) GetCommandFrequenciesInDir(dir string, scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
+	if m.GetCommandFrequenciesInDirFunc != nil {
+		return m.GetCommandFrequenciesInDirFunc(dir, scanLimit, outputLimit)
+	}
+	return nil, nil
+}
+
+// GetEntries mocks the GetEntries method.
+func (m *MockHistoryProvider) GetEntries(scanLimit int) ([]history.Entry, error) {
+	if m.GetEntriesFunc != nil {
+		return m.GetEntriesFunc(scanLimit)
+	}
+	return nil, nil
+}
+
+// ExplainExclusions mocks the ExplainExclusions method.
+func (m *MockHistoryProvider) ExplainExclusions(scanLimit int) (history.ExclusionReport, error) {
+	if m.ExplainExclusionsFunc != nil {
+		return m.ExplainExclusionsFunc(scanLimit)
+	}
+	return history.ExclusionReport{}, nil
+}
+
 // GetHistoryFilePath mocks the GetHistoryFilePath method.
 func (m *MockHistoryProvider) GetHistoryFilePath() string {
 	if m.GetHistoryFilePathFunc != nil {
@@ -0,0 +1,48 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MockLogger is a mock implementation of ports.Logger that records every message.
+// Each entry is the level, the message and the key-value pairs, e.g. "WARN msg key=value".
+type MockLogger struct {
+	Entries []string
+}
+
+func (m *MockLogger) record(level, msg string, args []any) {
+	entry := level + " " + msg
+	for i := 0; i+1 < len(args); i += 2 {
+		entry += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	m.Entries = append(m.Entries, entry)
+}
+
+// EntriesAt returns the entries recorded at level, e.g. "WARN".
+func (m *MockLogger) EntriesAt(level string) []string {
+	var entries []string
+	for _, entry := range m.Entries {
+		if strings.HasPrefix(entry, level+" ") {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Debug implements the ports.Logger interface.
+func (m *MockLogger) Debug(msg string, args ...any) { m.record("DEBUG", msg, args) }
+
+// Info implements the ports.Logger interface.
+func (m *MockLogger) Info(msg string, args ...any) { m.record("INFO", msg, args) }
+
+// Warn implements the ports.Logger interface.
+func (m *MockLogger) Warn(msg string, args ...any) { m.record("WARN", msg, args) }
+
+// Error implements the ports.Logger interface.
+func (m *MockLogger) Error(msg string, args ...any) { m.record("ERROR", msg, args) }
+
+// Ensure MockLogger implements the ports.Logger interface.
+var _ ports.Logger = (*MockLogger)(nil)
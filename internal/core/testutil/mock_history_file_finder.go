@@ -4,7 +4,8 @@ import "github.com/AntonioJCosta/nicksh/internal/core/ports"
 
 // MockHistoryFileFinder is a mock implementation of ports.HistoryFileFinder.
 type MockHistoryFileFinder struct {
-	FindFunc func() (string, error)
+	FindFunc    func() (string, error)
+	FindAllFunc func() ([]string, error)
 }
 
 // Find mocks the Find method.
@@ -15,4 +16,12 @@ func (m *MockHistoryFileFinder) Find() (string, error) {
 	return "", nil // Default behavior
 }
 
+// FindAll mocks the FindAll method.
+func (m *MockHistoryFileFinder) FindAll() ([]string, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc()
+	}
+	return nil, nil // Default behavior
+}
+
 var _ ports.HistoryFileFinder = (*MockHistoryFileFinder)(nil)
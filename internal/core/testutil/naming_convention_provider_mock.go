@@ -0,0 +1,37 @@
+package testutil
+
+import "github.com/AntonioJCosta/nicksh/internal/core/ports"
+
+// MockNamingConventionProvider is a mock implementation of ports.NamingConventionProvider.
+type MockNamingConventionProvider struct {
+	GetConventionalNamesFunc func() (map[string]string, error)
+	AvailableToolsFunc       func() []string
+	DisableToolsFunc         func(tools []string) error
+}
+
+// GetConventionalNames mocks the GetConventionalNames method.
+func (m *MockNamingConventionProvider) GetConventionalNames() (map[string]string, error) {
+	if m.GetConventionalNamesFunc != nil {
+		return m.GetConventionalNamesFunc()
+	}
+	return map[string]string{}, nil
+}
+
+// AvailableTools mocks the AvailableTools method.
+func (m *MockNamingConventionProvider) AvailableTools() []string {
+	if m.AvailableToolsFunc != nil {
+		return m.AvailableToolsFunc()
+	}
+	return nil
+}
+
+// DisableTools mocks the DisableTools method.
+func (m *MockNamingConventionProvider) DisableTools(tools []string) error {
+	if m.DisableToolsFunc != nil {
+		return m.DisableToolsFunc(tools)
+	}
+	return nil
+}
+
+// Ensure MockNamingConventionProvider implements the ports.NamingConventionProvider interface.
+var _ ports.NamingConventionProvider = (*MockNamingConventionProvider)(nil)
@@ -0,0 +1,30 @@
+package testutil
+
+import (
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MockProjectAliasProvider is a mock implementation of ports.ProjectAliasProvider.
+type MockProjectAliasProvider struct {
+	FindProjectFileFunc   func(dir string) (string, error)
+	GetProjectAliasesFunc func(projectFile string) ([]alias.Alias, error)
+}
+
+// FindProjectFile mocks the FindProjectFile method.
+func (m *MockProjectAliasProvider) FindProjectFile(dir string) (string, error) {
+	if m.FindProjectFileFunc != nil {
+		return m.FindProjectFileFunc(dir)
+	}
+	return "", nil // Default behavior: no project file.
+}
+
+// GetProjectAliases mocks the GetProjectAliases method.
+func (m *MockProjectAliasProvider) GetProjectAliases(projectFile string) ([]alias.Alias, error) {
+	if m.GetProjectAliasesFunc != nil {
+		return m.GetProjectAliasesFunc(projectFile)
+	}
+	return nil, nil
+}
+
+var _ ports.ProjectAliasProvider = (*MockProjectAliasProvider)(nil)
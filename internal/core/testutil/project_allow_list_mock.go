@@ -0,0 +1,36 @@
+package testutil
+
+import "github.com/AntonioJCosta/nicksh/internal/core/ports"
+
+// MockProjectAllowList is a mock implementation of ports.ProjectAllowList.
+type MockProjectAllowList struct {
+	AllowFunc     func(projectFile string) error
+	DenyFunc      func(projectFile string) error
+	IsAllowedFunc func(projectFile string) (bool, error)
+}
+
+// Allow mocks the Allow method.
+func (m *MockProjectAllowList) Allow(projectFile string) error {
+	if m.AllowFunc != nil {
+		return m.AllowFunc(projectFile)
+	}
+	return nil
+}
+
+// Deny mocks the Deny method.
+func (m *MockProjectAllowList) Deny(projectFile string) error {
+	if m.DenyFunc != nil {
+		return m.DenyFunc(projectFile)
+	}
+	return nil
+}
+
+// IsAllowed mocks the IsAllowed method.
+func (m *MockProjectAllowList) IsAllowed(projectFile string) (bool, error) {
+	if m.IsAllowedFunc != nil {
+		return m.IsAllowedFunc(projectFile)
+	}
+	return true, nil // Default behavior: every project file is allowed.
+}
+
+var _ ports.ProjectAllowList = (*MockProjectAllowList)(nil)
@@ -0,0 +1,30 @@
+package testutil
+
+import "github.com/AntonioJCosta/nicksh/internal/core/ports"
+
+// MockSecretDetector is a mock implementation of the ports.SecretDetector interface.
+type MockSecretDetector struct {
+	DetectSecretsFunc func(command string) []string
+	RedactFunc        func(command string) string
+}
+
+// DetectSecrets mocks the DetectSecrets method.
+func (m *MockSecretDetector) DetectSecrets(command string) []string {
+	if m.DetectSecretsFunc != nil {
+		return m.DetectSecretsFunc(command)
+	}
+	// Default behavior: no secrets.
+	return nil
+}
+
+// Redact mocks the Redact method.
+func (m *MockSecretDetector) Redact(command string) string {
+	if m.RedactFunc != nil {
+		return m.RedactFunc(command)
+	}
+	// Default behavior: nothing to redact.
+	return command
+}
+
+// Ensure MockSecretDetector implements the ports.SecretDetector interface.
+var _ ports.SecretDetector = (*MockSecretDetector)(nil)
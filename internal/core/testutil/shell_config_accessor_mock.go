@@ -2,15 +2,29 @@ package testutil
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 )
 
 // MockShellConfigAccessor is a mock implementation of ports.ShellConfigAccessor for testing.
 type MockShellConfigAccessor struct {
-	GetExistingAliasesFunc func() (map[string]string, error)
-	AddAliasFunc           func(newAlias alias.Alias) (bool, error)
-	GetConfigPathFunc      func() (string, error)
+	GetExistingAliasesFunc         func() (map[string]string, error)
+	GetAliasDefinitionsFunc        func() ([]alias.Definition, error)
+	GetStartupAliasDefinitionsFunc func() ([]alias.Definition, error)
+	SupportsKindFunc               func(kind string) bool
+	AliasLoaderFunc                func() string
+	StartupFileFunc                func() (string, string)
+	QuoteStringFunc                func(shell, s string) (string, error)
+	FormatDefinitionFunc           func(a alias.Alias) string
+	UnloadCommandFunc              func(name string) string
+	AddAliasFunc                   func(newAlias alias.Alias) (ports.AddAliasResult, error)
+	MoveAliasFunc                  func(name, targetGroup string) error
+	ReplaceAliasFunc               func(newAlias alias.Alias) error
+	RemoveAliasFunc                func(name string) error
+	GetConfigPathFunc              func() (string, error)
 }
 
 func (m *MockShellConfigAccessor) GetExistingAliases() (map[string]string, error) {
@@ -20,11 +34,88 @@ func (m *MockShellConfigAccessor) GetExistingAliases() (map[string]string, error
 	return nil, errors.New("MockShellConfigAccessor: GetExistingAliasesFunc not implemented")
 }
 
-func (m *MockShellConfigAccessor) AddAlias(newAlias alias.Alias) (bool, error) {
+func (m *MockShellConfigAccessor) GetAliasDefinitions() ([]alias.Definition, error) {
+	if m.GetAliasDefinitionsFunc != nil {
+		return m.GetAliasDefinitionsFunc()
+	}
+	return nil, errors.New("MockShellConfigAccessor: GetAliasDefinitionsFunc not implemented")
+}
+
+func (m *MockShellConfigAccessor) GetStartupAliasDefinitions() ([]alias.Definition, error) {
+	if m.GetStartupAliasDefinitionsFunc != nil {
+		return m.GetStartupAliasDefinitionsFunc()
+	}
+	return nil, nil // Default behavior: no aliases in the startup files.
+}
+
+func (m *MockShellConfigAccessor) SupportsKind(kind string) bool {
+	if m.SupportsKindFunc != nil {
+		return m.SupportsKindFunc(kind)
+	}
+	return kind == alias.KindRegular // Default behavior: a shell without global and suffix aliases.
+}
+
+func (m *MockShellConfigAccessor) AliasLoader() string {
+	if m.AliasLoaderFunc != nil {
+		return m.AliasLoaderFunc()
+	}
+	return `for file in "$HOME/.nicksh"/*; do source "$file"; done` // Default behavior: a POSIX shell.
+}
+
+func (m *MockShellConfigAccessor) StartupFile() (string, string) {
+	if m.StartupFileFunc != nil {
+		return m.StartupFileFunc()
+	}
+	return "~/.bashrc", "source ~/.bashrc" // Default behavior: bash.
+}
+
+func (m *MockShellConfigAccessor) QuoteString(shell, s string) (string, error) {
+	if m.QuoteStringFunc != nil {
+		return m.QuoteStringFunc(shell, s)
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'", nil // Default behavior: a POSIX shell.
+}
+
+func (m *MockShellConfigAccessor) FormatDefinition(a alias.Alias) string {
+	if m.FormatDefinitionFunc != nil {
+		return m.FormatDefinitionFunc(a)
+	}
+	return fmt.Sprintf("alias %s='%s'\n", a.Name, a.Command) // Default behavior: a POSIX shell.
+}
+
+func (m *MockShellConfigAccessor) UnloadCommand(name string) string {
+	if m.UnloadCommandFunc != nil {
+		return m.UnloadCommandFunc(name)
+	}
+	return "unalias " + name // Default behavior: a POSIX shell.
+}
+
+func (m *MockShellConfigAccessor) AddAlias(newAlias alias.Alias) (ports.AddAliasResult, error) {
 	if m.AddAliasFunc != nil {
 		return m.AddAliasFunc(newAlias)
 	}
-	return false, errors.New("MockShellConfigAccessor: AddAliasFunc not implemented")
+	return ports.AddAliasResult{}, errors.New("MockShellConfigAccessor: AddAliasFunc not implemented")
+}
+
+func (m *MockShellConfigAccessor) MoveAlias(name, targetGroup string) error {
+	if m.MoveAliasFunc != nil {
+		return m.MoveAliasFunc(name, targetGroup)
+	}
+	return errors.New("MockShellConfigAccessor: MoveAliasFunc not implemented")
+}
+
+func (m *MockShellConfigAccessor) ReplaceAlias(newAlias alias.Alias) error {
+	if m.ReplaceAliasFunc != nil {
+		return m.ReplaceAliasFunc(newAlias)
+	}
+	return errors.New("MockShellConfigAccessor: ReplaceAliasFunc not implemented")
+}
+
+func (m *MockShellConfigAccessor) RemoveAlias(name string) error {
+	if m.RemoveAliasFunc != nil {
+		return m.RemoveAliasFunc(name)
+	}
+	return errors.New("MockShellConfigAccessor: RemoveAliasFunc not implemented")
 }
 
 func (m *MockShellConfigAccessor) GetConfigPath() (string, error) {
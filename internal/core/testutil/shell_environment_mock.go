@@ -0,0 +1,47 @@
+package testutil
+
+import (
+	"errors"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// MockShellEnvironment is a mock implementation of ports.ShellEnvironment.
+// Env holds the environment variables, Executables the commands on PATH (name to path)
+// and RCFilesByShell the startup files of each shell.
+type MockShellEnvironment struct {
+	ShellName      string
+	Env            map[string]string
+	Executables    map[string]string
+	RCFilesByShell map[string][]ports.RCFile
+	RCFilesErr     error
+}
+
+// Shell mocks the Shell method.
+func (m *MockShellEnvironment) Shell() string {
+	return m.ShellName
+}
+
+// LookupEnv mocks the LookupEnv method.
+func (m *MockShellEnvironment) LookupEnv(key string) (string, bool) {
+	value, ok := m.Env[key]
+	return value, ok
+}
+
+// LookPath mocks the LookPath method.
+func (m *MockShellEnvironment) LookPath(name string) (string, error) {
+	if path, ok := m.Executables[name]; ok {
+		return path, nil
+	}
+	return "", errors.New("MockShellEnvironment: executable not found")
+}
+
+// RCFiles mocks the RCFiles method.
+func (m *MockShellEnvironment) RCFiles(shell string) ([]ports.RCFile, error) {
+	if m.RCFilesErr != nil {
+		return nil, m.RCFilesErr
+	}
+	return m.RCFilesByShell[shell], nil
+}
+
+var _ ports.ShellEnvironment = (*MockShellEnvironment)(nil)
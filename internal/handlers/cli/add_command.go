@@ -7,6 +7,7 @@ import (
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/tui"
 	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +21,9 @@ func NewAddCommand(
 		Use:   "add",
 		Short: "Interactively add suggested aliases to your shell configuration.",
 		Long: `Shows alias suggestions and allows you to select which ones to add.
-Uses fzf for selection if available, otherwise falls back to numeric input.`,
+In a terminal, suggestions are reviewed in an interactive UI where they can also be
+renamed and edited. Otherwise (or with --no-tui), uses fzf for selection if available,
+and falls back to numeric input.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAddCmd(cmd, args, aliasSuggestionService, aliasManagementService)
 		},
@@ -29,6 +32,8 @@ Uses fzf for selection if available, otherwise falls back to numeric input.`,
 	cmd.Flags().IntP("min-frequency", "f", 0, "Minimum frequency for a command to be considered for an alias (default 3).")
 	cmd.Flags().IntP("scan-limit", "s", 0, "Number of recent history entries to scan (default 500).")
 	cmd.Flags().IntP("output-limit", "o", 0, "Maximum number of alias suggestions to show (default 10).")
+	cmd.Flags().String("into", "", "Alias group (file in $HOME/.nicksh/) to write the selected aliases to (default generated_aliases).")
+	cmd.Flags().Bool("no-tui", false, "Select with fzf or numeric input instead of the interactive review UI.")
 
 	return cmd
 }
@@ -53,51 +58,69 @@ func runAddCmd(
 
 	if len(suggestionResult.Suggestions) == 0 {
 		fmt.Println(ui.InfoColor("No alias suggestions found to add with the current criteria."))
+		printWithheldSuggestions(suggestionResult.Withheld)
 		if suggestionResult.SourceDetails != "" {
 			fmt.Println(ui.DetailColor(fmt.Sprintf("Context: %s", suggestionResult.SourceDetails)))
 		}
 		return nil
 	}
 	fmt.Println(ui.InfoColor(fmt.Sprintf("Found %d suggestions. (Source: %s)", len(suggestionResult.Suggestions), ui.DetailColor(suggestionResult.SourceDetails))))
-
-	var finalSelectedAliases []alias.Alias
-	var selectionErr error
-
-	fzfSelected, fzfErr := selectAliasesViaFZF(suggestionResult.Suggestions)
-
-	if fzfErr == nil {
-		finalSelectedAliases = fzfSelected
-		if len(finalSelectedAliases) == 0 && len(suggestionResult.Suggestions) > 0 {
-			fmt.Println(ui.InfoColor("No aliases selected via fzf."))
+	printWithheldSuggestions(suggestionResult.Withheld)
+
+	// List shortcuts first, so typo corrections show up as a group of their own.
+	shortcuts, corrections := splitCorrections(suggestionResult.Suggestions)
+	suggestionResult.Suggestions = append(shortcuts, corrections...)
+
+	if !flags.noTUI && isInteractiveTerminal() {
+		reviewed, reviewErr := tui.Review(tui.ReviewOptions{
+			Suggestions:      suggestionResult.Suggestions,
+			ValidateName:     aliasSuggestionService.ValidateAliasName,
+			TargetFile:       groupFileDisplayPath(flags.group),
+			FormatDefinition: aliasManagementService.FormatDefinition,
+		})
+		switch {
+		case errors.Is(reviewErr, tui.ErrReviewCancelled):
+			fmt.Println(ui.InfoColor("Review cancelled. No aliases will be added."))
+			return nil
+		case reviewErr != nil:
+			fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error during review: %v. Falling back to fzf or numeric selection.", reviewErr)))
+		default:
+			return addReviewedAliases(reviewed, flags.group, aliasManagementService)
 		}
-	} else if errors.Is(fzfErr, ErrFZFNotFound) {
-		fmt.Println(ui.WarningColor("fzf not found in PATH. Falling back to numeric selection."))
-		finalSelectedAliases, selectionErr = selectAliasesNumerically(suggestionResult.Suggestions)
-	} else if errors.Is(fzfErr, ErrFZFCancelled) {
-		fmt.Println(ui.InfoColor("Selection cancelled via fzf. No aliases will be added."))
-	} else {
-		fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error during fzf selection: %v. Falling back to numeric selection.", fzfErr)))
-		finalSelectedAliases, selectionErr = selectAliasesNumerically(suggestionResult.Suggestions)
 	}
 
+	finalSelectedAliases, selectionErr := selectAliases(suggestionResult.Suggestions, aliasSuggestionService.ValidateAliasName)
+	if errors.Is(selectionErr, ErrFZFCancelled) {
+		fmt.Println(ui.InfoColor("Selection cancelled via fzf. No aliases will be added."))
+		return nil
+	}
 	if selectionErr != nil {
 		fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error during alias selection: %v", selectionErr)))
 		return nil
 	}
 
-	if len(finalSelectedAliases) == 0 {
+	return addReviewedAliases(finalSelectedAliases, flags.group, aliasManagementService)
+}
+
+// addReviewedAliases writes the aliases the user selected and reports the outcome.
+func addReviewedAliases(
+	selectedAliases []alias.Alias,
+	group string,
+	aliasManagementService ports.AliasManagementService,
+) error {
+	if len(selectedAliases) == 0 {
 		return nil
 	}
 
-	fmt.Println(ui.InfoColor(fmt.Sprintf("\nYou have selected %d alias(es) to add.", len(finalSelectedAliases))))
+	fmt.Println(ui.InfoColor(fmt.Sprintf("\nYou have selected %d alias(es) to add.", len(selectedAliases))))
 
-	successfullyAddedCount, skippedDueToExistingCount, addOutcomeErr := addAliasesToConfigAndPrintOutcome(finalSelectedAliases, aliasManagementService)
+	successfullyAddedCount, skippedDueToExistingCount, addOutcomeErr := addAliasesToConfigAndPrintOutcome(selectedAliases, group, aliasManagementService)
 
 	if addOutcomeErr != nil {
 		return fmt.Errorf("encountered an error while processing aliases (added: %d, skipped: %d): %w", successfullyAddedCount, skippedDueToExistingCount, addOutcomeErr)
 	}
 
-	if successfullyAddedCount == 0 && skippedDueToExistingCount == 0 && len(finalSelectedAliases) > 0 {
+	if successfullyAddedCount == 0 && skippedDueToExistingCount == 0 {
 		fmt.Println(ui.WarningColor("\nNo aliases were successfully added or skipped from your selection (check for errors printed above)."))
 	}
 
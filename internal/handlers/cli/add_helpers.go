@@ -2,36 +2,33 @@ package cli
 
 import (
 	"bufio"
-	"bytes"
-	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
-// ErrFZFNotFound indicates that the fzf binary was not found in PATH.
-var ErrFZFNotFound = errors.New("fzf binary not found in PATH")
-
-// ErrFZFCancelled indicates that the user cancelled the fzf selection (e.g., by pressing Esc or Ctrl-C).
-var ErrFZFCancelled = errors.New("fzf selection cancelled by user")
-
 type addCommandFlags struct {
 	minFrequency int
 	scanLimit    int
 	outputLimit  int
+	group        string
+	noTUI        bool
 }
 
 func parseAddCommandFlags(cmd *cobra.Command) addCommandFlags {
 	minFreq, _ := cmd.Flags().GetInt("min-frequency")
 	scanLim, _ := cmd.Flags().GetInt("scan-limit")
 	outLim, _ := cmd.Flags().GetInt("output-limit")
+	group, _ := cmd.Flags().GetString("into")
+	noTUI, _ := cmd.Flags().GetBool("no-tui")
 
 	// Default values if not provided or zero
 	if minFreq == 0 {
@@ -48,79 +45,34 @@ func parseAddCommandFlags(cmd *cobra.Command) addCommandFlags {
 		minFrequency: minFreq,
 		scanLimit:    scanLim,
 		outputLimit:  outLim,
+		group:        group,
+		noTUI:        noTUI,
 	}
 }
 
-func selectAliasesViaFZF(suggestions []alias.Alias) ([]alias.Alias, error) {
-	fzfPath, err := exec.LookPath("fzf")
-	if err != nil {
-		return nil, ErrFZFNotFound
-	}
-
-	if len(suggestions) == 0 {
-		return []alias.Alias{}, nil
-	}
-
-	var inputBuffer bytes.Buffer
-	suggestionMap := make(map[string]alias.Alias)
-
-	for _, s := range suggestions {
-		// Feed raw alias strings to fzf for reliable mapping of selections.
-		rawLine := fmt.Sprintf("alias %s='%s'", s.Name, s.Command)
-		suggestionMap[rawLine] = s
-		inputBuffer.WriteString(rawLine + "\n")
-	}
-
-	// The --ansi flag allows fzf to render ANSI codes if present in the prompt or preview.
-	fzfCmd := exec.Command(fzfPath, "--multi", "--ansi", "--prompt", ui.PromptColor("Select aliases (TAB to multi-select, Enter to confirm) > "))
-	fzfCmd.Stdin = &inputBuffer
-
-	var outBuffer bytes.Buffer
-	var errBuffer bytes.Buffer
-	fzfCmd.Stdout = &outBuffer
-	fzfCmd.Stderr = &errBuffer
-
-	err = fzfCmd.Run()
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			// Exit code 130 indicates user cancellation (e.g., Ctrl-C, Esc).
-			if exitErr.ExitCode() == 130 {
-				return nil, ErrFZFCancelled
-			}
-			// Exit code 1 with no output often means no selection was made.
-			if exitErr.ExitCode() == 1 && strings.TrimSpace(outBuffer.String()) == "" {
-				return []alias.Alias{}, nil
-			}
-		}
-		return nil, fmt.Errorf("fzf execution failed (stderr: %s): %w", strings.TrimSpace(errBuffer.String()), err)
-	}
-
-	selectedLinesStr := strings.TrimSpace(outBuffer.String())
-	if selectedLinesStr == "" {
-		return []alias.Alias{}, nil
-	}
+// isInteractiveTerminal reports whether both stdin and stdout are terminals,
+// which the interactive review UI needs.
+func isInteractiveTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
 
-	selectedLines := strings.Split(selectedLinesStr, "\n")
-	var chosenAliases []alias.Alias
-	for _, line := range selectedLines {
-		trimmedLine := strings.TrimSpace(line)
-		if selectedAlias, ok := suggestionMap[trimmedLine]; ok { // Map uses raw lines
-			chosenAliases = append(chosenAliases, selectedAlias)
-		} else if trimmedLine != "" {
-			// This might occur if fzf's output format changes unexpectedly.
-			fmt.Fprintln(os.Stderr, ui.WarningColor(fmt.Sprintf("Warning: fzf selected an unknown line: %s", trimmedLine)))
-		}
+// groupFileDisplayPath returns the alias file of group, for display.
+func groupFileDisplayPath(group string) string {
+	if group == "" {
+		group = "generated_aliases"
 	}
-
-	return chosenAliases, nil
+	return "$HOME/.nicksh/" + group
 }
 
 func displaySuggestionsForNumericSelection(suggestions []alias.Alias) {
-	fmt.Println(ui.PromptColor("Select aliases to add (e.g., 1,3-5, or 'all', 'none'):"))
+	fmt.Println(ui.PromptColor("Select aliases (e.g., 1,3-5, or 'all', 'none'):"))
 	for i, s := range suggestions {
+		if s.IsCorrection && (i == 0 || !suggestions[i-1].IsCorrection) {
+			fmt.Println(ui.InfoColor("Typo corrections:"))
+		}
 		fmt.Printf("%d. %s %s='%s'\n",
 			i+1,
-			ui.AliasKeywordColor("alias"),
+			ui.AliasKeywordColor(aliasKeyword(s)),
 			ui.AliasNameColor(s.Name),
 			ui.AliasCmdColor(s.Command))
 	}
@@ -209,6 +161,7 @@ func selectAliasesNumerically(suggestions []alias.Alias) ([]alias.Alias, error)
 
 func addAliasesToConfigAndPrintOutcome(
 	selectedAliases []alias.Alias,
+	group string,
 	aliasManagementService ports.AliasManagementService,
 ) (successfullyAddedCount int, skippedDueToExistingCount int, firstError error) {
 
@@ -218,14 +171,15 @@ func addAliasesToConfigAndPrintOutcome(
 
 	fmt.Println(ui.InfoColor("\nProcessing selected aliases..."))
 	for _, selectedAlias := range selectedAliases {
-		wasAdded, err := aliasManagementService.AddAliasToConfig(selectedAlias.Name, selectedAlias.Command)
+		result, err := aliasManagementService.AddAliasToConfig(selectedAlias.Name, selectedAlias.Command, group, selectedAlias.Kind)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error processing alias '%s': %v", selectedAlias.Name, err)))
 			if firstError == nil {
 				firstError = err
 			}
 		} else {
-			if wasAdded {
+			printAddAliasResult(selectedAlias.Name, result)
+			if result.Added {
 				successfullyAddedCount++
 			} else {
 				skippedDueToExistingCount++
@@ -241,14 +195,7 @@ func addAliasesToConfigAndPrintOutcome(
 
 		// Instructions for the user to source the new aliases.
 		fmt.Println(ui.InfoColor("\nTo use the new alias(es):"))
-		fmt.Println(ui.InfoColor("1. Ensure the following lines are present in your shell configuration file (e.g., ~/.bashrc, ~/.zshrc):"))
-		fmt.Println(ui.CodeColor("   # Load all alias files from $HOME/.nicksh if the directory exists"))
-		fmt.Println(ui.CodeColor(`   if [ -d "$HOME/.nicksh" ]; then`))
-		fmt.Println(ui.CodeColor(`     for file in "$HOME/.nicksh"/*; do`))
-		fmt.Println(ui.CodeColor(`       [ -f "$file" ] && source "$file"`))
-		fmt.Println(ui.CodeColor("     done"))
-		fmt.Println(ui.CodeColor("   fi"))
-		fmt.Println(ui.InfoColor("\n2. Then, reload your shell configuration (e.g., 'source ~/.bashrc') or open a new terminal session."))
+		printAliasLoaderInstructions(aliasManagementService)
 
 	} else if skippedDueToExistingCount > 0 && firstError == nil {
 		fmt.Println(ui.InfoColor(fmt.Sprintf("\nNo new aliases were added. %d alias(es) from your selection already exist.", skippedDueToExistingCount)))
@@ -261,3 +208,44 @@ func addAliasesToConfigAndPrintOutcome(
 	}
 	return successfullyAddedCount, skippedDueToExistingCount, firstError
 }
+
+// printAliasLoaderInstructions tells the user how to load the alias files of $HOME/.nicksh
+// in the startup file of their shell's dialect.
+func printAliasLoaderInstructions(aliasManagementService ports.AliasManagementService) {
+	startupFile, reload := aliasManagementService.StartupFile()
+	fmt.Println(ui.InfoColor(fmt.Sprintf("1. Ensure the following lines are present in %s:", startupFile)))
+	fmt.Println(ui.CodeColor("   # Load the nicksh alias files from $HOME/.nicksh"))
+	for _, line := range strings.Split(aliasManagementService.AliasLoader(), "\n") {
+		fmt.Println(ui.CodeColor("   " + line))
+	}
+	if reload == "" {
+		fmt.Println(ui.InfoColor("\n2. Then, open a new terminal session."))
+		return
+	}
+	fmt.Println(ui.InfoColor(fmt.Sprintf("\n2. Then, reload your shell configuration ('%s') or open a new terminal session.", reload)))
+}
+
+// printAddAliasResult prints where an alias was written, or where it already exists,
+// followed by any warnings raised while reading the alias files.
+func printAddAliasResult(name string, result ports.AddAliasResult) {
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, ui.WarningColor("Warning: "+warning))
+	}
+	if result.Added {
+		fmt.Printf("Alias '%s' added to %s (line %d).\n", name, displayPath(result.File), result.Line)
+		return
+	}
+	fmt.Printf("Alias '%s' already exists in %s as '%s'. Skipping.\n", name, displayPath(result.File), result.ExistingCommand)
+}
+
+// displayPath shortens a path under the home directory to start with '~'.
+func displayPath(path string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || homeDir == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(homeDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
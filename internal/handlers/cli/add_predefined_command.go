@@ -7,7 +7,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
 	"github.com/spf13/cobra"
@@ -52,29 +51,11 @@ allows you to select which ones to add, and then adds them to your generated ali
 			fmt.Println(ui.InfoColor(fmt.Sprintf("Found %d predefined aliases. %d are valid and available for selection:", len(allLoadedAliases), len(validAliases))))
 			// Displaying aliases will be handled by fzf or numeric selection helpers
 
-			var finalSelectedAliases []alias.Alias
-			var selectionErr error
-
-			// Attempt FZF selection first
-			fzfSelected, fzfErr := selectAliasesViaFZF(validAliases) // from add_helpers.go
-
-			if fzfErr == nil {
-				finalSelectedAliases = fzfSelected
-				if len(finalSelectedAliases) == 0 && len(validAliases) > 0 {
-					fmt.Println(ui.InfoColor("No aliases selected via fzf."))
-				}
-			} else if errors.Is(fzfErr, ErrFZFNotFound) { // ErrFZFNotFound from add_helpers.go
-				fmt.Println(ui.WarningColor("fzf not found in PATH. Falling back to numeric selection."))
-				finalSelectedAliases, selectionErr = selectAliasesNumerically(validAliases) // from add_helpers.go
-			} else if errors.Is(fzfErr, ErrFZFCancelled) { // ErrFZFCancelled from add_helpers.go
+			finalSelectedAliases, selectionErr := selectAliases(validAliases, suggestionSvc.ValidateAliasName)
+			if errors.Is(selectionErr, ErrFZFCancelled) {
 				fmt.Println(ui.InfoColor("Selection cancelled via fzf. No aliases will be added."))
 				return nil // User cancelled
-			} else {
-				// Other fzf error, try numeric
-				fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error during fzf selection: %v. Falling back to numeric selection.", fzfErr)))
-				finalSelectedAliases, selectionErr = selectAliasesNumerically(validAliases) // from add_helpers.go
 			}
-
 			if selectionErr != nil {
 				fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error during alias selection: %v", selectionErr)))
 				// Decide if you want to return or just print error and not add
@@ -105,14 +86,21 @@ allows you to select which ones to add, and then adds them to your generated ali
 
 			initiallyInvalidCount := len(allLoadedAliases) - len(validAliases) // This remains the same
 			// Pass the user-selected aliases to addPredefinedToConfig
-			successfullyAddedCount, skippedDueToExistingCount, addErrorCount := addPredefinedToConfig(finalSelectedAliases, managementSvc)
+			group, _ := cmd.Flags().GetString("into")
+			successfullyAddedCount, skippedDueToExistingCount, addErrorCount, addErr := addPredefinedToConfig(finalSelectedAliases, group, managementSvc)
 
 			// Adjust printAddPredefinedOutcome if its logic depends on "all valid" vs "selected"
 			// For now, assuming it reports based on what was attempted to be added.
 			printAddPredefinedOutcome(successfullyAddedCount, skippedDueToExistingCount, initiallyInvalidCount, addErrorCount, len(allLoadedAliases), managementSvc)
 
+			if addErr != nil {
+				return fmt.Errorf("%d predefined alias(es) could not be added: %w", addErrorCount, addErr)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().String("into", "", "Alias group (file in $HOME/.nicksh/) to write the selected aliases to (default generated_aliases).")
+
 	return cmd
 }
@@ -29,19 +29,25 @@ func fetchAndFilterPredefined(suggestionSvc ports.AliasSuggestionService, curren
 	return validAliases, allLoadedAliases, nil
 }
 
-func addPredefinedToConfig(validAliases []alias.Alias, managementSvc ports.AliasManagementService) (successfullyAddedCount int, skippedDueToExistingCount int, addErrorCount int) {
+func addPredefinedToConfig(validAliases []alias.Alias, group string, managementSvc ports.AliasManagementService) (successfullyAddedCount int, skippedDueToExistingCount int, addErrorCount int, firstError error) {
 	for _, pa := range validAliases {
-		actuallyAdded, err := managementSvc.AddAliasToConfig(pa.Name, pa.Command)
+		result, err := managementSvc.AddAliasToConfig(pa.Name, pa.Command, group, pa.Kind)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error adding predefined alias '%s': %v", pa.Name, err)))
 			addErrorCount++
-		} else if actuallyAdded {
+			if firstError == nil {
+				firstError = err
+			}
+			continue
+		}
+		printAddAliasResult(pa.Name, result)
+		if result.Added {
 			successfullyAddedCount++
 		} else {
 			skippedDueToExistingCount++
 		}
 	}
-	return successfullyAddedCount, skippedDueToExistingCount, addErrorCount
+	return successfullyAddedCount, skippedDueToExistingCount, addErrorCount, firstError
 }
 
 func printAddPredefinedOutcome(
@@ -50,7 +56,7 @@ func printAddPredefinedOutcome(
 	initiallyInvalidCount int,
 	addErrorCount int,
 	totalLoadedCount int,
-	_ ports.AliasManagementService, // managementSvc is not used here, consider removing if not planned for future use.
+	managementSvc ports.AliasManagementService,
 ) {
 	if successfullyAddedCount > 0 {
 		fmt.Println(ui.SuccessColor(fmt.Sprintf("\n%d predefined alias(es) successfully written to a file in the $HOME/.nicksh/ directory.", successfullyAddedCount)))
@@ -64,14 +70,7 @@ func printAddPredefinedOutcome(
 
 		// Instructions for the user.
 		fmt.Println(ui.InfoColor("\nTo use the new alias(es):"))
-		fmt.Println(ui.InfoColor("1. Ensure the following lines are present in your shell configuration file (e.g., ~/.bashrc, ~/.zshrc):"))
-		fmt.Println(ui.CodeColor("   # Load all alias files from $HOME/.nicksh if the directory exists"))
-		fmt.Println(ui.CodeColor(`   if [ -d "$HOME/.nicksh" ]; then`))
-		fmt.Println(ui.CodeColor(`     for file in "$HOME/.nicksh"/*; do`))
-		fmt.Println(ui.CodeColor(`       [ -f "$file" ] && source "$file"`))
-		fmt.Println(ui.CodeColor("     done"))
-		fmt.Println(ui.CodeColor("   fi"))
-		fmt.Println(ui.InfoColor("\n2. Then, reload your shell configuration (e.g., 'source ~/.bashrc') or open a new terminal session."))
+		printAliasLoaderInstructions(managementSvc)
 
 	} else {
 		totalSkippedOrFailed := initiallyInvalidCount + addErrorCount + skippedDueToExistingCount
@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+)
+
+// ErrFZFNotFound indicates that the fzf binary was not found in PATH.
+var ErrFZFNotFound = errors.New("fzf binary not found in PATH")
+
+// ErrFZFCancelled indicates that the user cancelled the fzf selection (e.g., by pressing Esc or Ctrl-C).
+var ErrFZFCancelled = errors.New("fzf selection cancelled by user")
+
+// typoCorrectionMarker is appended to typo corrections in the fzf list, to tell them apart from shortcuts.
+const typoCorrectionMarker = "  # typo correction"
+
+// fzfRenameKey is the fzf key binding that renames the current (or selected) aliases.
+const fzfRenameKey = "ctrl-r"
+
+// selectAliases lets the user pick aliases from candidates with fzf, falling back to numeric
+// input when fzf is not available or fails. validateName is used to show name conflicts in
+// the preview pane and to check renamed aliases. If it is nil, the aliases cannot be renamed,
+// e.g. when picking existing aliases to remove.
+// It returns ErrFZFCancelled if the user cancelled the selection.
+func selectAliases(candidates []alias.Alias, validateName func(string) error) ([]alias.Alias, error) {
+	selected, err := selectAliasesViaFZF(candidates, validateName)
+	switch {
+	case err == nil:
+		if len(selected) == 0 && len(candidates) > 0 {
+			fmt.Println(ui.InfoColor("No aliases selected via fzf."))
+		}
+		return selected, nil
+	case errors.Is(err, ErrFZFCancelled):
+		return nil, err
+	case errors.Is(err, ErrFZFNotFound):
+		fmt.Println(ui.WarningColor("fzf not found in PATH. Falling back to numeric selection."))
+	default:
+		fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error during fzf selection: %v. Falling back to numeric selection.", err)))
+	}
+	return selectAliasesNumerically(candidates)
+}
+
+// selectAliasesViaFZF runs fzf over candidates until the user confirms a selection.
+// Each fzf line starts with a hidden ID field, so selections are mapped back to candidates by
+// index rather than by their text. Pressing fzfRenameKey asks for new names on stdin and
+// reopens fzf with the renamed aliases, or just reopens it if renaming is disabled.
+func selectAliasesViaFZF(candidates []alias.Alias, validateName func(string) error) ([]alias.Alias, error) {
+	fzfPath, err := exec.LookPath("fzf")
+	if err != nil {
+		return nil, ErrFZFNotFound
+	}
+
+	if len(candidates) == 0 {
+		return []alias.Alias{}, nil
+	}
+
+	previewDir, err := os.MkdirTemp("", "nicksh-fzf-")
+	if err != nil {
+		return nil, fmt.Errorf("creating fzf preview directory: %w", err)
+	}
+	defer os.RemoveAll(previewDir)
+
+	// Work on a copy, so renames don't leak into the caller's slice.
+	items := append([]alias.Alias(nil), candidates...)
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if err := writeFZFPreviews(previewDir, items, validateName); err != nil {
+			return nil, err
+		}
+
+		canRename := validateName != nil
+		key, ids, err := runFZF(fzfPath, previewDir, items, canRename)
+		if err != nil {
+			return nil, err
+		}
+
+		if key != fzfRenameKey {
+			chosenAliases := make([]alias.Alias, 0, len(ids))
+			for _, id := range ids {
+				chosenAliases = append(chosenAliases, items[id])
+			}
+			return chosenAliases, nil
+		}
+
+		if !canRename {
+			continue
+		}
+		for _, id := range ids {
+			if err := promptRename(reader, items, id, validateName); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// runFZF shows items in fzf and returns the key that ended the selection ("" for Enter)
+// and the IDs of the selected items. The rename key is only advertised if canRename.
+func runFZF(fzfPath, previewDir string, items []alias.Alias, canRename bool) (string, []int, error) {
+	header := "TAB: multi-select, Enter: confirm"
+	if canRename {
+		header += fmt.Sprintf(", %s: rename", strings.ToUpper(fzfRenameKey))
+	}
+	// The --ansi flag allows fzf to render ANSI codes if present in the prompt or preview.
+	fzfCmd := exec.Command(fzfPath,
+		"--multi", "--ansi",
+		"--delimiter", "\t", "--with-nth", "2..",
+		"--expect", fzfRenameKey,
+		"--header", header,
+		"--preview", "cat {1}",
+		"--preview-window", "right:50%:wrap",
+		"--prompt", ui.PromptColor("Select aliases > "),
+	)
+	fzfCmd.Dir = previewDir // The preview files are named after the IDs; fzf quotes {1} itself.
+	fzfCmd.Stdin = strings.NewReader(fzfInput(items))
+
+	var outBuffer bytes.Buffer
+	var errBuffer bytes.Buffer
+	fzfCmd.Stdout = &outBuffer
+	fzfCmd.Stderr = &errBuffer
+
+	err := fzfCmd.Run()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// Exit code 130 indicates user cancellation (e.g., Ctrl-C, Esc).
+			if exitErr.ExitCode() == 130 {
+				return "", nil, ErrFZFCancelled
+			}
+			// Exit code 1 means there was no match to select.
+			if exitErr.ExitCode() == 1 {
+				return "", nil, nil
+			}
+		}
+		return "", nil, fmt.Errorf("fzf execution failed (stderr: %s): %w", strings.TrimSpace(errBuffer.String()), err)
+	}
+
+	key, ids, unknownLines := parseFZFOutput(outBuffer.String(), len(items))
+	for _, line := range unknownLines {
+		// This might occur if fzf's output format changes unexpectedly.
+		fmt.Fprintln(os.Stderr, ui.WarningColor(fmt.Sprintf("Warning: fzf selected an unknown line: %s", line)))
+	}
+	return key, ids, nil
+}
+
+// fzfInput returns the fzf input listing items, one line per item: its ID (its index in items),
+// a tab, then the text shown, e.g. "0\talias gs='git status'". fzf only shows the text
+// (--with-nth 2..) and outputs whole lines, so selections are mapped back by ID even if
+// several items are shown alike.
+func fzfInput(items []alias.Alias) string {
+	var b strings.Builder
+	for id, s := range items {
+		line := fmt.Sprintf("%s %s='%s'", aliasKeyword(s), s.Name, s.Command)
+		if s.IsCorrection {
+			line += typoCorrectionMarker
+		}
+		// A newline would split the item, and a tab would add a field.
+		line = strings.NewReplacer("\n", " ", "\t", " ").Replace(line)
+		fmt.Fprintf(&b, "%d\t%s\n", id, line)
+	}
+	return b.String()
+}
+
+// parseFZFOutput parses the output of fzf run with --expect over the lines of fzfInput for
+// itemCount items. The first output line is the key pressed (empty for Enter), and each following
+// line a selected item. It returns the key, the IDs of the selected items and the lines not
+// starting with a known ID.
+func parseFZFOutput(output string, itemCount int) (key string, ids []int, unknownLines []string) {
+	key, selection, _ := strings.Cut(output, "\n")
+	for _, line := range strings.Split(selection, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		idField, _, _ := strings.Cut(line, "\t")
+		id, err := strconv.Atoi(idField)
+		if err != nil || id < 0 || id >= itemCount {
+			unknownLines = append(unknownLines, line)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return strings.TrimSpace(key), ids, unknownLines
+}
+
+// writeFZFPreviews writes the preview pane of each item to previewDir, in a file named after its ID.
+func writeFZFPreviews(previewDir string, items []alias.Alias, validateName func(string) error) error {
+	for id := range items {
+		previewPath := filepath.Join(previewDir, strconv.Itoa(id))
+		if err := os.WriteFile(previewPath, []byte(aliasPreview(items, id, validateName)), 0o600); err != nil {
+			return fmt.Errorf("writing fzf preview: %w", err)
+		}
+	}
+	return nil
+}
+
+// aliasPreview describes items[id] for the fzf preview pane: its frequency, some of the
+// history lines it covers and, if names are validated, any name conflicts.
+func aliasPreview(items []alias.Alias, id int, validateName func(string) error) string {
+	s := items[id]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s='%s'\n\n", aliasKeyword(s), s.Name, s.Command)
+	if s.Source != "" {
+		fmt.Fprintf(&b, "Suggested from: %s\n", s.Source)
+	}
+	if s.Frequency > 0 {
+		fmt.Fprintf(&b, "Frequency:      %d\n", s.Frequency)
+	}
+
+	if validateName != nil {
+		b.WriteString("\nConflicts:\n")
+		conflicts := nameConflicts(items, id, validateName)
+		if len(conflicts) == 0 {
+			b.WriteString("  none\n")
+		}
+		for _, conflict := range conflicts {
+			fmt.Fprintf(&b, "  %s\n", conflict)
+		}
+	}
+
+	if len(s.Examples) > 0 {
+		b.WriteString("\nFrom history:\n")
+		for _, example := range s.Examples {
+			fmt.Fprintf(&b, "  $ %s\n", example)
+		}
+	}
+	return b.String()
+}
+
+// nameConflicts lists the reasons why the name of items[id] can't be used as is.
+func nameConflicts(items []alias.Alias, id int, validateName func(string) error) []string {
+	var conflicts []string
+	name := items[id].Name
+	for otherID, other := range items {
+		if otherID != id && other.Name == name {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' is also suggested for '%s'", name, other.Command))
+		}
+	}
+	if validateName != nil {
+		if err := validateName(name); err != nil {
+			conflicts = append(conflicts, err.Error())
+		}
+	}
+	return conflicts
+}
+
+// promptRename asks for a new name for items[id] and applies it if it is valid.
+// An empty answer keeps the current name.
+func promptRename(reader *bufio.Reader, items []alias.Alias, id int, validateName func(string) error) error {
+	for {
+		fmt.Print(ui.PromptColor(fmt.Sprintf("New name for '%s' (%s), Enter to keep: ", items[id].Name, items[id].Command)))
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read new alias name: %w", err)
+		}
+
+		newName := strings.TrimSpace(input)
+		if newName == "" || newName == items[id].Name {
+			return nil
+		}
+
+		renamed := append([]alias.Alias(nil), items...)
+		renamed[id].Name = newName
+		if conflicts := nameConflicts(renamed, id, validateName); len(conflicts) > 0 {
+			fmt.Println(ui.WarningColor(strings.Join(conflicts, "; ")))
+			continue
+		}
+		items[id].Name = newName
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+)
+
+func TestFZFInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []alias.Alias
+		want  string
+	}{
+		{
+			name:  "IDs are the indexes of the items",
+			items: []alias.Alias{{Name: "gs", Command: "git status"}, {Name: "G", Command: "| grep", Kind: alias.KindGlobal}},
+			want:  "0\talias gs='git status'\n1\talias -g G='| grep'\n",
+		},
+		{
+			name:  "duplicate display text keeps distinct IDs",
+			items: []alias.Alias{{Name: "gs", Command: "git status"}, {Name: "gs", Command: "git status"}},
+			want:  "0\talias gs='git status'\n1\talias gs='git status'\n",
+		},
+		{
+			name:  "tabs and newlines are shown as spaces",
+			items: []alias.Alias{{Name: "a\tb", Command: "printf 'x\ty'\necho"}},
+			want:  "0\talias a b='printf 'x y' echo'\n",
+		},
+		{
+			name:  "typo corrections are marked",
+			items: []alias.Alias{{Name: "gti", Command: "git", IsCorrection: true}},
+			want:  "0\talias gti='git'" + typoCorrectionMarker + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fzfInput(tt.items); got != tt.want {
+				t.Errorf("fzfInput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFZFOutput(t *testing.T) {
+	items := []alias.Alias{
+		{Name: "gs", Command: "git status"},
+		{Name: "gs", Command: "git status"}, // Shown exactly like the first one.
+		{Name: "a\tb", Command: `echo "it's"`},
+	}
+	lines := strings.Split(strings.TrimSuffix(fzfInput(items), "\n"), "\n")
+
+	tests := []struct {
+		name        string
+		output      string
+		wantKey     string
+		wantIDs     []int
+		wantUnknown []string
+	}{
+		{
+			name:    "enter with the second of two identical lines",
+			output:  "\n" + lines[1] + "\n",
+			wantKey: "",
+			wantIDs: []int{1},
+		},
+		{
+			name:    "multiple selection with tabs and quotes",
+			output:  "\n" + lines[2] + "\n" + lines[0] + "\n",
+			wantKey: "",
+			wantIDs: []int{2, 0},
+		},
+		{
+			name:    "rename key line",
+			output:  fzfRenameKey + "\n" + lines[0] + "\n" + lines[1] + "\n",
+			wantKey: fzfRenameKey,
+			wantIDs: []int{0, 1},
+		},
+		{
+			name:    "nothing selected",
+			output:  "\n",
+			wantKey: "",
+		},
+		{
+			name:        "unknown and out of range lines",
+			output:      "\nalias gs='git status'\n3\talias x='y'\n" + lines[2] + "\n",
+			wantKey:     "",
+			wantIDs:     []int{2},
+			wantUnknown: []string{"alias gs='git status'", "3\talias x='y'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ids, unknown := parseFZFOutput(tt.output, len(items))
+			if key != tt.wantKey {
+				t.Errorf("parseFZFOutput() key = %q, want %q", key, tt.wantKey)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("parseFZFOutput() ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("parseFZFOutput() unknown lines = %q, want %q", unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
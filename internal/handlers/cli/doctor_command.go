@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// doctorReport is the JSON output of 'nicksh doctor', to attach to bug reports.
+type doctorReport struct {
+	Version string        `json:"version"`
+	Checks  []doctorCheck `json:"checks"`
+}
+
+type doctorCheck struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Detail      string `json:"detail"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// NewDoctorCommand creates the 'doctor' subcommand.
+func NewDoctorCommand(diagnosticService ports.DiagnosticService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check your setup for problems that keep nicksh from working.",
+		Long: `Runs a series of checks on your shell, its history and how the aliases in
+$HOME/.nicksh/ are loaded, to find out why suggestions come back empty or added
+aliases are not available. Each check passes, warns or fails, and tells how to
+fix what it found.
+
+Use --output json for a report to attach to bug reports. The command exits with
+an error if any check failed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctorCmd(cmd, args, diagnosticService)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "text", "Output format: text or json.")
+
+	return cmd
+}
+
+// runDoctorCmd contains the core logic for the 'doctor' command.
+func runDoctorCmd(
+	cmd *cobra.Command,
+	_ []string,
+	diagnosticService ports.DiagnosticService,
+) error {
+	output, _ := cmd.Flags().GetString("output")
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format '%s': use text or json", output)
+	}
+
+	checks := diagnosticService.RunDiagnostics()
+	if output == "json" {
+		report := doctorReport{Version: cmd.Root().Version, Checks: make([]doctorCheck, 0, len(checks))}
+		for _, check := range checks {
+			report.Checks = append(report.Checks, doctorCheck(check))
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode the report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printDiagnosticChecks(checks)
+	}
+
+	failedCount := 0
+	for _, check := range checks {
+		if check.Status == ports.DiagnosticFail {
+			failedCount++
+		}
+	}
+	if failedCount > 0 {
+		cmd.SilenceUsage = true // The checks were run: the usage would only hide the report.
+		return fmt.Errorf("%d check(s) failed", failedCount)
+	}
+	return nil
+}
+
+// printDiagnosticChecks prints each check with its status, then a summary.
+func printDiagnosticChecks(checks []ports.DiagnosticCheck) {
+	counts := make(map[string]int)
+	for _, check := range checks {
+		counts[check.Status]++
+		switch check.Status {
+		case ports.DiagnosticPass:
+			fmt.Printf("%s %s: %s\n", ui.SuccessColor("[PASS]"), check.Name, check.Detail)
+		case ports.DiagnosticWarn:
+			fmt.Printf("%s %s: %s\n", ui.WarningColor("[WARN]"), check.Name, check.Detail)
+		default:
+			fmt.Printf("%s %s: %s\n", ui.ErrorColor("[FAIL]"), check.Name, check.Detail)
+		}
+		if check.Remediation != "" {
+			// Remediations may hold a snippet to copy: keep its lines together, indented under the check.
+			fmt.Println(ui.InfoColor("       " + strings.ReplaceAll(check.Remediation, "\n", "\n       ")))
+		}
+	}
+	fmt.Println(ui.HeaderColor(fmt.Sprintf("\n%d passed, %d warning(s), %d failed.",
+		counts[ports.DiagnosticPass], counts[ports.DiagnosticWarn], counts[ports.DiagnosticFail])))
+}
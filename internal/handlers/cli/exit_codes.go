@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+)
+
+// Exit codes of nicksh, documented in the README so scripts can react to them.
+const (
+	ExitOK              = 0
+	ExitFailure         = 1 // Any error without a more specific code.
+	ExitHistoryNotFound = 2
+	ExitAliasExists     = 3
+	ExitInvalidName     = 4
+	ExitConfigWrite     = 5
+)
+
+// errorKinds maps the core's sentinel errors to their exit code and a hint for the user.
+// The first match wins, so a write failure is reported as such whatever caused it.
+var errorKinds = []struct {
+	target error
+	code   int
+	hint   string
+}{
+	{alias.ErrConfigWrite, ExitConfigWrite, "Check that $HOME/.nicksh/ and the alias files in it are writable."},
+	{history.ErrHistoryNotFound, ExitHistoryNotFound, "Set HISTFILE to your history file, or pass it with --history (e.g. --history ~/.bash_history)."},
+	{alias.ErrAliasExists, ExitAliasExists, "Choose another name, or remove the existing alias first with 'nicksh remove <name>'."},
+	{alias.ErrInvalidName, ExitInvalidName, "Alias names use letters, digits and dots, and must not shadow a command on PATH."},
+}
+
+// ExitCode returns the exit code documented for err.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.target) {
+			return kind.code
+		}
+	}
+	return ExitFailure
+}
+
+// ReportError prints the hint for err, if there is one, and returns its exit code.
+// The error itself has already been printed by cobra.
+func ReportError(err error) int {
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.target) {
+			fmt.Fprintln(os.Stderr, ui.InfoColor("Hint: "+kind.hint))
+			break
+		}
+	}
+	return ExitCode(err)
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewExpandCommand creates the 'expand' subcommand.
+func NewExpandCommand(aliasResolutionService ports.AliasResolutionService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   `expand "<command line>"`,
+		Short: "Show what a command line runs once its aliases are expanded, without running it.",
+		Long: `Performs alias expansion on a command line the way bash and zsh do, with the
+aliases of your shell startup files and of $HOME/.nicksh/: the first word of each
+command is expanded, then the first word of its expansion, and so on. An alias
+whose value ends with a space (e.g. alias sudo='sudo ') makes the next word
+expanded too. Quoted words are never expanded.
+
+Each alias replaced is listed, indented under the alias whose expansion it came
+from. Aliases that lead back to an alias being expanded are reported as loops.`,
+		Example: `  nicksh expand "gs && gp"
+  nicksh expand "sudo ll /root"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExpandCmd(cmd, args, aliasResolutionService)
+		},
+	}
+
+	return cmd
+}
+
+// runExpandCmd contains the core logic for the 'expand' command.
+func runExpandCmd(
+	_ *cobra.Command,
+	args []string,
+	aliasResolutionService ports.AliasResolutionService,
+) error {
+	// An unquoted command line comes as several arguments.
+	line := strings.Join(args, " ")
+	expansion, err := aliasResolutionService.Expand(line)
+	if err != nil {
+		return fmt.Errorf("could not expand the command line: %w", err)
+	}
+
+	fmt.Println(ui.CodeColor(expansion.Expanded))
+	if len(expansion.Steps) == 0 {
+		fmt.Println(ui.InfoColor("No alias to expand."))
+	}
+	for _, step := range expansion.Steps {
+		fmt.Printf("  %s%s -> %s\n", strings.Repeat("  ", step.Depth), step.Name, step.Command)
+	}
+	for _, loop := range expansion.Loops {
+		fmt.Println(ui.WarningColor(fmt.Sprintf("Loop: %s. The shell stops expanding at '%s'.",
+			strings.Join(loop, " -> "), loop[len(loop)-1])))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates the 'export' subcommand.
+func NewExportCommand(aliasTransferService ports.AliasTransferService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export the aliases managed by nicksh to a YAML or JSON file.",
+		Long: `Writes every alias in the $HOME/.nicksh/ directory, with its group, to a file
+(or to standard output if no file is given), to be imported with 'nicksh import'
+on another machine or shared with teammates.
+
+The file uses the same schema as predefined_aliases.yaml. The format is taken
+from --format, or from the file extension (.json for JSON, YAML otherwise).`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExportCmd(cmd, args, aliasTransferService)
+		},
+	}
+
+	cmd.Flags().StringP("format", "f", "", "Output format: yaml or json. Defaults to the file extension, or yaml.")
+
+	return cmd
+}
+
+// runExportCmd contains the core logic for the 'export' command.
+func runExportCmd(
+	cmd *cobra.Command,
+	args []string,
+	aliasTransferService ports.AliasTransferService,
+) error {
+	path := ""
+	if len(args) == 1 {
+		path = args[0]
+	}
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = aliasFileFormatFromPath(path)
+	}
+
+	data, err := aliasTransferService.ExportAliases(format)
+	if err != nil {
+		return fmt.Errorf("could not export aliases: %w", err)
+	}
+
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("could not write export file: %w", err)
+	}
+	fmt.Println(ui.SuccessColor(fmt.Sprintf("Aliases exported to %s.", path)))
+	return nil
+}
+
+// aliasFileFormatFromPath guesses the format of an alias file from its extension.
+func aliasFileFormatFromPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ports.AliasFileFormatJSON
+	}
+	return ports.AliasFileFormatYAML
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/spf13/cobra"
+)
+
+// Conflict resolutions of the 'import' command, see the --on-conflict flag.
+const (
+	conflictAsk       = "ask"
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictRename    = "rename"
+)
+
+// NewImportCommand creates the 'import' subcommand.
+func NewImportCommand(aliasTransferService ports.AliasTransferService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import aliases from a YAML or JSON file written by 'nicksh export'.",
+		Long: `Adds the aliases of an exported (or hand-written) alias file to the
+$HOME/.nicksh/ directory, each into its group.
+
+Every alias name is validated first. An alias whose name is already defined
+with another command is a conflict: you are asked whether to skip it, overwrite
+the existing alias, or import it under another name. Use --on-conflict to
+resolve every conflict the same way, e.g. in scripts.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImportCmd(cmd, args, aliasTransferService)
+		},
+	}
+
+	cmd.Flags().StringP("format", "f", "", "Input format: yaml or json. Defaults to the file extension, or yaml.")
+	cmd.Flags().String("on-conflict", conflictAsk, "How to resolve conflicts: ask, skip or overwrite. Without a terminal, ask skips them.")
+
+	return cmd
+}
+
+// runImportCmd contains the core logic for the 'import' command.
+func runImportCmd(
+	cmd *cobra.Command,
+	args []string,
+	aliasTransferService ports.AliasTransferService,
+) error {
+	path := args[0]
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = aliasFileFormatFromPath(path)
+	}
+	onConflict, _ := cmd.Flags().GetString("on-conflict")
+	switch onConflict {
+	case conflictAsk, conflictSkip, conflictOverwrite:
+	default:
+		return fmt.Errorf("invalid --on-conflict value '%s': use %s, %s or %s", onConflict, conflictAsk, conflictSkip, conflictOverwrite)
+	}
+	if onConflict == conflictAsk && !isInteractiveTerminal() {
+		onConflict = conflictSkip
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read import file: %w", err)
+	}
+	plan, err := aliasTransferService.PlanImport(data, format)
+	if err != nil {
+		return fmt.Errorf("could not import aliases: %w", err)
+	}
+	printImportPlan(plan)
+
+	var outcome importOutcome
+	for _, a := range plan.New {
+		added, importErr := aliasTransferService.ImportAlias(a, false)
+		outcome.record(a.Name, "", added, importErr)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for _, conflict := range plan.Conflicts {
+		resolution := onConflict
+		if resolution == conflictAsk {
+			if resolution, err = promptConflictResolution(reader, conflict); err != nil {
+				return err
+			}
+		}
+
+		imported := conflict.Alias
+		switch resolution {
+		case conflictSkip:
+			outcome.skipped++
+			continue
+		case conflictRename:
+			if imported.Name, err = promptImportName(reader, imported.Name, aliasTransferService.ValidateImportName); err != nil {
+				return err
+			}
+			if imported.Name == "" {
+				outcome.skipped++
+				continue
+			}
+		}
+		added, importErr := aliasTransferService.ImportAlias(imported, resolution == conflictOverwrite)
+		outcome.record(imported.Name, resolution, added, importErr)
+	}
+
+	outcome.print(len(plan.Unchanged), len(plan.Invalid))
+	if outcome.firstError != nil {
+		return fmt.Errorf("some aliases could not be imported: %w", outcome.firstError)
+	}
+	return nil
+}
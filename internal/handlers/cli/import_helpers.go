@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+)
+
+// importOutcome counts what happened to the aliases of an import.
+type importOutcome struct {
+	added       int
+	overwritten int
+	renamed     int
+	skipped     int
+	failed      int
+	firstError  error
+}
+
+// record counts the result of importing the alias name with the given conflict resolution
+// (empty for an alias that did not conflict).
+func (o *importOutcome) record(name, resolution string, added bool, err error) {
+	switch {
+	case err != nil:
+		fmt.Fprintln(os.Stderr, ui.ErrorColor(fmt.Sprintf("Error importing alias '%s': %v", name, err)))
+		o.failed++
+		if o.firstError == nil {
+			o.firstError = err
+		}
+	case !added:
+		o.skipped++
+	case resolution == conflictOverwrite:
+		o.overwritten++
+	case resolution == conflictRename:
+		o.renamed++
+	default:
+		o.added++
+	}
+}
+
+// print reports the outcome of the import.
+func (o *importOutcome) print(unchanged, invalid int) {
+	fmt.Println(ui.HeaderColor("\nImport summary:"))
+	fmt.Printf("  added: %d, overwritten: %d, renamed: %d, skipped: %d, already present: %d, invalid: %d, failed: %d\n",
+		o.added, o.overwritten, o.renamed, o.skipped, unchanged, invalid, o.failed)
+	if o.added+o.overwritten+o.renamed > 0 {
+		fmt.Println(ui.InfoColor("Reload your shell configuration (e.g., 'source ~/.bashrc') or open a new terminal session to use them."))
+	}
+}
+
+// printImportPlan lists the aliases that will not be imported as they are: invalid ones and conflicts.
+func printImportPlan(plan ports.ImportPlan) {
+	fmt.Println(ui.InfoColor(fmt.Sprintf("Found %d new alias(es), %d conflict(s) and %d alias(es) already present.",
+		len(plan.New), len(plan.Conflicts), len(plan.Unchanged))))
+	if len(plan.Invalid) > 0 {
+		fmt.Println(ui.WarningColor(fmt.Sprintf("%d alias(es) will not be imported because they are invalid (bad name, no command, or listed twice):", len(plan.Invalid))))
+		for _, a := range plan.Invalid {
+			fmt.Printf("  alias %s='%s'\n", a.Name, a.Command)
+		}
+	}
+}
+
+// promptConflictResolution asks how to resolve a conflict: skip, overwrite or rename.
+func promptConflictResolution(reader *bufio.Reader, conflict ports.ImportConflict) (string, error) {
+	fmt.Println(ui.WarningColor(fmt.Sprintf("\nAlias '%s' already exists:", conflict.Alias.Name)))
+	fmt.Printf("  current:  alias %s='%s'\n", conflict.Alias.Name, conflict.ExistingCommand)
+	fmt.Printf("  imported: alias %s='%s'\n", conflict.Alias.Name, conflict.Alias.Command)
+	for {
+		fmt.Print(ui.PromptColor("[s]kip, [o]verwrite or [r]ename the imported alias? "))
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("failed to read conflict resolution: %w", err)
+		}
+		switch strings.ToLower(strings.TrimSpace(input)) {
+		case "s", conflictSkip, "":
+			return conflictSkip, nil
+		case "o", conflictOverwrite:
+			return conflictOverwrite, nil
+		case "r", conflictRename:
+			return conflictRename, nil
+		}
+		fmt.Println(ui.WarningColor("Please answer s, o or r."))
+	}
+}
+
+// promptImportName asks for a new name for the imported alias currently named name,
+// until validateName accepts it. An empty answer returns an empty name, skipping the alias.
+func promptImportName(reader *bufio.Reader, name string, validateName func(string) error) (string, error) {
+	for {
+		fmt.Print(ui.PromptColor(fmt.Sprintf("New name for the imported '%s', Enter to skip it: ", name)))
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("failed to read new alias name: %w", err)
+		}
+		newName := strings.TrimSpace(input)
+		if newName == "" {
+			return "", nil
+		}
+		if err := validateName(newName); err != nil {
+			fmt.Println(ui.WarningColor(err.Error()))
+			continue
+		}
+		return newName, nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
 	"github.com/olekukonko/tablewriter"
@@ -15,31 +16,61 @@ func NewListCommand(aliasManagementService ports.AliasManagementService) *cobra.
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List existing aliases managed by nicksh.",
-		Long:  `Displays aliases found in the $HOME/.nicksh/ directory.`,
+		Long: `Displays aliases found in the $HOME/.nicksh/ directory.
+Use --group to only show the aliases of a single group (file in $HOME/.nicksh/).
+
+The aliases are then checked as a whole, and the problems found are listed:
+aliases that loop through other aliases or expand to their own name, aliases
+running a command that cannot be found, and aliases defined in several files.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runListCmd(cmd, args, aliasManagementService)
 		},
 	}
+
+	cmd.Flags().StringP("group", "g", "", "Only list aliases in this group (file in $HOME/.nicksh/).")
+
 	return cmd
 }
 
 // runListCmd contains the core logic for the 'list' command.
 func runListCmd(
-	_ *cobra.Command,
+	cmd *cobra.Command,
 	_ []string,
 	aliasManagementService ports.AliasManagementService,
 ) error {
-	aliases, err := aliasManagementService.ListAliases()
+	group, _ := cmd.Flags().GetString("group")
+
+	var aliases map[string]string
+	var err error
+	if group != "" {
+		aliases, err = aliasManagementService.ListAliasesInGroup(group)
+	} else {
+		aliases, err = aliasManagementService.ListAliases()
+	}
+	// Aliases defined in several files make listing fail: the issues tell where they are.
+	issues, validateErr := aliasManagementService.ValidateAliases()
 	if err != nil {
+		printAliasIssues(issues)
 		return fmt.Errorf("could not list aliases: %w", err)
 	}
+	if validateErr != nil {
+		return fmt.Errorf("could not validate aliases: %w", validateErr)
+	}
 
 	if len(aliases) == 0 {
+		if group != "" {
+			fmt.Println(ui.InfoColor(fmt.Sprintf("No aliases found in group '%s' in the $HOME/.nicksh/ directory.", group)))
+			return nil
+		}
 		fmt.Println(ui.InfoColor("No aliases found that are managed by nicksh in the $HOME/.nicksh/ directory."))
 		return nil
 	}
 
-	fmt.Println(ui.HeaderColor("Existing Aliases (managed by nicksh in $HOME/.nicksh/):"))
+	if group != "" {
+		fmt.Println(ui.HeaderColor(fmt.Sprintf("Existing Aliases in group '%s' (managed by nicksh in $HOME/.nicksh/%s):", group, group)))
+	} else {
+		fmt.Println(ui.HeaderColor("Existing Aliases (managed by nicksh in $HOME/.nicksh/):"))
+	}
 	fmt.Println(ui.WarningColor("Note: These aliases are read from files in $HOME/.nicksh/."))
 	fmt.Println(ui.WarningColor("       They reflect what nicksh manages, not necessarily your live shell's current alias state."))
 
@@ -52,5 +83,35 @@ func runListCmd(
 		table.Append([]string{name, command})
 	}
 	table.Render()
+
+	if group != "" {
+		// Only the issues of the group's aliases, though they were found among all aliases.
+		var groupIssues []alias.Issue
+		for _, issue := range issues {
+			if _, inGroup := aliases[issue.Name]; inGroup {
+				groupIssues = append(groupIssues, issue)
+			}
+		}
+		issues = groupIssues
+	}
+	printAliasIssues(issues)
 	return nil
 }
+
+// printAliasIssues lists the issues found in the aliases, errors first.
+func printAliasIssues(issues []alias.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+	fmt.Println(ui.HeaderColor(fmt.Sprintf("\nProblems found (%d):", len(issues))))
+	for _, issue := range issues {
+		if issue.IsError() {
+			fmt.Printf("%s %s\n", ui.ErrorColor("[ERROR]"), issue)
+		}
+	}
+	for _, issue := range issues {
+		if !issue.IsError() {
+			fmt.Printf("%s %s\n", ui.WarningColor("[WARN]"), issue)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewMoveCommand creates the 'move' subcommand.
+func NewMoveCommand(aliasManagementService ports.AliasManagementService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "move <alias> <group>",
+		Short: "Move a managed alias to another group.",
+		Long: `Moves the definition of an alias managed by nicksh into another group.
+Groups are files in the $HOME/.nicksh/ directory (e.g. git, k8s, work); the
+target group file is created if it does not exist yet.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMoveCmd(cmd, args, aliasManagementService)
+		},
+	}
+	return cmd
+}
+
+// runMoveCmd contains the core logic for the 'move' command.
+func runMoveCmd(
+	_ *cobra.Command,
+	args []string,
+	aliasManagementService ports.AliasManagementService,
+) error {
+	aliasName, group := args[0], args[1]
+	if err := aliasManagementService.MoveAlias(aliasName, group); err != nil {
+		return fmt.Errorf("could not move alias: %w", err)
+	}
+	fmt.Println(ui.SuccessColor(fmt.Sprintf("Alias '%s' is now in group '%s' ($HOME/.nicksh/%s).", aliasName, group, group)))
+	return nil
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// NewProjectCommand creates the 'project' subcommand and its children.
+func NewProjectCommand(
+	projectAliasService ports.ProjectAliasService,
+	aliasManagementService ports.AliasManagementService,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage per-project aliases defined in .nicksh.yaml files.",
+		Long: `Project aliases live in a .nicksh.yaml file at the root of a project and only
+apply while your shell is inside that project. Install the shell hook with
+'nicksh project hook <shell>' to load and unload them automatically on cd.
+
+A project file is only loaded once you allowed it with 'nicksh project allow',
+and again after each change to it, so that cloning a repository cannot
+redefine your commands.
+
+Example .nicksh.yaml:
+
+  - alias: ti
+    command: "make test-integration"
+  - alias: dev
+    command: "npm run dev"`,
+	}
+
+	cmd.AddCommand(newProjectListCommand(projectAliasService))
+	cmd.AddCommand(newProjectAllowCommand(projectAliasService))
+	cmd.AddCommand(newProjectDenyCommand(projectAliasService))
+	cmd.AddCommand(newProjectEnvCommand(projectAliasService, aliasManagementService))
+	cmd.AddCommand(newProjectHookCommand())
+
+	return cmd
+}
+
+func newProjectListCommand(projectAliasService ports.ProjectAliasService) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the project aliases that apply to the current directory.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine current directory: %w", err)
+			}
+			projectAliases, err := projectAliasService.GetProjectAliases(cwd)
+			if err != nil {
+				return fmt.Errorf("could not load project aliases: %w", err)
+			}
+
+			if projectAliases.ProjectFile == "" {
+				fmt.Println(ui.InfoColor(fmt.Sprintf("No %s found in the current directory or any of its parents.", projectAliasesFileHint)))
+				return nil
+			}
+
+			fmt.Println(ui.HeaderColor(fmt.Sprintf("Project Aliases (from %s):", projectAliases.ProjectFile)))
+			if len(projectAliases.Aliases) == 0 {
+				fmt.Println(ui.InfoColor("No valid aliases are defined in this project file."))
+			} else {
+				table := tablewriter.NewWriter(os.Stdout)
+				table.SetHeader([]string{"Alias Name", "Command"})
+				table.SetBorder(true)
+				table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT})
+				for _, pa := range projectAliases.Aliases {
+					table.Append([]string{pa.Name, pa.Command})
+				}
+				table.Render()
+			}
+			for _, skipped := range projectAliases.Skipped {
+				fmt.Println(ui.WarningColor(fmt.Sprintf("Skipped alias '%s': invalid name, conflicts with a system command, or empty command.", skipped.Name)))
+			}
+			if !projectAliases.Allowed {
+				fmt.Println(ui.WarningColor("This project file is not allowed, or changed since it was allowed: its aliases are not loaded."))
+				fmt.Println(ui.InfoColor("Review it, then run " + ui.CodeColor("nicksh project allow") + " to load them."))
+			}
+			return nil
+		},
+	}
+}
+
+func newProjectAllowCommand(projectAliasService ports.ProjectAliasService) *cobra.Command {
+	return &cobra.Command{
+		Use:   "allow",
+		Short: "Allow the project aliases of the current directory to be loaded.",
+		Long: `Allows the .nicksh.yaml file governing the current directory to be loaded by
+the shell hook, as it is now. Review the file first: its aliases can redefine
+any command. If the file changes, it must be allowed again.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine current directory: %w", err)
+			}
+			projectFile, err := projectAliasService.AllowProject(cwd)
+			if err != nil {
+				return fmt.Errorf("could not allow project aliases: %w", err)
+			}
+			fmt.Println(ui.SuccessColor(fmt.Sprintf("Allowed %s. Its aliases are loaded on the next directory change.", projectFile)))
+			return nil
+		},
+	}
+}
+
+func newProjectDenyCommand(projectAliasService ports.ProjectAliasService) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deny",
+		Short: "Stop loading the project aliases of the current directory.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine current directory: %w", err)
+			}
+			projectFile, err := projectAliasService.DenyProject(cwd)
+			if err != nil {
+				return fmt.Errorf("could not deny project aliases: %w", err)
+			}
+			fmt.Println(ui.SuccessColor(fmt.Sprintf("Denied %s. Its aliases are no longer loaded.", projectFile)))
+			return nil
+		},
+	}
+}
+
+func newProjectEnvCommand(
+	projectAliasService ports.ProjectAliasService,
+	aliasManagementService ports.AliasManagementService,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print shell code that loads the project aliases of the current directory.",
+		Long: `Prints shell code that unloads the previously loaded project aliases and loads
+the ones that apply to the current directory, if their file is allowed (see
+'nicksh project allow'). It is meant to be evaluated by the shell hook (see
+'nicksh project hook'), not run by hand.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shellName, _ := cmd.Flags().GetString("shell")
+			if shellName == "" {
+				shellName = filepath.Base(os.Getenv("SHELL"))
+			}
+
+			previouslyLoaded := strings.Fields(os.Getenv(projectAliasesEnvVar))
+
+			var projectAliases ports.ProjectAliases
+			cwd, err := os.Getwd()
+			if err == nil {
+				projectAliases, err = projectAliasService.GetProjectAliases(cwd)
+			}
+			if err != nil {
+				// Still unload the previous project's aliases; only report the problem on stderr
+				// since stdout is evaluated by the shell.
+				fmt.Fprintf(os.Stderr, "nicksh: could not load project aliases: %v\n", err)
+			}
+			if projectAliases.ProjectFile != "" && !projectAliases.Allowed {
+				// Like direnv, never load a project file the user has not reviewed.
+				fmt.Fprintf(os.Stderr, "nicksh: %s is not allowed. Run 'nicksh project allow' to load its aliases.\n", projectAliases.ProjectFile)
+				projectAliases.Aliases = nil
+			}
+
+			// Global aliases shadowed by project aliases are restored when unloading.
+			globalAliases, listErr := aliasManagementService.ListAliases()
+			if listErr != nil {
+				globalAliases = map[string]string{}
+			}
+
+			quote := func(s string) string {
+				// renderProjectEnv refuses the shells that cannot be quoted for before quoting anything.
+				quoted, _ := aliasManagementService.QuoteString(shellName, s)
+				return quoted
+			}
+			script, err := renderProjectEnv(shellName, previouslyLoaded, projectAliases.Aliases, globalAliases, quote)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), script)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("shell", "", "Shell to generate code for: bash, zsh or fish (default from $SHELL).")
+	return cmd
+}
+
+func newProjectHookCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "hook <bash|zsh|fish>",
+		Short: "Print the shell hook that loads project aliases on directory change.",
+		Long: `Prints a shell snippet that loads and unloads project aliases whenever you
+change directory. Add it to your shell configuration file, for example:
+
+  # ~/.zshrc
+  eval "$(nicksh project hook zsh)"
+
+  # ~/.bashrc
+  eval "$(nicksh project hook bash)"
+
+  # ~/.config/fish/config.fish
+  nicksh project hook fish | source`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hook, err := renderProjectHook(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), hook)
+			return nil
+		},
+	}
+}
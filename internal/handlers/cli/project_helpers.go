@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+)
+
+// projectAliasesEnvVar holds the space-separated names of the currently loaded project aliases.
+const projectAliasesEnvVar = "NICKSH_PROJECT_ALIASES"
+
+const projectAliasesFileHint = ".nicksh.yaml"
+
+const posixProjectHook = `_nicksh_project_hook() {
+  eval "$(command nicksh --quiet project env --shell %[1]s)"
+}
+`
+
+const zshProjectHook = posixProjectHook + `typeset -ag chpwd_functions
+if (( ! ${chpwd_functions[(I)_nicksh_project_hook]} )); then
+  chpwd_functions+=(_nicksh_project_hook)
+fi
+_nicksh_project_hook
+`
+
+const bashProjectHook = `_nicksh_project_prompt_hook() {
+  if [ "$PWD" != "${_NICKSH_PROJECT_PWD:-}" ]; then
+    _NICKSH_PROJECT_PWD="$PWD"
+    _nicksh_project_hook
+  fi
+}
+case ";${PROMPT_COMMAND:-};" in
+  *";_nicksh_project_prompt_hook;"*) ;;
+  *) PROMPT_COMMAND="_nicksh_project_prompt_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`
+
+const fishProjectHook = `function _nicksh_project_hook --on-variable PWD
+    command nicksh --quiet project env --shell fish | source
+end
+_nicksh_project_hook
+`
+
+// renderProjectHook returns the shell snippet that evaluates 'nicksh project env' on directory change.
+func renderProjectHook(shellName string) (string, error) {
+	switch shellName {
+	case "zsh":
+		return fmt.Sprintf(zshProjectHook, shellName), nil
+	case "bash":
+		return fmt.Sprintf(posixProjectHook, shellName) + bashProjectHook, nil
+	case "fish":
+		return fishProjectHook, nil
+	default:
+		return "", fmt.Errorf("unsupported shell '%s': supported shells are bash, zsh and fish", shellName)
+	}
+}
+
+/*
+renderProjectEnv returns shell code that unloads the previously loaded project aliases,
+restoring any global alias they shadowed, and loads the given project aliases.
+The names of the loaded aliases are recorded in NICKSH_PROJECT_ALIASES for the next call.
+quote quotes a literal string for the shell.
+*/
+func renderProjectEnv(shellName string, previouslyLoaded []string, projectAliases []alias.Alias, globalAliases map[string]string, quote func(string) string) (string, error) {
+	var sb strings.Builder
+	loadedNames := make([]string, 0, len(projectAliases))
+
+	switch shellName {
+	case "bash", "zsh", "sh":
+		for _, name := range previouslyLoaded {
+			fmt.Fprintf(&sb, "unalias %s 2>/dev/null\n", name)
+			if globalCmd, ok := globalAliases[name]; ok {
+				fmt.Fprintf(&sb, "alias %s=%s\n", name, quote(globalCmd))
+			}
+		}
+		for _, pa := range projectAliases {
+			fmt.Fprintf(&sb, "alias %s=%s\n", pa.Name, quote(pa.Command))
+			loadedNames = append(loadedNames, pa.Name)
+		}
+		if len(loadedNames) > 0 {
+			fmt.Fprintf(&sb, "export %s=%s\n", projectAliasesEnvVar, quote(strings.Join(loadedNames, " ")))
+		} else {
+			fmt.Fprintf(&sb, "unset %s\n", projectAliasesEnvVar)
+		}
+	case "fish":
+		for _, name := range previouslyLoaded {
+			fmt.Fprintf(&sb, "functions --erase %s\n", name)
+			if globalCmd, ok := globalAliases[name]; ok {
+				fmt.Fprintf(&sb, "alias %s %s\n", name, quote(globalCmd))
+			}
+		}
+		for _, pa := range projectAliases {
+			fmt.Fprintf(&sb, "alias %s %s\n", pa.Name, quote(pa.Command))
+			loadedNames = append(loadedNames, pa.Name)
+		}
+		if len(loadedNames) > 0 {
+			fmt.Fprintf(&sb, "set -gx %s %s\n", projectAliasesEnvVar, quote(strings.Join(loadedNames, " ")))
+		} else {
+			fmt.Fprintf(&sb, "set -e %s\n", projectAliasesEnvVar)
+		}
+	default:
+		return "", fmt.Errorf("unsupported shell '%s': supported shells are bash, zsh and fish", shellName)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewRemoveCommand creates the 'remove' subcommand.
+func NewRemoveCommand(aliasManagementService ports.AliasManagementService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [alias]...",
+		Short: "Remove managed aliases.",
+		Long: `Removes the definitions of aliases managed by nicksh from the $HOME/.nicksh/
+directory, whichever group they are in. Without alias names, the managed
+aliases are listed for you to pick the ones to remove, with fzf if available.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemoveCmd(cmd, args, aliasManagementService)
+		},
+	}
+	return cmd
+}
+
+// runRemoveCmd contains the core logic for the 'remove' command.
+func runRemoveCmd(
+	_ *cobra.Command,
+	args []string,
+	aliasManagementService ports.AliasManagementService,
+) error {
+	if len(args) == 0 {
+		selected, err := selectAliasesToRemove(aliasManagementService)
+		if errors.Is(err, ErrFZFCancelled) {
+			fmt.Println(ui.InfoColor("Selection cancelled via fzf. No aliases will be removed."))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(selected) == 0 {
+			return nil
+		}
+		args = selected
+	}
+
+	unloadCommands := make([]string, 0, len(args))
+	for _, aliasName := range args {
+		if err := aliasManagementService.RemoveAlias(aliasName); err != nil {
+			return fmt.Errorf("could not remove alias: %w", err)
+		}
+		fmt.Println(ui.SuccessColor(fmt.Sprintf("Alias '%s' removed.", aliasName)))
+		unloadCommands = append(unloadCommands, aliasManagementService.UnloadCommand(aliasName))
+	}
+	fmt.Println(ui.InfoColor(fmt.Sprintf("Open a new terminal session, or run '%s', for the removal to take effect.", strings.Join(unloadCommands, "; "))))
+	return nil
+}
+
+// selectAliasesToRemove lets the user pick managed aliases with the shared alias selector,
+// and returns their names. The aliases cannot be renamed, so no name validation is needed.
+func selectAliasesToRemove(aliasManagementService ports.AliasManagementService) ([]string, error) {
+	existing, err := aliasManagementService.ListAliases()
+	if err != nil {
+		return nil, fmt.Errorf("could not list aliases: %w", err)
+	}
+	if len(existing) == 0 {
+		fmt.Println(ui.InfoColor("No managed aliases to remove."))
+		return nil, nil
+	}
+
+	candidates := make([]alias.Alias, 0, len(existing))
+	for _, name := range slices.Sorted(maps.Keys(existing)) {
+		candidates = append(candidates, alias.Alias{Name: name, Command: existing[name]})
+	}
+	selected, err := selectAliases(candidates, nil)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(selected))
+	for _, a := range selected {
+		names = append(names, a.Name)
+	}
+	return names, nil
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/spf13/cobra"
@@ -13,6 +15,19 @@ func NewRootCommand(
 	version string,
 	suggestionService ports.AliasSuggestionService,
 	managementService ports.AliasManagementService,
+	projectService ports.ProjectAliasService,
+	transferService ports.AliasTransferService,
+	syncService ports.AliasSyncService,
+	registryServer ports.AliasRegistryServer,
+	diagnosticService ports.DiagnosticService,
+	historyStatsService ports.HistoryStatsService,
+	aliasResolutionService ports.AliasResolutionService,
+	historyBackendSelector ports.HistoryBackendSelector,
+	historySourceSelector ports.HistorySourceSelector,
+	aliasDialectSelector ports.AliasDialectSelector,
+	aliasStyleSelector ports.AliasStyleSelector,
+	namingConventions ports.NamingConventionProvider,
+	logConfigurator ports.LogConfigurator,
 ) *cobra.Command {
 	rootCmd = &cobra.Command{
 		Use:   "nicksh",
@@ -21,20 +36,156 @@ func NewRootCommand(
 and provides tools to manage them in your shell configuration.`,
 		Version: version,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Logging is configured first, so the selectors below log with the chosen verbosity.
+			if logConfigurator != nil {
+				logFile, _ := cmd.Flags().GetString("log-file")
+				if err := logConfigurator.ConfigureLogging(logVerbosity(cmd), logFile); err != nil {
+					return err
+				}
+			}
 			if suggestionService == nil && (cmd.Name() == "suggest" || cmd.Name() == "add" || cmd.Name() == "add-predefined") {
 				return fmt.Errorf("alias suggestion service not initialized for command %s", cmd.Name())
 			}
-			if managementService == nil && (cmd.Name() == "add" || cmd.Name() == "list" || cmd.Name() == "add-predefined") {
+			if managementService == nil && (cmd.Name() == "add" || cmd.Name() == "list" || cmd.Name() == "add-predefined" || cmd.Name() == "move" || cmd.Name() == "remove" || cmd.Name() == "env") {
 				return fmt.Errorf("alias management service not initialized for command %s", cmd.Name())
 			}
+			// History files must be selected first: explicit ones rule out the structured backends.
+			if historySourceSelector != nil {
+				historyFiles, _ := cmd.Flags().GetStringSlice("history")
+				if err := historySourceSelector.SelectHistorySources(historyFiles); err != nil {
+					return err
+				}
+			}
+			if historyBackendSelector != nil {
+				backend, _ := cmd.Flags().GetString("history-backend")
+				if err := historyBackendSelector.SelectHistoryBackend(backend); err != nil {
+					return err
+				}
+			}
+			// The dialect must be selected first: the styles available depend on it.
+			if aliasDialectSelector != nil {
+				dialect, _ := cmd.Flags().GetString("dialect")
+				if err := aliasDialectSelector.SelectAliasDialect(dialect); err != nil {
+					return err
+				}
+			}
+			if aliasStyleSelector != nil {
+				style, _ := cmd.Flags().GetString("style")
+				if err := aliasStyleSelector.SelectAliasStyle(style); err != nil {
+					return err
+				}
+			}
+			if namingConventions != nil {
+				disabled, _ := cmd.Flags().GetStringSlice("disable-conventions")
+				if err := namingConventions.DisableTools(disabled); err != nil {
+					return err
+				}
+			}
+			if transferService == nil && (cmd.Name() == "export" || cmd.Name() == "import") {
+				return fmt.Errorf("alias transfer service not initialized for command %s", cmd.Name())
+			}
+			if syncService == nil && cmd.Name() == "sync" {
+				return fmt.Errorf("alias sync service not initialized for command %s", cmd.Name())
+			}
+			if registryServer == nil && cmd.Name() == "serve-registry" {
+				return fmt.Errorf("alias registry server not initialized for command %s", cmd.Name())
+			}
+			if diagnosticService == nil && cmd.Name() == "doctor" {
+				return fmt.Errorf("diagnostic service not initialized for command %s", cmd.Name())
+			}
+			if historyStatsService == nil && cmd.Name() == "stats" {
+				return fmt.Errorf("history stats service not initialized for command %s", cmd.Name())
+			}
+			if aliasResolutionService == nil && (cmd.Name() == "which" || cmd.Name() == "expand") {
+				return fmt.Errorf("alias resolution service not initialized for command %s", cmd.Name())
+			}
+			if projectService == nil && cmd.HasParent() && cmd.Parent().Name() == "project" && cmd.Name() != "hook" {
+				return fmt.Errorf("project alias service not initialized for command %s", cmd.Name())
+			}
 			return nil
 		},
 	}
 
+	defaultHistoryBackend := os.Getenv("NICKSH_HISTORY_BACKEND")
+	if defaultHistoryBackend == "" {
+		defaultHistoryBackend = "auto"
+	}
+	backendsHelp := "auto, file, atuin, histdb, mcfly"
+	if historyBackendSelector != nil {
+		backendsHelp = strings.Join(historyBackendSelector.AvailableHistoryBackends(), ", ")
+	}
+	rootCmd.PersistentFlags().String("history-backend", defaultHistoryBackend,
+		fmt.Sprintf("History source to analyze: %s. Can also be set with NICKSH_HISTORY_BACKEND.", backendsHelp))
+
+	var defaultHistoryFiles []string
+	if env := os.Getenv("NICKSH_HISTORY"); env != "" {
+		defaultHistoryFiles = strings.Split(env, ",")
+	}
+	rootCmd.PersistentFlags().StringSlice("history", defaultHistoryFiles,
+		"History files to analyze together, as PATH or PATH:WEIGHT (e.g. ~/laptop_history:0.5); counts from each file are multiplied by its weight. Defaults to every history file found. Can also be set with NICKSH_HISTORY.")
+
+	dialectsHelp := "bash, zsh, fish, nu, pwsh"
+	if aliasDialectSelector != nil {
+		dialectsHelp = strings.Join(aliasDialectSelector.AvailableAliasDialects(), ", ")
+	}
+	rootCmd.PersistentFlags().String("dialect", os.Getenv("NICKSH_DIALECT"),
+		fmt.Sprintf("Shell to read and write aliases for: %s. Defaults to the shell in $SHELL. Can also be set with NICKSH_DIALECT.", dialectsHelp))
+
+	stylesHelp := "alias, abbr"
+	if aliasStyleSelector != nil {
+		stylesHelp = strings.Join(aliasStyleSelector.AvailableAliasStyles(), ", ")
+	}
+	rootCmd.PersistentFlags().String("style", os.Getenv("NICKSH_ALIAS_STYLE"),
+		fmt.Sprintf("How new aliases are written: %s. abbr writes fish abbreviations (abbr -a gs 'git status'), the default for fish; alias is the default for other shells. Can also be set with NICKSH_ALIAS_STYLE.", stylesHelp))
+
+	var defaultDisabledConventions []string
+	if env := os.Getenv("NICKSH_DISABLE_CONVENTIONS"); env != "" {
+		defaultDisabledConventions = strings.Split(env, ",")
+	}
+	conventionToolsHelp := "all"
+	if namingConventions != nil {
+		conventionToolsHelp = strings.Join(append([]string{"all"}, namingConventions.AvailableTools()...), ", ")
+	}
+	rootCmd.PersistentFlags().StringSlice("disable-conventions", defaultDisabledConventions,
+		fmt.Sprintf("Tools whose community alias names (e.g. gst for git status) should not be preferred: %s. Can also be set with NICKSH_DISABLE_CONVENTIONS.", conventionToolsHelp))
+
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Log what nicksh is doing, e.g. which history files it reads.")
+	rootCmd.PersistentFlags().Bool("debug", false, "Log the decisions nicksh makes too, e.g. why each candidate alias name was rejected.")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Log errors only.")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "debug")
+	rootCmd.PersistentFlags().String("log-file", os.Getenv("NICKSH_LOG_FILE"),
+		"File to append a JSON log to, at least at --verbose level. Can also be set with NICKSH_LOG_FILE.")
+
 	rootCmd.AddCommand(NewSuggestCommand(suggestionService))
 	rootCmd.AddCommand(NewAddCommand(suggestionService, managementService))
 	rootCmd.AddCommand(NewListCommand(managementService))
 	rootCmd.AddCommand(NewAddPredefinedCommand(suggestionService, managementService))
+	rootCmd.AddCommand(NewMoveCommand(managementService))
+	rootCmd.AddCommand(NewRemoveCommand(managementService))
+	rootCmd.AddCommand(NewProjectCommand(projectService, managementService))
+	rootCmd.AddCommand(NewExportCommand(transferService))
+	rootCmd.AddCommand(NewImportCommand(transferService))
+	rootCmd.AddCommand(NewSyncCommand(syncService))
+	rootCmd.AddCommand(NewServeRegistryCommand(registryServer))
+	rootCmd.AddCommand(NewDoctorCommand(diagnosticService))
+	rootCmd.AddCommand(NewStatsCommand(historyStatsService))
+	rootCmd.AddCommand(NewWhichCommand(aliasResolutionService))
+	rootCmd.AddCommand(NewExpandCommand(aliasResolutionService))
 
 	return rootCmd
 }
+
+// logVerbosity returns the verbosity chosen with the --quiet, --verbose and --debug flags.
+func logVerbosity(cmd *cobra.Command) ports.LogVerbosity {
+	if debug, _ := cmd.Flags().GetBool("debug"); debug {
+		return ports.LogDebug
+	}
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		return ports.LogVerbose
+	}
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return ports.LogQuiet
+	}
+	return ports.LogNormal
+}
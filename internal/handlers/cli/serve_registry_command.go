@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewServeRegistryCommand creates the 'serve-registry' subcommand.
+func NewServeRegistryCommand(registryServer ports.AliasRegistryServer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve-registry <dir>",
+		Short: "Serve a directory of alias packs as a team alias registry.",
+		Long: `Serves the alias packs of a directory as a versioned alias catalog over HTTP,
+at /catalog. Each pack is a YAML or JSON alias file, in the format written by
+'nicksh export'; the pack name is the file name without its extension.
+
+Point nicksh at the registry by setting NICKSH_REGISTRY_URL, e.g.
+  export NICKSH_REGISTRY_URL=http://127.0.0.1:8383/catalog
+Its aliases are then offered by 'add-predefined' and suggestions, before the
+built-in predefined aliases. The catalog is cached in $HOME/.nicksh/cache, and
+the cache is used when the registry is unreachable.
+
+Packs are reloaded on every request, so edits are served right away.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServeRegistryCmd(cmd, args, registryServer)
+		},
+	}
+
+	cmd.Flags().String("addr", "127.0.0.1:8383", "Address to listen on, as host:port.")
+
+	return cmd
+}
+
+// runServeRegistryCmd contains the core logic for the 'serve-registry' command.
+func runServeRegistryCmd(
+	cmd *cobra.Command,
+	args []string,
+	registryServer ports.AliasRegistryServer,
+) error {
+	packsDir := args[0]
+	addr, _ := cmd.Flags().GetString("addr")
+
+	// Load the packs once up front, so a broken pack is reported before serving.
+	version, packs, err := registryServer.Describe(packsDir)
+	if err != nil {
+		return fmt.Errorf("could not load alias packs: %w", err)
+	}
+	if len(packs) == 0 {
+		fmt.Fprintln(os.Stderr, ui.WarningColor(fmt.Sprintf("No alias packs (*.yaml, *.yml, *.json) found in %s yet.", packsDir)))
+	} else {
+		fmt.Println(ui.InfoColor(fmt.Sprintf("Loaded %d pack(s) from %s: %s (catalog version %s).", len(packs), packsDir, strings.Join(packs, ", "), version)))
+	}
+
+	host := addr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	fmt.Println(ui.SuccessColor(fmt.Sprintf("Serving the alias catalog at http://%s/catalog", host)))
+	fmt.Println(ui.InfoColor(fmt.Sprintf("Use it with: export NICKSH_REGISTRY_URL=http://%s/catalog", host)))
+	fmt.Println(ui.DetailColor("Press Ctrl+C to stop."))
+
+	if err := registryServer.Serve(addr, packsDir); err != nil {
+		return fmt.Errorf("alias registry stopped: %w", err)
+	}
+	return nil
+}
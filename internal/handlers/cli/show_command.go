@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
 	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
 	"github.com/spf13/cobra"
@@ -11,11 +14,17 @@ import (
 // NewSuggestCommand creates the 'show' subcommand.
 func NewSuggestCommand(aliasSuggestionService ports.AliasSuggestionService) *cobra.Command {
 	var minFrequency, scanLimit, outputLimit int
+	var here, explain bool
 
 	cmd := &cobra.Command{
-		Use:   "show",
-		Short: "Show alias suggestions based on command history.",
-		Long:  `Analyzes command history to find frequently used commands and suggests potential aliases.`,
+		Use:     "show",
+		Aliases: []string{"suggest"},
+		Short:   "Show alias suggestions based on command history.",
+		Long: `Analyzes command history to find frequently used commands and suggests potential aliases.
+With --here, only commands run in the current directory are analyzed; this needs a
+history source that records directories (e.g. the zsh per-directory-history plugin).
+History entries matched by HISTIGNORE, HISTORY_IGNORE or the user's exclusion patterns
+are not counted; --explain shows how many entries each of those rules removed.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runShowCmd(cmd, args, aliasSuggestionService)
 		},
@@ -24,6 +33,8 @@ func NewSuggestCommand(aliasSuggestionService ports.AliasSuggestionService) *cob
 	cmd.Flags().IntVarP(&minFrequency, "min-frequency", "f", 0, "Minimum frequency for a command to be considered for an alias (default 3).")
 	cmd.Flags().IntVarP(&scanLimit, "scan-limit", "s", 0, "Number of recent history entries to scan (default 500).")
 	cmd.Flags().IntVarP(&outputLimit, "output-limit", "o", 0, "Maximum number of alias suggestions to show (default 10).")
+	cmd.Flags().BoolVar(&here, "here", false, "Only analyze commands run in the current directory.")
+	cmd.Flags().BoolVar(&explain, "explain", false, "Show how many history entries each exclusion rule removed.")
 
 	return cmd
 }
@@ -36,6 +47,8 @@ func runShowCmd(
 	minFrequency, _ := cmd.Flags().GetInt("min-frequency")
 	scanLimit, _ := cmd.Flags().GetInt("scan-limit")
 	outputLimit, _ := cmd.Flags().GetInt("output-limit")
+	here, _ := cmd.Flags().GetBool("here")
+	explain, _ := cmd.Flags().GetBool("explain")
 
 	// Default values
 	if minFrequency <= 0 {
@@ -48,13 +61,24 @@ func runShowCmd(
 		outputLimit = 10
 	}
 
-	suggestionResult, err := aliasSuggestionService.GetSuggestions(minFrequency, scanLimit, outputLimit)
+	var suggestionResult ports.SuggestionResult
+	var err error
+	if here {
+		cwd, cwdErr := os.Getwd()
+		if cwdErr != nil {
+			return fmt.Errorf("could not determine current directory: %w", cwdErr)
+		}
+		suggestionResult, err = aliasSuggestionService.GetSuggestionsForDirectory(cwd, minFrequency, scanLimit, outputLimit)
+	} else {
+		suggestionResult, err = aliasSuggestionService.GetSuggestions(minFrequency, scanLimit, outputLimit)
+	}
 	if err != nil {
 		return fmt.Errorf("could not get suggestions: %w", err)
 	}
 
 	if len(suggestionResult.Suggestions) == 0 {
 		fmt.Println(ui.InfoColor("No alias suggestions found with the current criteria."))
+		printWithheldSuggestions(suggestionResult.Withheld)
 		if suggestionResult.SourceDetails != "" {
 			fmt.Println(ui.DetailColor(fmt.Sprintf("Context: %s", suggestionResult.SourceDetails)))
 		} else {
@@ -64,18 +88,99 @@ func runShowCmd(
 				fmt.Println(ui.DetailColor(fmt.Sprintf("Context: %s", contextDetails)))
 			}
 		}
+		if explain {
+			return printExclusionReport(aliasSuggestionService, scanLimit)
+		}
 		return nil
 	}
 
-	fmt.Println(ui.InfoColor("Suggested Aliases:"))
-	for _, s := range suggestionResult.Suggestions {
-		fmt.Printf("  %s %s='%s'\n",
-			ui.AliasKeywordColor("alias"),
-			ui.AliasNameColor(s.Name),
-			ui.AliasCmdColor(s.Command))
+	shortcuts, corrections := splitCorrections(suggestionResult.Suggestions)
+	printSuggestionGroup("Suggested Aliases:", shortcuts)
+	if len(shortcuts) > 0 && len(corrections) > 0 {
+		fmt.Println()
 	}
+	printSuggestionGroup("Typo Corrections:", corrections)
+	printWithheldSuggestions(suggestionResult.Withheld)
 	if suggestionResult.SourceDetails != "" {
 		fmt.Println(ui.DetailColor(fmt.Sprintf("\n(Source: %s)", suggestionResult.SourceDetails)))
 	}
+	if explain {
+		return printExclusionReport(aliasSuggestionService, scanLimit)
+	}
 	return nil
 }
+
+// printExclusionReport prints how many of the scanned history entries each exclusion rule removed.
+func printExclusionReport(aliasSuggestionService ports.AliasSuggestionService, scanLimit int) error {
+	report, err := aliasSuggestionService.ExplainHistoryExclusions(scanLimit)
+	if err != nil {
+		return fmt.Errorf("could not explain history exclusions: %w", err)
+	}
+
+	fmt.Println(ui.InfoColor(fmt.Sprintf("\nHistory exclusions (%d entries scanned):", report.ScannedEntries)))
+	if len(report.Rules) == 0 {
+		fmt.Println(ui.DetailColor("  No exclusion rules configured (HISTIGNORE, HISTORY_IGNORE or history_exclusions.yaml)."))
+		return nil
+	}
+	totalRemoved := 0
+	for _, rule := range report.Rules {
+		fmt.Printf("  %-14s %-30s %s\n", rule.Origin, rule.Pattern, ui.DetailColor(fmt.Sprintf("%d removed", rule.Removed)))
+		totalRemoved += rule.Removed
+	}
+	fmt.Println(ui.DetailColor(fmt.Sprintf("  %d of %d entries removed in total.", totalRemoved, report.ScannedEntries)))
+	return nil
+}
+
+// printSuggestionGroup prints a titled list of suggestions, or nothing if there are none.
+func printSuggestionGroup(title string, suggestions []alias.Alias) {
+	if len(suggestions) == 0 {
+		return
+	}
+	fmt.Println(ui.InfoColor(title))
+	for _, s := range suggestions {
+		fmt.Printf("  %s %s='%s'\n",
+			ui.AliasKeywordColor(aliasKeyword(s)),
+			ui.AliasNameColor(s.Name),
+			ui.AliasCmdColor(s.Command))
+	}
+}
+
+// aliasKeyword returns the command that defines a, with the option for its kind: "alias", "alias -g" or "alias -s".
+func aliasKeyword(a alias.Alias) string {
+	switch a.Kind {
+	case alias.KindGlobal:
+		return "alias -g"
+	case alias.KindSuffix:
+		return "alias -s"
+	}
+	return "alias"
+}
+
+// printWithheldSuggestions explains which suggestions were left out because their command contains a secret.
+// Their commands are already redacted.
+func printWithheldSuggestions(withheld []alias.Alias) {
+	if len(withheld) == 0 {
+		return
+	}
+	fmt.Println(ui.WarningColor(fmt.Sprintf("\n%d suggestion(s) withheld because the command contains a secret:", len(withheld))))
+	for _, s := range withheld {
+		fmt.Printf("  %s %s='%s' %s\n",
+			ui.AliasKeywordColor("alias"),
+			ui.AliasNameColor(s.Name),
+			ui.AliasCmdColor(s.Command),
+			ui.DetailColor("("+strings.Join(s.Secrets, ", ")+")"))
+	}
+	fmt.Println(ui.DetailColor("Keep secrets in environment variables or credentials files and refer to them instead (e.g. $API_TOKEN)."))
+}
+
+// splitCorrections separates typo corrections from shortcut suggestions, keeping their order.
+func splitCorrections(suggestions []alias.Alias) (shortcuts, corrections []alias.Alias) {
+	for _, s := range suggestions {
+		if s.IsCorrection {
+			corrections = append(corrections, s)
+		} else {
+			shortcuts = append(shortcuts, s)
+		}
+	}
+	return shortcuts, corrections
+}
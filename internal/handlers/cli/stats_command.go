@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// volumeBarWidth is the width of the bar of the busiest hour or day.
+const volumeBarWidth = 30
+
+// statsReport is the JSON output of 'nicksh stats'.
+type statsReport struct {
+	Source            string           `json:"source"`
+	Commands          int              `json:"commands"`
+	Keystrokes        int              `json:"keystrokes"`
+	TopCommands       []statsCount     `json:"top_commands"`
+	TopSubcommands    []statsCount     `json:"top_subcommands"`
+	AliasCoverage     statsCoverage    `json:"alias_coverage"`
+	VolumeByHour      []statsVolume    `json:"volume_by_hour,omitempty"`
+	VolumeByWeekday   []statsVolume    `json:"volume_by_weekday,omitempty"`
+	SuggestionSavings statsSuggestions `json:"suggestion_savings"`
+}
+
+type statsCount struct {
+	Command string `json:"command"`
+	Count   int    `json:"count"`
+}
+
+type statsCoverage struct {
+	CoveredKeystrokes int `json:"covered_keystrokes"`
+	SavedKeystrokes   int `json:"saved_keystrokes"`
+	MissedKeystrokes  int `json:"missed_keystrokes"`
+}
+
+type statsVolume struct {
+	Period     string `json:"period"`
+	Commands   int    `json:"commands"`
+	Keystrokes int    `json:"keystrokes"`
+}
+
+type statsSuggestions struct {
+	Suggestions int `json:"suggestions"`
+	Keystrokes  int `json:"keystrokes"`
+}
+
+// NewStatsCommand creates the 'stats' subcommand.
+func NewStatsCommand(historyStatsService ports.HistoryStatsService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show statistics about your command history and aliases.",
+		Long: `Analyzes recent command history and shows the most used commands and
+command + subcommand pairs, how much of your typing your aliases cover, and how
+many keystrokes accepting the current suggestions would save.
+
+When the history records timestamps (see 'nicksh doctor'), the typing volume by
+hour of the day and by day of the week is shown too. Keystrokes are the
+characters of the commands as typed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatsCmd(cmd, args, historyStatsService)
+		},
+	}
+
+	cmd.Flags().IntP("scan-limit", "s", 5000, "Number of recent history entries to analyze.")
+	cmd.Flags().IntP("top", "n", 10, "Number of commands and subcommands to show.")
+	cmd.Flags().IntP("min-frequency", "f", 3, "Minimum frequency for a command to be suggested an alias, for the savings estimate.")
+	cmd.Flags().StringP("output", "o", "text", "Output format: text or json.")
+
+	return cmd
+}
+
+// runStatsCmd contains the core logic for the 'stats' command.
+func runStatsCmd(
+	cmd *cobra.Command,
+	_ []string,
+	historyStatsService ports.HistoryStatsService,
+) error {
+	scanLimit, _ := cmd.Flags().GetInt("scan-limit")
+	top, _ := cmd.Flags().GetInt("top")
+	minFrequency, _ := cmd.Flags().GetInt("min-frequency")
+	output, _ := cmd.Flags().GetString("output")
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format '%s': use text or json", output)
+	}
+
+	stats, err := historyStatsService.GetHistoryStats(scanLimit, top, minFrequency)
+	if err != nil {
+		return fmt.Errorf("could not compute history statistics: %w", err)
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(newStatsReport(stats), "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode the statistics: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printHistoryStats(stats)
+	return nil
+}
+
+// newStatsReport converts stats to their JSON form. Volumes are only included when the history has timestamps.
+func newStatsReport(stats ports.HistoryStats) statsReport {
+	report := statsReport{
+		Source:            stats.SourceDetails,
+		Commands:          stats.Commands,
+		Keystrokes:        stats.Keystrokes,
+		TopCommands:       toStatsCounts(stats.TopCommands),
+		TopSubcommands:    toStatsCounts(stats.TopSubcommands),
+		AliasCoverage:     statsCoverage(stats.AliasCoverage),
+		SuggestionSavings: statsSuggestions(stats.SuggestionSavings),
+	}
+	if stats.HasTimestamps {
+		for hour, volume := range stats.VolumeByHour {
+			report.VolumeByHour = append(report.VolumeByHour, statsVolume{Period: fmt.Sprintf("%02d:00", hour), Commands: volume.Commands, Keystrokes: volume.Keystrokes})
+		}
+		for day, volume := range stats.VolumeByWeekday {
+			report.VolumeByWeekday = append(report.VolumeByWeekday, statsVolume{Period: time.Weekday(day).String(), Commands: volume.Commands, Keystrokes: volume.Keystrokes})
+		}
+	}
+	return report
+}
+
+func toStatsCounts(frequencies []history.CommandFrequency) []statsCount {
+	counts := make([]statsCount, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		counts = append(counts, statsCount(frequency))
+	}
+	return counts
+}
+
+// printHistoryStats prints stats as tables.
+func printHistoryStats(stats ports.HistoryStats) {
+	fmt.Println(ui.InfoColor(fmt.Sprintf("Context: %s", stats.SourceDetails)))
+	fmt.Println(ui.InfoColor(fmt.Sprintf("Analyzed %d commands, %d keystrokes.", stats.Commands, stats.Keystrokes)))
+	if stats.Commands == 0 {
+		return
+	}
+
+	fmt.Println(ui.HeaderColor("\nTop Commands:"))
+	printFrequencyTable("Command", stats.TopCommands, stats.Commands)
+	if len(stats.TopSubcommands) > 0 {
+		fmt.Println(ui.HeaderColor("\nTop Subcommands:"))
+		printFrequencyTable("Command + Subcommand", stats.TopSubcommands, stats.Commands)
+	}
+
+	coverage := stats.AliasCoverage
+	fmt.Println(ui.HeaderColor("\nAlias Coverage:"))
+	fmt.Printf("  Keystrokes in commands covered by your aliases: %d of %d (%s)\n",
+		coverage.CoveredKeystrokes, stats.Keystrokes, percentage(coverage.CoveredKeystrokes, stats.Keystrokes))
+	fmt.Printf("  Keystrokes saved by the aliases you used:       %d\n", coverage.SavedKeystrokes)
+	fmt.Printf("  Keystrokes you could have saved with them:      %d\n", coverage.MissedKeystrokes)
+
+	if stats.HasTimestamps {
+		fmt.Println(ui.HeaderColor("\nTyping Volume by Hour:"))
+		hours := make([]string, 0, len(stats.VolumeByHour))
+		for hour := range stats.VolumeByHour {
+			hours = append(hours, fmt.Sprintf("%02d:00", hour))
+		}
+		printVolumeTable("Hour", hours, stats.VolumeByHour[:])
+		fmt.Println(ui.HeaderColor("\nTyping Volume by Day:"))
+		days := make([]string, 0, len(stats.VolumeByWeekday))
+		for day := range stats.VolumeByWeekday {
+			days = append(days, time.Weekday(day).String())
+		}
+		printVolumeTable("Day", days, stats.VolumeByWeekday[:])
+	} else {
+		fmt.Println(ui.WarningColor("\nThe history has no timestamps, so the typing volume by hour and day cannot be shown. Run 'nicksh doctor' to see how to enable them."))
+	}
+
+	savings := stats.SuggestionSavings
+	fmt.Println(ui.HeaderColor("\nSuggestions:"))
+	if savings.Suggestions == 0 {
+		fmt.Println("  No aliases to suggest for these commands.")
+		return
+	}
+	fmt.Printf("  Accepting the %d current suggestion(s) would have saved about %d keystrokes (%s of your typing).\n",
+		savings.Suggestions, savings.Keystrokes, percentage(savings.Keystrokes, stats.Keystrokes))
+	fmt.Println(ui.InfoColor("  Run 'nicksh add' to review them."))
+}
+
+// printFrequencyTable prints frequencies with their share of total.
+func printFrequencyTable(header string, frequencies []history.CommandFrequency, total int) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{header, "Count", "Share"})
+	table.SetBorder(true)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT})
+	for _, frequency := range frequencies {
+		table.Append([]string{frequency.Command, strconv.Itoa(frequency.Count), percentage(frequency.Count, total)})
+	}
+	table.Render()
+}
+
+// printVolumeTable prints the typing volume of each period, with a bar scaled to the busiest one.
+func printVolumeTable(header string, periods []string, volumes []ports.TypingVolume) {
+	busiest := 0
+	for _, volume := range volumes {
+		busiest = max(busiest, volume.Keystrokes)
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{header, "Commands", "Keystrokes", ""})
+	table.SetBorder(true)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT})
+	for i, volume := range volumes {
+		bar := ""
+		if busiest > 0 {
+			bar = strings.Repeat("#", volume.Keystrokes*volumeBarWidth/busiest)
+		}
+		table.Append([]string{periods[i], strconv.Itoa(volume.Commands), strconv.Itoa(volume.Keystrokes), bar})
+	}
+	table.Render()
+}
+
+// percentage formats part as a percentage of total.
+func percentage(part, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
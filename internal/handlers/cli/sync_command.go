@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewSyncCommand creates the 'sync' subcommand.
+func NewSyncCommand(aliasSyncService ports.AliasSyncService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Synchronize the managed aliases with a git repository.",
+		Long: `Keeps the aliases of the $HOME/.nicksh/ directory in sync with an alias file
+(nicksh_aliases.yaml) in a git working copy, such as your dotfiles repository.
+
+The working copy is pulled first. Its aliases are then merged with the local
+ones, alias by alias, against the state of the last sync: aliases added,
+changed or removed on one side are added, changed or removed on the other.
+An alias changed differently on both sides is a conflict, resolved in favor
+of --prefer. Finally, the alias file is committed and pushed.
+
+The working copy is given with --dir or NICKSH_SYNC_DIR.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSyncCmd(cmd, args, aliasSyncService)
+		},
+	}
+
+	cmd.Flags().String("dir", os.Getenv("NICKSH_SYNC_DIR"), "Directory of the git working copy holding the alias file. Can also be set with NICKSH_SYNC_DIR.")
+	cmd.Flags().String("prefer", ports.SyncSideLocal, "Side kept when an alias was changed differently on both sides: local or remote.")
+
+	return cmd
+}
+
+// runSyncCmd contains the core logic for the 'sync' command.
+func runSyncCmd(
+	cmd *cobra.Command,
+	_ []string,
+	aliasSyncService ports.AliasSyncService,
+) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	prefer, _ := cmd.Flags().GetString("prefer")
+	if dir == "" {
+		return fmt.Errorf("no sync directory: use --dir or set NICKSH_SYNC_DIR to a git working copy")
+	}
+
+	result, err := aliasSyncService.Sync(dir, prefer)
+	if err != nil {
+		return fmt.Errorf("could not sync aliases: %w", err)
+	}
+
+	printAliasChanges("Changes applied to $HOME/.nicksh/:", result.LocalChanges)
+	printAliasChanges(fmt.Sprintf("Changes saved to %s:", dir), result.RemoteChanges)
+	if len(result.Conflicts) > 0 {
+		fmt.Println(ui.WarningColor(fmt.Sprintf("%d alias(es) were changed on both sides:", len(result.Conflicts))))
+		for _, conflict := range result.Conflicts {
+			fmt.Printf("  %s: local %s, remote %s; kept %s\n",
+				conflict.Name, describeSyncedAlias(conflict.Local), describeSyncedAlias(conflict.Remote), conflict.Kept)
+		}
+	}
+
+	switch {
+	case result.Pushed:
+		fmt.Println(ui.SuccessColor("Aliases synced and pushed."))
+	case result.Committed:
+		fmt.Println(ui.SuccessColor("Aliases synced and committed (the repository has no remote to push to)."))
+	case len(result.LocalChanges) == 0:
+		fmt.Println(ui.SuccessColor("Aliases are already in sync."))
+	default:
+		fmt.Println(ui.SuccessColor("Aliases synced."))
+	}
+	if len(result.LocalChanges) > 0 {
+		fmt.Println(ui.InfoColor("Open a new terminal session for the changes to take effect."))
+	}
+	return nil
+}
+
+// printAliasChanges lists changes under header, if there are any.
+func printAliasChanges(header string, changes []ports.AliasChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Println(ui.HeaderColor(header))
+	for _, change := range changes {
+		switch {
+		case change.Before == nil:
+			fmt.Printf("  + %s\n", describeSyncedAlias(change.After))
+		case change.After == nil:
+			fmt.Printf("  - %s\n", describeSyncedAlias(change.Before))
+		default:
+			fmt.Printf("  ~ %s (was '%s')\n", describeSyncedAlias(change.After), change.Before.Command)
+		}
+	}
+}
+
+// describeSyncedAlias describes one side of a sync change or conflict.
+func describeSyncedAlias(a *alias.Alias) string {
+	if a == nil {
+		return "removed"
+	}
+	return fmt.Sprintf("alias %s='%s' [%s]", a.Name, a.Command, a.Group)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewWhichCommand creates the 'which' subcommand.
+func NewWhichCommand(aliasResolutionService ports.AliasResolutionService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "which <name>",
+		Short: "Show what a name resolves to: its alias definitions and the command it shadows.",
+		Long: `Looks a name up among the aliases defined in your shell startup files and in
+$HOME/.nicksh/, and shows every definition with its file and line. The definition
+loaded last is the one in effect; the others are marked as overridden.
+
+It also tells whether the alias shadows an executable on PATH or a shell builtin.
+The command exits with an error if the name is not an alias.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhichCmd(cmd, args, aliasResolutionService)
+		},
+	}
+
+	return cmd
+}
+
+// runWhichCmd contains the core logic for the 'which' command.
+func runWhichCmd(
+	cmd *cobra.Command,
+	args []string,
+	aliasResolutionService ports.AliasResolutionService,
+) error {
+	lookup, err := aliasResolutionService.Which(args[0])
+	if err != nil {
+		return fmt.Errorf("could not look up '%s': %w", args[0], err)
+	}
+
+	if lookup.IsAlias() {
+		effective := lookup.Effective()
+		switch effective.Kind {
+		case alias.KindGlobal:
+			fmt.Printf("%s is a global alias for %s, expanded anywhere on the command line\n", ui.CodeColor(lookup.Name), ui.CodeColor(effective.Command))
+		case alias.KindSuffix:
+			fmt.Printf("%s is a suffix alias: *.%s files are opened with %s\n", ui.CodeColor(lookup.Name), lookup.Name, ui.CodeColor(effective.Command))
+		default:
+			fmt.Printf("%s is an alias for %s\n", ui.CodeColor(lookup.Name), ui.CodeColor(effective.Command))
+		}
+		for i, def := range lookup.Definitions {
+			location := fmt.Sprintf("%s:%d", def.File, def.Line)
+			if i == len(lookup.Definitions)-1 {
+				fmt.Printf("  %s %s\n", ui.SuccessColor("in effect:"), location)
+			} else {
+				fmt.Printf("  %s %s (%s)\n", ui.WarningColor("overridden:"), location, def.Command)
+			}
+		}
+	}
+
+	switch {
+	case lookup.IsBuiltin && lookup.IsAlias():
+		fmt.Println(ui.WarningColor(fmt.Sprintf("It shadows the shell builtin '%s'.", lookup.Name)))
+	case lookup.Path != "" && lookup.IsAlias():
+		fmt.Println(ui.WarningColor(fmt.Sprintf("It shadows the executable %s.", lookup.Path)))
+	case lookup.IsBuiltin:
+		fmt.Printf("%s is a shell builtin\n", ui.CodeColor(lookup.Name))
+	case lookup.Path != "":
+		fmt.Printf("%s is %s\n", ui.CodeColor(lookup.Name), lookup.Path)
+	}
+
+	if !lookup.IsAlias() {
+		cmd.SilenceUsage = true // The name was looked up: the usage would only hide the answer.
+		return fmt.Errorf("'%s' is not an alias", lookup.Name)
+	}
+	return nil
+}
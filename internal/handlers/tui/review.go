@@ -0,0 +1,45 @@
+/*
+Package tui implements the interactive terminal UI used to review alias
+suggestions before they are written: selecting, renaming, editing commands
+and previewing the change to the alias file.
+*/
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrReviewCancelled indicates that the user left the review without accepting (e.g., by pressing q or Esc).
+var ErrReviewCancelled = errors.New("review cancelled by user")
+
+// ReviewOptions configures a review session.
+type ReviewOptions struct {
+	// Suggestions are the aliases to review.
+	Suggestions []alias.Alias
+	// ValidateName returns an error describing why a name cannot be used, or nil.
+	// It is called on every change of a name, for live validation.
+	ValidateName func(name string) error
+	// TargetFile is the file the accepted aliases are appended to, shown in the diff preview.
+	TargetFile string
+	// FormatDefinition renders an alias as the line written to TargetFile, for the diff preview.
+	FormatDefinition func(a alias.Alias) string
+}
+
+// Review runs the review UI on the terminal and returns the accepted aliases,
+// with the names and commands as edited by the user.
+// It returns ErrReviewCancelled if the user quits without accepting.
+func Review(opts ReviewOptions) ([]alias.Alias, error) {
+	finalModel, err := tea.NewProgram(newReviewModel(opts), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, fmt.Errorf("running review UI: %w", err)
+	}
+	m := finalModel.(reviewModel)
+	if !m.accepted {
+		return nil, ErrReviewCancelled
+	}
+	return m.selectedAliases(), nil
+}
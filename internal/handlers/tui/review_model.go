@@ -0,0 +1,318 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/handlers/ui"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reviewMode is what the review UI is currently doing.
+type reviewMode int
+
+const (
+	modeList        reviewMode = iota // Browsing and selecting suggestions.
+	modeRename                        // Editing the name of the current suggestion.
+	modeEditCommand                   // Editing the command of the current suggestion.
+	modePreview                       // Showing the diff of the alias file.
+)
+
+// reviewItem is a suggestion under review.
+type reviewItem struct {
+	alias    alias.Alias
+	selected bool
+	conflict string // Why the current name cannot be used, "" if it can.
+}
+
+// reviewModel is the bubbletea model of the review UI.
+type reviewModel struct {
+	items        []reviewItem
+	cursor       int
+	mode         reviewMode
+	input        textinput.Model
+	inputErr     string // Live validation result of the input, "" if valid.
+	status       string // One-line feedback for the last action.
+	validateName func(name string) error
+	targetFile   string
+	format       func(a alias.Alias) string
+	accepted     bool
+}
+
+func newReviewModel(opts ReviewOptions) reviewModel {
+	m := reviewModel{
+		items:        make([]reviewItem, len(opts.Suggestions)),
+		validateName: opts.ValidateName,
+		targetFile:   opts.TargetFile,
+		format:       opts.FormatDefinition,
+		input:        textinput.New(),
+	}
+	for i, s := range opts.Suggestions {
+		m.items[i] = reviewItem{alias: s}
+	}
+	for i := range m.items {
+		m.items[i].conflict = m.nameConflict(i, m.items[i].alias.Name)
+	}
+	return m
+}
+
+// Init implements tea.Model.
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	switch m.mode {
+	case modeRename, modeEditCommand:
+		if isKey {
+			switch keyMsg.Type {
+			case tea.KeyEnter:
+				return m.commitInput(), nil
+			case tea.KeyEsc:
+				m.mode = modeList
+				m.status = "Edit discarded."
+				return m, nil
+			}
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		m.inputErr = m.validateInput()
+		return m, cmd
+	case modePreview:
+		if isKey {
+			m.mode = modeList
+		}
+		return m, nil
+	}
+
+	if !isKey {
+		return m, nil
+	}
+	m.status = ""
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		m = m.toggle(m.cursor)
+	case "a":
+		m = m.toggleAll()
+	case "r":
+		m = m.startEdit(modeRename)
+	case "e":
+		m = m.startEdit(modeEditCommand)
+	case "p":
+		m.mode = modePreview
+	case "enter":
+		m.accepted = true
+		return m, tea.Quit
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// toggle flips the selection of the item at index; items with a conflict cannot be selected.
+func (m reviewModel) toggle(index int) reviewModel {
+	if index >= len(m.items) {
+		return m
+	}
+	item := &m.items[index]
+	if !item.selected && item.conflict != "" {
+		m.status = fmt.Sprintf("Cannot select '%s': %s. Press r to rename it.", item.alias.Name, item.conflict)
+		return m
+	}
+	item.selected = !item.selected
+	return m
+}
+
+// toggleAll selects every item without a conflict, or clears the selection if they all are selected.
+func (m reviewModel) toggleAll() reviewModel {
+	allSelected := true
+	for _, item := range m.items {
+		if item.conflict == "" && !item.selected {
+			allSelected = false
+		}
+	}
+	for i := range m.items {
+		m.items[i].selected = !allSelected && m.items[i].conflict == ""
+	}
+	return m
+}
+
+// startEdit switches to an edit mode, with the input set to the current value.
+func (m reviewModel) startEdit(mode reviewMode) reviewModel {
+	if m.cursor >= len(m.items) {
+		return m
+	}
+	m.mode = mode
+	m.input.Reset()
+	if mode == modeRename {
+		m.input.SetValue(m.items[m.cursor].alias.Name)
+	} else {
+		m.input.SetValue(m.items[m.cursor].alias.Command)
+	}
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.inputErr = m.validateInput()
+	return m
+}
+
+// commitInput applies the edited value if it is valid, and goes back to the list.
+func (m reviewModel) commitInput() reviewModel {
+	if m.inputErr != "" {
+		m.status = "Fix the value first, or press Esc to discard the edit."
+		return m
+	}
+	value := strings.TrimSpace(m.input.Value())
+	item := &m.items[m.cursor]
+	if m.mode == modeRename {
+		item.alias.Name = value
+	} else {
+		item.alias.Command = value
+	}
+	m.mode = modeList
+	m.input.Blur()
+	// A rename can create or solve conflicts with other items.
+	for i := range m.items {
+		m.items[i].conflict = m.nameConflict(i, m.items[i].alias.Name)
+		if m.items[i].conflict != "" {
+			m.items[i].selected = false
+		}
+	}
+	return m
+}
+
+// validateInput returns why the current input cannot be applied, or "" if it can.
+func (m reviewModel) validateInput() string {
+	value := strings.TrimSpace(m.input.Value())
+	if m.mode == modeEditCommand {
+		if value == "" {
+			return "the command cannot be empty"
+		}
+		return ""
+	}
+	return m.nameConflict(m.cursor, value)
+}
+
+// nameConflict returns why name cannot be used for the item at index, or "" if it can.
+func (m reviewModel) nameConflict(index int, name string) string {
+	if name == "" {
+		return "the name cannot be empty"
+	}
+	for i, other := range m.items {
+		if i != index && other.alias.Name == name {
+			return fmt.Sprintf("'%s' is also proposed for '%s'", name, other.alias.Command)
+		}
+	}
+	if m.validateName != nil {
+		if err := m.validateName(name); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// selectedAliases returns the selected items' aliases, in list order.
+func (m reviewModel) selectedAliases() []alias.Alias {
+	selected := []alias.Alias{}
+	for _, item := range m.items {
+		if item.selected {
+			selected = append(selected, item.alias)
+		}
+	}
+	return selected
+}
+
+// View implements tea.Model.
+func (m reviewModel) View() string {
+	if m.mode == modePreview {
+		return m.previewView()
+	}
+
+	var b strings.Builder
+	b.WriteString(ui.HeaderColor("Review alias suggestions") + "\n\n")
+	if len(m.items) == 0 {
+		b.WriteString(ui.InfoColor("No suggestions to review.") + "\n")
+	}
+	for i, item := range m.items {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = ui.PromptColor("> ")
+		}
+		check := "[ ]"
+		if item.selected {
+			check = ui.SuccessColor("[x]")
+		}
+		fmt.Fprintf(&b, "%s%s %s='%s'  %s", cursor, check,
+			ui.AliasNameColor(item.alias.Name), ui.AliasCmdColor(item.alias.Command),
+			ui.DetailColor(itemDetails(item.alias)))
+		if item.conflict != "" {
+			b.WriteString("  " + ui.ErrorColor("! "+item.conflict))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch m.mode {
+	case modeRename, modeEditCommand:
+		label := "New name: "
+		if m.mode == modeEditCommand {
+			label = "New command: "
+		}
+		b.WriteString(ui.PromptColor(label) + m.input.View() + "\n")
+		if m.inputErr != "" {
+			b.WriteString(ui.ErrorColor(m.inputErr) + "\n")
+		} else {
+			b.WriteString(ui.SuccessColor("ok") + "\n")
+		}
+		b.WriteString(ui.DetailColor("enter apply • esc discard") + "\n")
+	default:
+		if m.status != "" {
+			b.WriteString(ui.WarningColor(m.status) + "\n")
+		}
+		b.WriteString(ui.DetailColor("↑/↓ move • space select • a select all • r rename • e edit command • p preview • enter accept • q quit") + "\n")
+	}
+	return b.String()
+}
+
+// previewView renders the change accepting the current selection would make to the alias file.
+func (m reviewModel) previewView() string {
+	var b strings.Builder
+	b.WriteString(ui.HeaderColor("Preview") + "\n\n")
+	b.WriteString(ui.DetailColor("--- "+m.targetFile) + "\n")
+	b.WriteString(ui.DetailColor("+++ "+m.targetFile) + "\n")
+	selected := m.selectedAliases()
+	if len(selected) == 0 {
+		b.WriteString(ui.InfoColor("(no changes: nothing is selected)") + "\n")
+	}
+	for _, a := range selected {
+		b.WriteString(ui.SuccessColor("+"+strings.TrimSuffix(m.format(a), "\n")) + "\n")
+	}
+	b.WriteString("\n" + ui.DetailColor("press any key to go back") + "\n")
+	return b.String()
+}
+
+// itemDetails describes where a suggestion comes from, e.g. "(12×, exact command)".
+func itemDetails(a alias.Alias) string {
+	var details []string
+	if a.Frequency > 0 {
+		details = append(details, fmt.Sprintf("%d×", a.Frequency))
+	}
+	if a.Source != "" {
+		details = append(details, a.Source)
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(details, ", ") + ")"
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends keys to the model one by one; plain strings are typed as runes.
+func press(t *testing.T, m reviewModel, keys ...any) reviewModel {
+	t.Helper()
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch k := key.(type) {
+		case tea.KeyType:
+			msg = tea.KeyMsg{Type: k}
+		case string:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		default:
+			t.Fatalf("unsupported key %v", key)
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(reviewModel)
+	}
+	return m
+}
+
+func newTestModel() reviewModel {
+	return newReviewModel(ReviewOptions{
+		Suggestions: []alias.Alias{
+			{Name: "gs", Command: "git status", Frequency: 12, Source: alias.SourceSubcommand},
+			{Name: "ls", Command: "ls -la", Frequency: 5, Source: alias.SourceExactCommand},
+			{Name: "gp", Command: "git push"},
+		},
+		ValidateName: func(name string) error {
+			if name == "ls" || name == "taken" {
+				return errors.New("'" + name + "' is not a valid alias name")
+			}
+			return nil
+		},
+		TargetFile: "~/.nicksh/generated_aliases",
+		FormatDefinition: func(a alias.Alias) string {
+			return "abbr -a " + a.Name + " '" + a.Command + "'\n" // As the fish abbreviation style writes it.
+		},
+	})
+}
+
+func TestReviewModel_InitialConflicts(t *testing.T) {
+	m := newTestModel()
+	if m.items[0].conflict != "" || m.items[2].conflict != "" {
+		t.Errorf("unexpected conflicts: %q, %q", m.items[0].conflict, m.items[2].conflict)
+	}
+	if m.items[1].conflict != "'ls' is not a valid alias name" {
+		t.Errorf("items[1].conflict = %q, want validation error", m.items[1].conflict)
+	}
+}
+
+func TestReviewModel_SelectAndAccept(t *testing.T) {
+	m := press(t, newTestModel(), " ", "j", " ", "j", "x", tea.KeyEnter)
+
+	if !m.accepted {
+		t.Fatal("model not accepted after enter")
+	}
+	want := []alias.Alias{
+		{Name: "gs", Command: "git status", Frequency: 12, Source: alias.SourceSubcommand},
+		{Name: "gp", Command: "git push"},
+	}
+	if got := m.selectedAliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("selectedAliases() = %v, want %v (conflicting item must not be selectable)", got, want)
+	}
+	if !strings.Contains(press(t, newTestModel(), "j", " ").status, "Cannot select 'ls'") {
+		t.Error("selecting a conflicting item did not report why")
+	}
+}
+
+func TestReviewModel_ToggleAll(t *testing.T) {
+	m := press(t, newTestModel(), "a")
+	if got := len(m.selectedAliases()); got != 2 {
+		t.Errorf("after a, %d aliases selected, want the 2 without conflict", got)
+	}
+	m = press(t, m, "a")
+	if got := len(m.selectedAliases()); got != 0 {
+		t.Errorf("after a twice, %d aliases selected, want 0", got)
+	}
+}
+
+func TestReviewModel_Rename(t *testing.T) {
+	m := press(t, newTestModel(), "j", "r")
+	if m.mode != modeRename || m.input.Value() != "ls" {
+		t.Fatalf("rename did not start with the current name: mode %v, input %q", m.mode, m.input.Value())
+	}
+
+	// Live validation: a name proposed for another suggestion is rejected while typing.
+	m = press(t, m, tea.KeyBackspace, tea.KeyBackspace, "gs")
+	if !strings.Contains(m.inputErr, "'gs' is also proposed for 'git status'") {
+		t.Errorf("inputErr = %q, want duplicate name error", m.inputErr)
+	}
+	m = press(t, m, tea.KeyEnter)
+	if m.mode != modeRename {
+		t.Error("an invalid name was applied")
+	}
+
+	m = press(t, m, tea.KeyBackspace, "a", tea.KeyEnter)
+	if m.mode != modeList || m.items[1].alias.Name != "ga" || m.items[1].conflict != "" {
+		t.Fatalf("rename not applied: mode %v, item %+v", m.mode, m.items[1])
+	}
+	m = press(t, m, " ", tea.KeyEnter)
+	want := []alias.Alias{{Name: "ga", Command: "ls -la", Frequency: 5, Source: alias.SourceExactCommand}}
+	if got := m.selectedAliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("selectedAliases() = %v, want %v", got, want)
+	}
+}
+
+func TestReviewModel_RenameDiscarded(t *testing.T) {
+	m := press(t, newTestModel(), "r", "x", tea.KeyEsc)
+	if m.mode != modeList || m.items[0].alias.Name != "gs" {
+		t.Errorf("Esc did not discard the rename: mode %v, name %q", m.mode, m.items[0].alias.Name)
+	}
+	if m.accepted {
+		t.Error("Esc in an edit must not leave the review")
+	}
+}
+
+func TestReviewModel_EditCommand(t *testing.T) {
+	m := press(t, newTestModel(), "e", " --short", tea.KeyEnter)
+	if got := m.items[0].alias.Command; got != "git status --short" {
+		t.Errorf("command = %q, want %q", got, "git status --short")
+	}
+
+	m = press(t, m, "e")
+	for range len("git status --short") {
+		m = press(t, m, tea.KeyBackspace)
+	}
+	if m.inputErr != "the command cannot be empty" {
+		t.Errorf("inputErr = %q, want empty command error", m.inputErr)
+	}
+}
+
+func TestReviewModel_Preview(t *testing.T) {
+	m := press(t, newTestModel(), " ", "p")
+	view := m.View()
+	for _, want := range []string{"--- ~/.nicksh/generated_aliases", "+abbr -a gs 'git status'\n"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("preview does not contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "gs='git status'") {
+		t.Errorf("preview is not rendered by FormatDefinition:\n%s", view)
+	}
+	if m = press(t, m, "q"); m.mode != modeList {
+		t.Error("a key press did not leave the preview")
+	}
+}
+
+func TestReviewModel_Cancel(t *testing.T) {
+	m := press(t, newTestModel(), " ", "q")
+	if m.accepted {
+		t.Error("q accepted the review")
+	}
+}
+
+func TestReviewModel_View(t *testing.T) {
+	view := newTestModel().View()
+	for _, want := range []string{"gs='git status'", "(12×, command + argument)", "! 'ls' is not a valid alias name"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() does not contain %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package gitsync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// AliasFileName is the alias file kept in the git working copy.
+const AliasFileName = "nicksh_aliases.yaml"
+
+// baseFileName is the file, in the .git directory of the working copy, holding the
+// alias set of the last sync. It is local to the working copy and never committed.
+const baseFileName = "nicksh_sync_base.yaml"
+
+/*
+GitAliasStore keeps the alias set in a YAML file of a git working copy, such as
+a dotfiles repository, and shares it through the working copy's remote.
+It implements the ports.AliasSyncStore interface.
+*/
+type GitAliasStore struct {
+	dir       string // The directory holding the alias file, inside the working copy.
+	aliasFile string
+	baseFile  string
+	codec     ports.AliasFileCodec
+	logger    ports.Logger
+}
+
+// NewGitAliasStore opens the git working copy containing dir.
+// It returns an error if git is not installed or dir is not in a git working copy.
+// logger receives every git command run, and can be nil.
+func NewGitAliasStore(dir string, codec ports.AliasFileCodec, logger ports.Logger) (ports.AliasSyncStore, error) {
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving sync directory %s: %w", dir, err)
+	}
+	store := &GitAliasStore{dir: absDir, aliasFile: filepath.Join(absDir, AliasFileName), codec: codec, logger: logger}
+
+	gitDir, err := store.git("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git working copy: %w", dir, err)
+	}
+	store.baseFile = filepath.Join(gitDir, baseFileName)
+	return store, nil
+}
+
+// Pull implements the ports.AliasSyncStore interface.
+// The local branch is rebased onto its upstream. A working copy cloned from an empty
+// repository has no upstream yet; it is pulled from the branch of the same name on
+// its first remote, if another machine created it. Without a remote, there is nothing to pull.
+func (s *GitAliasStore) Pull() error {
+	if s.hasUpstream() {
+		return s.pullRebase()
+	}
+
+	remote, err := s.firstRemote()
+	if err != nil || remote == "" {
+		return err
+	}
+	branch, err := s.git("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return err
+	}
+	if _, err := s.git("fetch", "--quiet", remote); err != nil {
+		return err
+	}
+	if _, err := s.git("rev-parse", "--verify", "--quiet", remote+"/"+branch); err != nil {
+		return nil // Nobody has published this branch yet.
+	}
+	if err := s.pullRebase(remote, branch); err != nil {
+		return err
+	}
+	_, err = s.git("branch", "--quiet", "--set-upstream-to="+remote+"/"+branch)
+	return err
+}
+
+// LoadAliases implements the ports.AliasSyncStore interface.
+func (s *GitAliasStore) LoadAliases() ([]alias.Alias, error) {
+	aliases, _, err := s.readAliasFile(s.aliasFile)
+	return aliases, err
+}
+
+// SaveAliases implements the ports.AliasSyncStore interface.
+func (s *GitAliasStore) SaveAliases(aliases []alias.Alias) error {
+	return s.writeAliasFile(s.aliasFile, aliases)
+}
+
+// LoadBase implements the ports.AliasSyncStore interface.
+func (s *GitAliasStore) LoadBase() ([]alias.Alias, bool, error) {
+	return s.readAliasFile(s.baseFile)
+}
+
+// SaveBase implements the ports.AliasSyncStore interface.
+func (s *GitAliasStore) SaveBase(aliases []alias.Alias) error {
+	return s.writeAliasFile(s.baseFile, aliases)
+}
+
+// Publish implements the ports.AliasSyncStore interface.
+// Only the alias file is committed; other changes in the working copy are left alone.
+// Commits not on the upstream yet are pushed, including ones a failed push left behind.
+func (s *GitAliasStore) Publish(message string) (bool, bool, error) {
+	committed := false
+	if _, err := os.Stat(s.aliasFile); err == nil {
+		if _, err := s.git("add", "--", AliasFileName); err != nil {
+			return false, false, err
+		}
+		unchanged, err := s.gitSucceeds("diff", "--cached", "--quiet", "--", AliasFileName)
+		if err != nil {
+			return false, false, err
+		}
+		if !unchanged {
+			if _, err := s.git("commit", "--quiet", "-m", message, "--", AliasFileName); err != nil {
+				return false, false, err
+			}
+			committed = true
+		}
+	}
+
+	pushed, err := s.push()
+	return committed, pushed, err
+}
+
+// push pushes the commits the upstream does not have yet. A working copy without
+// an upstream is pushed to its first remote, which becomes the upstream.
+func (s *GitAliasStore) push() (bool, error) {
+	if _, err := s.git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return false, nil // Nothing was ever committed.
+	}
+	if s.hasUpstream() {
+		ahead, err := s.git("rev-list", "--count", "@{upstream}..HEAD")
+		if err != nil || ahead == "0" {
+			return false, err
+		}
+		if _, err := s.git("push", "--quiet"); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	remote, err := s.firstRemote()
+	if err != nil || remote == "" {
+		return false, err // A local-only repository: changes are committed, not pushed.
+	}
+	if _, err := s.git("push", "--quiet", "--set-upstream", remote, "HEAD"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readAliasFile decodes the aliases of path, reporting whether the file exists.
+func (s *GitAliasStore) readAliasFile(path string) ([]alias.Alias, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	aliases, err := s.codec.Decode(data, ports.AliasFileFormatYAML)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return aliases, true, nil
+}
+
+// writeAliasFile encodes aliases to path.
+func (s *GitAliasStore) writeAliasFile(path string, aliases []alias.Alias) error {
+	data, err := s.codec.Encode(aliases, ports.AliasFileFormatYAML)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package gitsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// git runs a git command in the store directory and returns its trimmed standard output.
+// A failure is reported with git's error message.
+func (s *GitAliasStore) git(args ...string) (string, error) {
+	s.logger.Debug("running git", "dir", s.dir, "args", strings.Join(args, " "))
+	cmd := exec.Command("git", append([]string{"-C", s.dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, message)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitSucceeds runs a git command that answers a question through its exit code,
+// such as 'git diff --quiet'. Exit code 0 is true, 1 is false, anything else is an error.
+func (s *GitAliasStore) gitSucceeds(args ...string) (bool, error) {
+	_, err := s.git(args...)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// hasUpstream reports whether the current branch tracks a remote branch.
+func (s *GitAliasStore) hasUpstream() bool {
+	_, err := s.git("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	return err == nil
+}
+
+// firstRemote returns the name of the first configured remote, or "" if there is none.
+func (s *GitAliasStore) firstRemote() (string, error) {
+	remotes, err := s.git("remote")
+	if err != nil || remotes == "" {
+		return "", err
+	}
+	return strings.Fields(remotes)[0], nil
+}
+
+// pullRebase rebases the current branch onto the remote changes (the upstream if
+// no remote and branch are given). A failed rebase is aborted, leaving the working copy as it was.
+func (s *GitAliasStore) pullRebase(remoteAndBranch ...string) error {
+	args := append([]string{"pull", "--rebase", "--quiet"}, remoteAndBranch...)
+	if _, err := s.git(args...); err != nil {
+		_, _ = s.git("rebase", "--abort")
+		return err
+	}
+	return nil
+}
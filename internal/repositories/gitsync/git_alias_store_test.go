@@ -0,0 +1,154 @@
+package gitsync
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/adapters/aliasfile"
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// runGit runs a git command for test setup, failing the test on error.
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+// newClone clones the repository at remote into a new directory, with a commit identity.
+func newClone(t *testing.T, remote string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "dotfiles")
+	runGit(t, "clone", "--quiet", remote, dir)
+	runGit(t, "-C", dir, "config", "user.name", "nicksh test")
+	runGit(t, "-C", dir, "config", "user.email", "test@example.com")
+	return dir
+}
+
+// openStore opens the store of dir, failing the test on error.
+func openStore(t *testing.T, dir string) ports.AliasSyncStore {
+	t.Helper()
+	store, err := NewGitAliasStore(dir, aliasfile.NewCodec(), nil)
+	if err != nil {
+		t.Fatalf("NewGitAliasStore() unexpected error: %v", err)
+	}
+	return store
+}
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+}
+
+func TestNewGitAliasStore_NotAWorkingCopy(t *testing.T) {
+	requireGit(t)
+	_, err := NewGitAliasStore(t.TempDir(), aliasfile.NewCodec(), nil)
+	if err == nil || !strings.Contains(err.Error(), "is not a git working copy") {
+		t.Errorf("NewGitAliasStore() error = %v, want an error about the working copy", err)
+	}
+}
+
+func TestGitAliasStore_SyncThroughBareRepository(t *testing.T) {
+	requireGit(t)
+	remote := filepath.Join(t.TempDir(), "dotfiles.git")
+	runGit(t, "init", "--quiet", "--bare", remote)
+	laptop := openStore(t, newClone(t, remote))
+	desktop := openStore(t, newClone(t, remote))
+
+	// Nothing was published yet: pulling an empty repository is not an error.
+	if err := laptop.Pull(); err != nil {
+		t.Fatalf("Pull() on an empty repository unexpected error: %v", err)
+	}
+	if aliases, err := laptop.LoadAliases(); err != nil || len(aliases) != 0 {
+		t.Fatalf("LoadAliases() = %v, %v, want no aliases", aliases, err)
+	}
+
+	laptopAliases := []alias.Alias{{Name: "gs", Command: "git status", Group: "git"}}
+	if err := laptop.SaveAliases(laptopAliases); err != nil {
+		t.Fatalf("SaveAliases() unexpected error: %v", err)
+	}
+	if committed, pushed, err := laptop.Publish("laptop aliases"); err != nil || !committed || !pushed {
+		t.Fatalf("Publish() = %v, %v, %v, want committed and pushed", committed, pushed, err)
+	}
+
+	// The desktop clone has no upstream yet; it picks up the branch the laptop created.
+	if err := desktop.Pull(); err != nil {
+		t.Fatalf("Pull() unexpected error: %v", err)
+	}
+	got, err := desktop.LoadAliases()
+	if err != nil || !reflect.DeepEqual(got, laptopAliases) {
+		t.Fatalf("LoadAliases() after pull = %v, %v, want %v", got, err, laptopAliases)
+	}
+
+	desktopAliases := append(laptopAliases, alias.Alias{Name: "ll", Command: "ls -l"})
+	if err := desktop.SaveAliases(desktopAliases); err != nil {
+		t.Fatalf("SaveAliases() unexpected error: %v", err)
+	}
+	if committed, pushed, err := desktop.Publish("desktop aliases"); err != nil || !committed || !pushed {
+		t.Fatalf("Publish() = %v, %v, %v, want committed and pushed", committed, pushed, err)
+	}
+	if committed, pushed, err := desktop.Publish("nothing new"); err != nil || committed || pushed {
+		t.Errorf("Publish() without changes = %v, %v, %v, want nothing committed or pushed", committed, pushed, err)
+	}
+
+	if err := laptop.Pull(); err != nil {
+		t.Fatalf("Pull() unexpected error: %v", err)
+	}
+	if got, err := laptop.LoadAliases(); err != nil || !reflect.DeepEqual(got, desktopAliases) {
+		t.Errorf("LoadAliases() after pull = %v, %v, want %v", got, err, desktopAliases)
+	}
+}
+
+func TestGitAliasStore_LocalRepository(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	runGit(t, "init", "--quiet", dir)
+	runGit(t, "-C", dir, "config", "user.name", "nicksh test")
+	runGit(t, "-C", dir, "config", "user.email", "test@example.com")
+	subdir := filepath.Join(dir, "shell")
+	if err := os.Mkdir(subdir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", subdir, err)
+	}
+	store := openStore(t, subdir)
+
+	if err := store.Pull(); err != nil {
+		t.Fatalf("Pull() without a remote unexpected error: %v", err)
+	}
+	if committed, pushed, err := store.Publish("empty"); err != nil || committed || pushed {
+		t.Fatalf("Publish() without an alias file = %v, %v, %v, want nothing done", committed, pushed, err)
+	}
+	if err := store.SaveAliases([]alias.Alias{{Name: "ll", Command: "ls -l"}}); err != nil {
+		t.Fatalf("SaveAliases() unexpected error: %v", err)
+	}
+	if committed, pushed, err := store.Publish("add ll"); err != nil || !committed || pushed {
+		t.Errorf("Publish() = %v, %v, %v, want committed but not pushed", committed, pushed, err)
+	}
+	if _, err := os.Stat(filepath.Join(subdir, AliasFileName)); err != nil {
+		t.Errorf("alias file not written to the sync directory: %v", err)
+	}
+
+	t.Run("base is kept out of the working tree", func(t *testing.T) {
+		if _, ok, err := store.LoadBase(); err != nil || ok {
+			t.Fatalf("LoadBase() before any sync = %v, %v, want no base", ok, err)
+		}
+		base := []alias.Alias{{Name: "ll", Command: "ls -l"}}
+		if err := store.SaveBase(base); err != nil {
+			t.Fatalf("SaveBase() unexpected error: %v", err)
+		}
+		got, ok, err := store.LoadBase()
+		if err != nil || !ok || !reflect.DeepEqual(got, base) {
+			t.Errorf("LoadBase() = %v, %v, %v, want %v", got, ok, err, base)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git", baseFileName)); err != nil {
+			t.Errorf("base not stored in the .git directory: %v", err)
+		}
+	})
+}
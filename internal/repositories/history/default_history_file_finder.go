@@ -10,6 +10,11 @@ func (d *DefaultHistoryFileFinder) Find() (string, error) {
 	return findUserHistoryFile() // Calls your existing global/package-level function
 }
 
+// FindAll implements the ports.HistoryFileFinder interface.
+func (d *DefaultHistoryFileFinder) FindAll() ([]string, error) {
+	return findUserHistoryFiles()
+}
+
 // NewDefaultHistoryFileFinder creates a new DefaultHistoryFileFinder.
 func NewDefaultHistoryFileFinder() ports.HistoryFileFinder {
 	return &DefaultHistoryFileFinder{}
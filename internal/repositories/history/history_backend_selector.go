@@ -0,0 +1,156 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+const (
+	autoHistoryBackend = "auto"
+	fileHistoryBackend = "file"
+)
+
+/*
+HistoryBackendSelector is a ports.HistoryProvider that delegates to the selected
+history backend: the plain history file, or one of the structured SQLite backends.
+With "auto", structured backends are preferred, since they record directories and
+exit codes, and the history file is used when none of them is found (or when
+history files were selected explicitly).
+It also implements the ports.HistoryBackendSelector and ports.HistorySourceSelector interfaces.
+*/
+type HistoryBackendSelector struct {
+	fileProvider    ports.HistoryProvider
+	newStructured   func(backendName string) (ports.HistoryProvider, error)
+	selected        ports.HistoryProvider
+	explicitSources bool // History files were given explicitly, so "auto" means the file provider.
+	logger          ports.Logger
+}
+
+// NewHistoryBackendSelector creates a HistoryBackendSelector falling back to fileProvider.
+// Structured backends apply exclusions, which can be nil. logger can be nil too.
+func NewHistoryBackendSelector(fileProvider ports.HistoryProvider, exclusions *HistoryExclusions, logger ports.Logger) *HistoryBackendSelector {
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &HistoryBackendSelector{
+		fileProvider: fileProvider,
+		newStructured: func(backendName string) (ports.HistoryProvider, error) {
+			return NewStructuredHistoryProvider(backendName, exclusions, logger)
+		},
+		logger: logger,
+	}
+}
+
+// SelectHistoryBackend implements the ports.HistoryBackendSelector interface.
+func (s *HistoryBackendSelector) SelectHistoryBackend(name string) error {
+	switch name {
+	case "", autoHistoryBackend:
+		s.selected = s.detect()
+		return nil
+	case fileHistoryBackend:
+		s.selected = s.fileProvider
+		return nil
+	}
+
+	if _, ok := findSQLiteBackend(name); !ok {
+		return fmt.Errorf("unknown history backend '%s' (available: %s)", name, strings.Join(s.AvailableHistoryBackends(), ", "))
+	}
+	if s.explicitSources {
+		return fmt.Errorf("history files cannot be combined with the '%s' history backend", name)
+	}
+	provider, err := s.newStructured(name)
+	if err != nil {
+		return fmt.Errorf("selecting history backend '%s': %w", name, err)
+	}
+	s.selected = provider
+	return nil
+}
+
+// AvailableHistoryBackends implements the ports.HistoryBackendSelector interface.
+func (s *HistoryBackendSelector) AvailableHistoryBackends() []string {
+	names := []string{autoHistoryBackend, fileHistoryBackend}
+	for _, backend := range sqliteBackends {
+		names = append(names, backend.name)
+	}
+	return names
+}
+
+// SelectHistorySources implements the ports.HistorySourceSelector interface.
+// The sources are selected on the file provider. Call it before SelectHistoryBackend.
+func (s *HistoryBackendSelector) SelectHistorySources(specs []string) error {
+	sourceSelector, ok := s.fileProvider.(ports.HistorySourceSelector)
+	if !ok {
+		if len(specs) > 0 {
+			return fmt.Errorf("the history file provider does not support selecting history files")
+		}
+		return nil
+	}
+	if err := sourceSelector.SelectHistorySources(specs); err != nil {
+		return err
+	}
+	s.explicitSources = len(specs) > 0
+	return nil
+}
+
+// detect returns the first structured backend whose database exists, or the file provider.
+func (s *HistoryBackendSelector) detect() ports.HistoryProvider {
+	if s.explicitSources {
+		s.logger.Debug("using the history file backend, as history files were given")
+		return s.fileProvider
+	}
+	for _, backend := range sqliteBackends {
+		provider, err := s.newStructured(backend.name)
+		if err == nil {
+			s.logger.Debug("detected history backend", "backend", backend.name)
+			return provider
+		}
+		s.logger.Debug("history backend not available", "backend", backend.name, "reason", err)
+	}
+	s.logger.Debug("using the history file backend, as no structured backend was found")
+	return s.fileProvider
+}
+
+// provider returns the selected provider, auto-detecting one if none was selected.
+func (s *HistoryBackendSelector) provider() ports.HistoryProvider {
+	if s.selected == nil {
+		s.selected = s.detect()
+	}
+	return s.selected
+}
+
+// GetCommandFrequencies implements the ports.HistoryProvider interface.
+func (s *HistoryBackendSelector) GetCommandFrequencies(scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
+	return s.provider().GetCommandFrequencies(scanLimit, outputLimit)
+}
+
+// GetCommandFrequenciesInDir implements the ports.HistoryProvider interface.
+func (s *HistoryBackendSelector) GetCommandFrequenciesInDir(dir string, scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
+	return s.provider().GetCommandFrequenciesInDir(dir, scanLimit, outputLimit)
+}
+
+// GetEntries implements the ports.HistoryProvider interface.
+func (s *HistoryBackendSelector) GetEntries(scanLimit int) ([]history.Entry, error) {
+	return s.provider().GetEntries(scanLimit)
+}
+
+// ExplainExclusions implements the ports.HistoryProvider interface.
+func (s *HistoryBackendSelector) ExplainExclusions(scanLimit int) (history.ExclusionReport, error) {
+	return s.provider().ExplainExclusions(scanLimit)
+}
+
+// GetHistoryFilePath implements the ports.HistoryProvider interface.
+func (s *HistoryBackendSelector) GetHistoryFilePath() string {
+	return s.provider().GetHistoryFilePath()
+}
+
+// GetSourceIdentifier implements the ports.HistoryProvider interface.
+func (s *HistoryBackendSelector) GetSourceIdentifier() string {
+	return s.provider().GetSourceIdentifier()
+}
+
+var _ ports.HistoryProvider = (*HistoryBackendSelector)(nil)
+var _ ports.HistoryBackendSelector = (*HistoryBackendSelector)(nil)
+var _ ports.HistorySourceSelector = (*HistoryBackendSelector)(nil)
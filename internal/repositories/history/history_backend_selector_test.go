@@ -0,0 +1,98 @@
+package history
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+// newTestSelector returns a selector whose structured backends are stubbed:
+// only the backends in available can be opened.
+func newTestSelector(available map[string]ports.HistoryProvider) (*HistoryBackendSelector, ports.HistoryProvider) {
+	fileProvider := &testutil.MockHistoryProvider{GetSourceIdentifierFunc: func() string { return "file" }}
+	selector := NewHistoryBackendSelector(fileProvider, nil, nil)
+	selector.newStructured = func(name string) (ports.HistoryProvider, error) {
+		if provider, ok := available[name]; ok {
+			return provider, nil
+		}
+		return nil, errors.New("database not found")
+	}
+	return selector, fileProvider
+}
+
+func sourceProvider(source string) ports.HistoryProvider {
+	return &testutil.MockHistoryProvider{GetSourceIdentifierFunc: func() string { return source }}
+}
+
+func TestHistoryBackendSelector_SelectHistoryBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		available   map[string]ports.HistoryProvider
+		backend     string
+		wantSource  string
+		wantErrText string
+	}{
+		{
+			name:       "auto prefers the first structured backend found",
+			available:  map[string]ports.HistoryProvider{"histdb": sourceProvider("histdb"), "mcfly": sourceProvider("mcfly")},
+			backend:    "auto",
+			wantSource: "histdb",
+		},
+		{
+			name:       "auto falls back to the history file",
+			backend:    "",
+			wantSource: "file",
+		},
+		{
+			name:       "file ignores structured backends",
+			available:  map[string]ports.HistoryProvider{"atuin": sourceProvider("atuin")},
+			backend:    "file",
+			wantSource: "file",
+		},
+		{
+			name:       "explicit backend",
+			available:  map[string]ports.HistoryProvider{"atuin": sourceProvider("atuin"), "mcfly": sourceProvider("mcfly")},
+			backend:    "mcfly",
+			wantSource: "mcfly",
+		},
+		{
+			name:        "explicit backend not found",
+			backend:     "atuin",
+			wantErrText: "selecting history backend 'atuin': database not found",
+		},
+		{
+			name:        "unknown backend",
+			backend:     "fish",
+			wantErrText: "unknown history backend 'fish' (available: auto, file, atuin, histdb, mcfly)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, _ := newTestSelector(tt.available)
+			err := selector.SelectHistoryBackend(tt.backend)
+			if tt.wantErrText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Fatalf("SelectHistoryBackend(%q) error = %v, want %q", tt.backend, err, tt.wantErrText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectHistoryBackend(%q) unexpected error: %v", tt.backend, err)
+			}
+			if got := selector.GetSourceIdentifier(); got != tt.wantSource {
+				t.Errorf("GetSourceIdentifier() = %q, want %q", got, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestHistoryBackendSelector_DetectsWhenNotSelected(t *testing.T) {
+	selector, _ := newTestSelector(map[string]ports.HistoryProvider{"atuin": sourceProvider("atuin")})
+	if got := selector.GetSourceIdentifier(); got != "atuin" {
+		t.Errorf("GetSourceIdentifier() = %q, want %q", got, "atuin")
+	}
+}
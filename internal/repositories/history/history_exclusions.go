@@ -0,0 +1,297 @@
+package history
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"gopkg.in/yaml.v3"
+)
+
+// ExclusionsFileEnv overrides the location of the user's history exclusions file.
+const ExclusionsFileEnv = "NICKSH_HISTORY_EXCLUSIONS"
+
+// histIgnorePrevious is the HISTIGNORE pattern matching a repeat of the previous history line.
+const histIgnorePrevious = "&"
+
+/*
+HistoryExclusions removes history entries that should not count towards
+suggestions: those matched by bash's HISTIGNORE, zsh's HISTORY_IGNORE, or the
+globs and regular expressions of the user's exclusions file.
+A nil *HistoryExclusions excludes nothing.
+*/
+type HistoryExclusions struct {
+	rules []exclusionRule
+	// err is why the exclusions could not be loaded. History reads fail with it,
+	// rather than counting entries the user meant to exclude.
+	err error
+}
+
+// exclusionRule is a single exclusion pattern and where it was configured.
+type exclusionRule struct {
+	origin  string
+	pattern string
+	// matches reports whether command should be excluded; previous is the command before it.
+	matches func(command, previous string) bool
+}
+
+// exclusionsFile is the schema of the user's exclusions file.
+type exclusionsFile struct {
+	Globs   []string `yaml:"globs"`
+	Regexes []string `yaml:"regexes"`
+}
+
+// LoadHistoryExclusions builds the exclusion rules from the HISTIGNORE and HISTORY_IGNORE
+// environment variables and the user's exclusions file (see DefaultExclusionsFile).
+// Shells don't export those variables by default; they only apply when exported.
+// If they can't be loaded, the error is returned along with exclusions that fail every
+// history read with it, so commands that don't read history keep working.
+func LoadHistoryExclusions() (*HistoryExclusions, error) {
+	exclusions, err := loadHistoryExclusions()
+	if err != nil {
+		return &HistoryExclusions{err: fmt.Errorf("loading history exclusions: %w", err)}, err
+	}
+	return exclusions, nil
+}
+
+// loadHistoryExclusions builds the exclusion rules for LoadHistoryExclusions.
+func loadHistoryExclusions() (*HistoryExclusions, error) {
+	exclusionsPath, err := DefaultExclusionsFile()
+	if err != nil {
+		return nil, err
+	}
+	userExclusions, err := readExclusionsFile(exclusionsPath)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoryExclusions(os.Getenv("HISTIGNORE"), os.Getenv("HISTORY_IGNORE"), userExclusions)
+}
+
+// DefaultExclusionsFile returns the path of the user's exclusions file: $NICKSH_HISTORY_EXCLUSIONS,
+// or history_exclusions.yaml in the nicksh directory of the user's configuration directory
+// (e.g. ~/.config/nicksh/history_exclusions.yaml).
+func DefaultExclusionsFile() (string, error) {
+	if envPath := os.Getenv(ExclusionsFileEnv); envPath != "" {
+		return envPath, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating the history exclusions file: %w", err)
+	}
+	return filepath.Join(configDir, "nicksh", "history_exclusions.yaml"), nil
+}
+
+// readExclusionsFile parses the exclusions file at path. A missing or empty file defines no exclusions.
+func readExclusionsFile(path string) (exclusionsFile, error) {
+	var parsed exclusionsFile
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return parsed, nil
+		}
+		return parsed, fmt.Errorf("reading history exclusions file %s: %w", toUserFriendlyPath(path), err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true) // Catch typos in the YAML structure
+	if err := decoder.Decode(&parsed); err != nil && !errors.Is(err, io.EOF) {
+		return parsed, fmt.Errorf("parsing history exclusions file %s: %w", toUserFriendlyPath(path), err)
+	}
+	return parsed, nil
+}
+
+// newHistoryExclusions compiles the exclusion rules, in the order they are checked:
+// HISTIGNORE patterns, the HISTORY_IGNORE pattern, then the user's globs and regexes.
+func newHistoryExclusions(histIgnore, historyIgnore string, userExclusions exclusionsFile) (*HistoryExclusions, error) {
+	exclusions := &HistoryExclusions{}
+
+	for _, pattern := range splitHistIgnore(histIgnore) {
+		if pattern == histIgnorePrevious {
+			exclusions.rules = append(exclusions.rules, exclusionRule{
+				origin:  "HISTIGNORE",
+				pattern: pattern,
+				matches: func(command, previous string) bool { return command == previous },
+			})
+			continue
+		}
+		if err := exclusions.addGlob("HISTIGNORE", pattern, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if historyIgnore != "" {
+		if err := exclusions.addGlob("HISTORY_IGNORE", historyIgnore, true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, glob := range userExclusions.Globs {
+		if err := exclusions.addGlob("glob", glob, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pattern := range userExclusions.Regexes {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid history exclusion regex '%s': %w", pattern, err)
+		}
+		exclusions.rules = append(exclusions.rules, exclusionRule{
+			origin:  "regex",
+			pattern: pattern,
+			matches: func(command, _ string) bool { return re.MatchString(command) },
+		})
+	}
+	return exclusions, nil
+}
+
+// addGlob adds a rule excluding the commands matched, as a whole, by glob.
+func (e *HistoryExclusions) addGlob(origin, glob string, alternation bool) error {
+	re, err := globToRegexp(glob, alternation)
+	if err != nil {
+		return fmt.Errorf("invalid %s pattern '%s': %w", origin, glob, err)
+	}
+	e.rules = append(e.rules, exclusionRule{
+		origin:  origin,
+		pattern: glob,
+		matches: func(command, _ string) bool { return re.MatchString(command) },
+	})
+	return nil
+}
+
+// active reports whether any exclusion rule is configured.
+func (e *HistoryExclusions) active() bool {
+	return e != nil && len(e.rules) > 0
+}
+
+// apply returns the entries not matched by any rule, and how many entries each rule removed.
+// Each removed entry is logged to logger, with the rule that removed it.
+// It fails if the exclusions could not be loaded.
+func (e *HistoryExclusions) apply(entries []history.Entry, logger ports.Logger) ([]history.Entry, history.ExclusionReport, error) {
+	report := history.ExclusionReport{ScannedEntries: len(entries)}
+	if e != nil && e.err != nil {
+		return nil, history.ExclusionReport{}, e.err
+	}
+	if !e.active() {
+		return entries, report, nil
+	}
+
+	report.Rules = make([]history.ExclusionStat, len(e.rules))
+	for i, rule := range e.rules {
+		report.Rules[i] = history.ExclusionStat{Origin: rule.origin, Pattern: rule.pattern}
+	}
+
+	kept := make([]history.Entry, 0, len(entries))
+	previous := ""
+	for _, entry := range entries {
+		excluded := false
+		for i, rule := range e.rules {
+			if rule.matches(entry.Command, previous) {
+				report.Rules[i].Removed++
+				logger.Debug("skipped excluded history entry", "command", entry.Command, "origin", rule.origin, "pattern", rule.pattern)
+				excluded = true
+				break
+			}
+		}
+		previous = entry.Command
+		if !excluded {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, report, nil
+}
+
+// splitHistIgnore splits a HISTIGNORE value on its colons. A backslash-escaped colon is kept in the pattern.
+func splitHistIgnore(histIgnore string) []string {
+	var patterns []string
+	var current strings.Builder
+	for i := 0; i < len(histIgnore); i++ {
+		switch {
+		case histIgnore[i] == '\\' && i+1 < len(histIgnore) && histIgnore[i+1] == ':':
+			current.WriteByte(':')
+			i++
+		case histIgnore[i] == ':':
+			patterns = append(patterns, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(histIgnore[i])
+		}
+	}
+	patterns = append(patterns, current.String())
+
+	nonEmpty := patterns[:0]
+	for _, pattern := range patterns {
+		if pattern != "" {
+			nonEmpty = append(nonEmpty, pattern)
+		}
+	}
+	return nonEmpty
+}
+
+// globToRegexp converts a shell glob into a regular expression matching whole commands:
+// * matches any string, ? any character, [...] (or [!...]) a character class and a
+// backslash escapes the next character. With alternation, zsh's (a|b) groups are supported too.
+func globToRegexp(glob string, alternation bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString(`^(?s:`)
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case c == '*':
+			b.WriteString(`.*`)
+		case c == '?':
+			b.WriteString(`.`)
+		case c == '[':
+			class, width, ok := globCharClass(glob[i:])
+			if !ok {
+				b.WriteString(`\[`)
+				continue
+			}
+			b.WriteString(class)
+			i += width - 1
+		case alternation && c == '(':
+			b.WriteString(`(?:`)
+		case alternation && (c == '|' || c == ')'):
+			b.WriteByte(c)
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString(`)$`)
+	return regexp.Compile(b.String())
+}
+
+// globCharClass converts the character class at the start of glob and returns it
+// with the number of glob bytes it spans. ok is false if the class is not closed.
+func globCharClass(glob string) (class string, width int, ok bool) {
+	j := 1
+	negated := j < len(glob) && (glob[j] == '!' || glob[j] == '^')
+	if negated {
+		j++
+	}
+	contentStart := j
+	if j < len(glob) && glob[j] == ']' {
+		j++ // A leading ] is part of the class.
+	}
+	end := strings.IndexByte(glob[j:], ']')
+	if end < 0 {
+		return "", 0, false
+	}
+	end += j
+
+	content := strings.ReplaceAll(glob[contentStart:end], `\`, `\\`)
+	if negated {
+		return "[^" + content + "]", end + 1, true
+	}
+	return "[" + content + "]", end + 1, true
+}
@@ -0,0 +1,208 @@
+package history
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		name        string
+		glob        string
+		alternation bool
+		matches     []string
+		nonMatches  []string
+	}{
+		{name: "literal matches the whole command", glob: "ls", matches: []string{"ls"}, nonMatches: []string{"ls -la", "als"}},
+		{name: "star", glob: "ls *", matches: []string{"ls -la", "ls "}, nonMatches: []string{"ls"}},
+		{name: "question mark", glob: "c?", matches: []string{"cd"}, nonMatches: []string{"c", "cdd"}},
+		{name: "character class", glob: "[bf]g", matches: []string{"bg", "fg"}, nonMatches: []string{"cg"}},
+		{name: "negated character class", glob: "[!bf]g", matches: []string{"cg"}, nonMatches: []string{"bg"}},
+		{name: "unclosed bracket is literal", glob: "echo [", matches: []string{"echo ["}},
+		{name: "escaped star", glob: `echo \*`, matches: []string{"echo *"}, nonMatches: []string{"echo a"}},
+		{name: "regex metacharacters are literal", glob: "cd ..", matches: []string{"cd .."}, nonMatches: []string{"cd ab"}},
+		{name: "star spans lines", glob: "echo*", matches: []string{"echo one\ntwo"}},
+		{name: "zsh alternation", glob: "(ls|cd|pwd)", alternation: true, matches: []string{"ls", "pwd"}, nonMatches: []string{"ls -la"}},
+		{name: "parenthesis without alternation is literal", glob: "(ls|cd)", matches: []string{"(ls|cd)"}, nonMatches: []string{"ls"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := globToRegexp(tt.glob, tt.alternation)
+			if err != nil {
+				t.Fatalf("globToRegexp(%q) unexpected error: %v", tt.glob, err)
+			}
+			for _, command := range tt.matches {
+				if !re.MatchString(command) {
+					t.Errorf("globToRegexp(%q) does not match %q", tt.glob, command)
+				}
+			}
+			for _, command := range tt.nonMatches {
+				if re.MatchString(command) {
+					t.Errorf("globToRegexp(%q) unexpectedly matches %q", tt.glob, command)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitHistIgnore(t *testing.T) {
+	got := splitHistIgnore(`ls:&::[bf]g:echo a\:b`)
+	want := []string{"ls", "&", "[bf]g", "echo a:b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitHistIgnore() = %q, want %q", got, want)
+	}
+}
+
+func TestHistoryExclusions_Apply(t *testing.T) {
+	exclusions, err := newHistoryExclusions("ls:&", "(clear|exit)", exclusionsFile{
+		Globs:   []string{"cd *"},
+		Regexes: []string{`(?i)password`, `^ls`},
+	})
+	if err != nil {
+		t.Fatalf("newHistoryExclusions() unexpected error: %v", err)
+	}
+
+	entries := []history.Entry{
+		{Command: "ls"}, {Command: "git status"}, {Command: "git status"}, {Command: "clear"},
+		{Command: "cd src"}, {Command: "export PASSWORD=hunter2"}, {Command: "make"}, {Command: "ls -la"},
+	}
+	kept, report, err := exclusions.apply(entries, ports.NopLogger)
+	if err != nil {
+		t.Fatalf("apply() unexpected error: %v", err)
+	}
+
+	wantKept := []history.Entry{{Command: "git status"}, {Command: "make"}}
+	if !reflect.DeepEqual(kept, wantKept) {
+		t.Errorf("apply() kept = %v, want %v", kept, wantKept)
+	}
+	wantReport := history.ExclusionReport{
+		ScannedEntries: 8,
+		Rules: []history.ExclusionStat{
+			{Origin: "HISTIGNORE", Pattern: "ls", Removed: 1},
+			{Origin: "HISTIGNORE", Pattern: "&", Removed: 1},
+			{Origin: "HISTORY_IGNORE", Pattern: "(clear|exit)", Removed: 1},
+			{Origin: "glob", Pattern: "cd *", Removed: 1},
+			{Origin: "regex", Pattern: "(?i)password", Removed: 1},
+			{Origin: "regex", Pattern: "^ls", Removed: 1},
+		},
+	}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Errorf("apply() report = %+v, want %+v", report, wantReport)
+	}
+
+	t.Run("nil exclusions keep everything", func(t *testing.T) {
+		var none *HistoryExclusions
+		kept, report, err := none.apply(entries, ports.NopLogger)
+		if err != nil || !reflect.DeepEqual(kept, entries) || report.ScannedEntries != len(entries) || len(report.Rules) != 0 {
+			t.Errorf("apply() on nil exclusions = %v, %+v, %v", kept, report, err)
+		}
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		_, err := newHistoryExclusions("", "", exclusionsFile{Regexes: []string{"("}})
+		if err == nil || !strings.Contains(err.Error(), "invalid history exclusion regex") {
+			t.Errorf("newHistoryExclusions() error = %v, want invalid regex error", err)
+		}
+	})
+}
+
+func TestReadExclusionsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		missing bool
+		want    exclusionsFile
+		wantErr bool
+	}{
+		{name: "missing file", missing: true},
+		{name: "empty file", content: "# only comments\n"},
+		{
+			name:    "globs and regexes",
+			content: "globs:\n  - ls\n  - 'cd *'\nregexes:\n  - 'token=\\S+'\n",
+			want:    exclusionsFile{Globs: []string{"ls", "cd *"}, Regexes: []string{`token=\S+`}},
+		},
+		{name: "unknown field", content: "glob:\n  - ls\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history_exclusions.yaml")
+			if !tt.missing {
+				manageTestFile(t, path, []byte(tt.content))
+			}
+			got, err := readExclusionsFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readExclusionsFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readExclusionsFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryProvider_GetCommandFrequenciesWithExclusions(t *testing.T) {
+	historyFilePath := filepath.Join(t.TempDir(), ".bash_history")
+	manageTestFile(t, historyFilePath, []byte("ls\ngit status\nls\ngit status\nclear\n"))
+
+	exclusions, err := newHistoryExclusions("ls:clear", "", exclusionsFile{})
+	if err != nil {
+		t.Fatalf("newHistoryExclusions() unexpected error: %v", err)
+	}
+	provider := &HistoryProvider{
+		Shell:       "bash",
+		HistoryFile: historyFilePath,
+		exclusions:  exclusions,
+	}
+
+	got, err := provider.GetCommandFrequencies(0, 10)
+	if err != nil {
+		t.Fatalf("GetCommandFrequencies() unexpected error: %v", err)
+	}
+	want := []history.CommandFrequency{{Command: "git status", Count: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCommandFrequencies() = %v, want %v", got, want)
+	}
+
+	report, err := provider.ExplainExclusions(0)
+	if err != nil {
+		t.Fatalf("ExplainExclusions() unexpected error: %v", err)
+	}
+	if report.ScannedEntries != 5 || report.Rules[0].Removed != 2 || report.Rules[1].Removed != 1 {
+		t.Errorf("ExplainExclusions() = %+v, want 5 scanned, 2 'ls' and 1 'clear' removed", report)
+	}
+}
+
+func TestLoadHistoryExclusions_MalformedFile(t *testing.T) {
+	historyFilePath := filepath.Join(t.TempDir(), ".bash_history")
+	manageTestFile(t, historyFilePath, []byte("ls\ngit status\n"))
+	exclusionsPath := filepath.Join(t.TempDir(), "history_exclusions.yaml")
+	manageTestFile(t, exclusionsPath, []byte("glob:\n  - ls\n"))
+	t.Setenv(ExclusionsFileEnv, exclusionsPath)
+
+	exclusions, err := LoadHistoryExclusions()
+	if err == nil {
+		t.Fatal("LoadHistoryExclusions() expected an error for a malformed exclusions file")
+	}
+	provider := &HistoryProvider{
+		Shell:       "bash",
+		HistoryFile: historyFilePath,
+		exclusions:  exclusions,
+	}
+
+	if _, err := provider.GetCommandFrequencies(0, 10); err == nil || !strings.Contains(err.Error(), "loading history exclusions") {
+		t.Errorf("GetCommandFrequencies() error = %v, want the exclusions loading error", err)
+	}
+	if _, err := provider.GetEntries(0); err == nil {
+		t.Error("GetEntries() expected the exclusions loading error")
+	}
+	if _, err := provider.ExplainExclusions(0); err == nil {
+		t.Error("ExplainExclusions() expected the exclusions loading error")
+	}
+}
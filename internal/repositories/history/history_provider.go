@@ -16,12 +16,26 @@ It implements the ports.HistoryProvider interface.
 */
 type HistoryProvider struct {
 	Shell            string
-	HistoryFile      string // Stores the absolute path
-	cmdExecutor      ports.CommandExecutor
-	sourceIdentifier string // Stores the user-friendly source identifier
+	HistoryFile      string             // Stores the absolute path
+	sourceIdentifier string             // Stores the user-friendly source identifier
+	exclusions       *HistoryExclusions // Entries to leave out of frequencies; nil excludes nothing.
+	fileFinder       ports.HistoryFileFinder
+	sources          []historySource // Selected by SelectHistorySources; empty means HistoryFile only.
+	logger           ports.Logger    // Can be nil, in which case nothing is logged.
+}
+
+// log returns the provider's logger, or ports.NopLogger if it has none.
+func (hp *HistoryProvider) log() ports.Logger {
+	if hp.logger == nil {
+		return ports.NopLogger
+	}
+	return hp.logger
 }
 
 func (hp *HistoryProvider) GetSourceIdentifier() string {
+	if hp.mergesSources() {
+		return hp.sourcesIdentifier()
+	}
 	if hp.sourceIdentifier != "" {
 		return hp.sourceIdentifier
 	}
@@ -33,7 +47,9 @@ func (hp *HistoryProvider) GetSourceIdentifier() string {
 }
 
 // NewHistoryProvider creates a new FileBasedHistoryProvider.
-func NewHistoryProvider(cmdExecutor ports.CommandExecutor, fileFinder ports.HistoryFileFinder) (ports.HistoryProvider, error) {
+// exclusions can be nil if no history entries should be excluded.
+// logger can be nil if nothing should be logged.
+func NewHistoryProvider(fileFinder ports.HistoryFileFinder, exclusions *HistoryExclusions, logger ports.Logger) (ports.HistoryProvider, error) {
 	shellPath := os.Getenv("SHELL")
 	if shellPath == "" {
 		return nil, fmt.Errorf("SHELL environment variable not set")
@@ -43,11 +59,15 @@ func NewHistoryProvider(cmdExecutor ports.CommandExecutor, fileFinder ports.Hist
 	histFilePath, err := fileFinder.Find()
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not automatically find a history file: %v. History-based suggestions might be unavailable.\n", err)
+		if logger != nil {
+			logger.Warn(fmt.Sprintf("could not automatically find a history file: %v. History-based suggestions might be unavailable.", err))
+		}
 		return &HistoryProvider{
 			Shell:            shellName,
-			cmdExecutor:      cmdExecutor,
 			sourceIdentifier: fmt.Sprintf("Shell: %s (history file not found or configured)", shellName),
+			exclusions:       exclusions,
+			fileFinder:       fileFinder,
+			logger:           logger,
 		}, nil
 	}
 
@@ -56,20 +76,72 @@ func NewHistoryProvider(cmdExecutor ports.CommandExecutor, fileFinder ports.Hist
 	return &HistoryProvider{
 		HistoryFile:      histFilePath, // Store the actual absolute path for internal use
 		Shell:            shellName,
-		cmdExecutor:      cmdExecutor,
 		sourceIdentifier: fmt.Sprintf("File: %s", userFriendlyHistPath), // Store user-friendly path for display
+		exclusions:       exclusions,
+		fileFinder:       fileFinder,
+		logger:           logger,
 	}, nil
 }
 
 // GetCommandFrequencies implements the ports.HistoryProvider interface.
 func (hp *HistoryProvider) GetCommandFrequencies(scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
 	if hp.HistoryFile == "" {
-		return nil, fmt.Errorf("history file not found or configured for shell %s. Cannot fetch command frequencies", hp.Shell)
+		return nil, fmt.Errorf("%w: no history file found or configured for shell %s; cannot fetch command frequencies", history.ErrHistoryNotFound, hp.Shell)
+	}
+	if hp.mergesSources() {
+		return hp.countSourceFrequencies(hp.sources, scanLimit, outputLimit)
 	}
 
 	return hp.getHistoryFrequencies(scanLimit, outputLimit)
 }
 
+// GetCommandFrequenciesInDir implements the ports.HistoryProvider interface.
+// Plain history files carry no directory information, so this relies on the
+// per-directory history files written by the zsh per-directory-history plugin.
+func (hp *HistoryProvider) GetCommandFrequenciesInDir(dir string, scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
+	dirHistoryFile, err := findDirectoryHistoryFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	return hp.getFrequenciesFromFile(dirHistoryFile, scanLimit, outputLimit)
+}
+
+// GetEntries implements the ports.HistoryProvider interface.
+// Plain history files only record the command and, in zsh extended or bash
+// timestamped format, when it was run. The entries of merged history sources
+// are returned one source after the other.
+func (hp *HistoryProvider) GetEntries(scanLimit int) ([]history.Entry, error) {
+	if hp.HistoryFile == "" {
+		return nil, fmt.Errorf("%w: no history file found or configured for shell %s; cannot fetch history entries", history.ErrHistoryNotFound, hp.Shell)
+	}
+	scanCount, _ := determineScanCount(scanLimit)
+	entries, err := hp.readSourceEntries(scanCount)
+	if err != nil {
+		return nil, err
+	}
+	kept, _, err := hp.exclusions.apply(entries, hp.log())
+	if err != nil {
+		return nil, err
+	}
+	return kept, nil
+}
+
+// ExplainExclusions implements the ports.HistoryProvider interface.
+func (hp *HistoryProvider) ExplainExclusions(scanLimit int) (history.ExclusionReport, error) {
+	if hp.HistoryFile == "" {
+		return history.ExclusionReport{}, fmt.Errorf("%w: no history file found or configured for shell %s; cannot explain history exclusions", history.ErrHistoryNotFound, hp.Shell)
+	}
+	scanCount, _ := determineScanCount(scanLimit)
+	entries, err := hp.readSourceEntries(scanCount)
+	if err != nil {
+		return history.ExclusionReport{}, err
+	}
+	_, report, err := hp.exclusions.apply(entries, ports.NopLogger)
+	return report, err
+}
+
 func (hp *HistoryProvider) GetHistoryFilePath() string {
 	return hp.HistoryFile
 }
+
+var _ ports.HistorySourceSelector = (*HistoryProvider)(nil)
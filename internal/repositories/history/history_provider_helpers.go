@@ -1,13 +1,16 @@
 package history
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"os/user"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
 )
@@ -39,12 +42,26 @@ func toUserFriendlyPath(absPath string) string {
 // findUserHistoryFile attempts to find a shell history file by checking common locations and environment variables.
 // It no longer takes shellExecutablePath as an argument.
 func findUserHistoryFile() (string, error) {
+	historyFiles, err := findUserHistoryFiles()
+	if err != nil {
+		return "", err
+	}
+	if len(historyFiles) == 0 {
+		return "", fmt.Errorf("%w: could not automatically find a common shell history file. Please ensure your history file is in a standard location (e.g., ~/.bash_history, ~/.zsh_history) or set the HISTFILE environment variable", history.ErrHistoryNotFound)
+	}
+	return historyFiles[0], nil
+}
+
+// findUserHistoryFiles returns every existing shell history file: $HISTFILE first, then the
+// common default locations. A user who switched shells may have more than one.
+func findUserHistoryFiles() ([]string, error) {
 	usr, err := user.Current()
 	if err != nil {
-		return "", fmt.Errorf("getting current user: %w", err)
+		return nil, fmt.Errorf("getting current user: %w", err)
 	}
 	homeDir := usr.HomeDir
 
+	var potentialPaths []string
 	// 1. Check HISTFILE environment variable (common for Zsh, respected by some other tools)
 	if histFileEnvVal := os.Getenv("HISTFILE"); histFileEnvVal != "" {
 		pathToCheck := histFileEnvVal
@@ -52,56 +69,172 @@ func findUserHistoryFile() (string, error) {
 			// Resolve relative to home directory if not absolute
 			pathToCheck = filepath.Join(homeDir, pathToCheck)
 		}
-		if _, err := os.Stat(pathToCheck); err == nil {
-			return pathToCheck, nil
-		}
-		// If HISTFILE is set but file doesn't exist, we can optionally log a warning
-		// fmt.Fprintf(os.Stderr, "Warning: HISTFILE environment variable is set to '%s' but the file was not found.\n", histFileEnvVal)
+		potentialPaths = append(potentialPaths, pathToCheck)
 	}
 
 	// 2. Check a list of common default history file paths
 	// Order can be significant if a user somehow has multiple (e.g. switched shells).
-	potentialPaths := []string{
+	potentialPaths = append(potentialPaths,
 		filepath.Join(homeDir, ".zsh_history"),  // Common for Zsh
 		filepath.Join(homeDir, ".bash_history"), // Common for Bash
 		// Add other common paths here if desired, e.g.:
-		// filepath.Join(homeDir, ".local", "share", "fish", "fish_history"), // Common for Fish (XDG)
-		// filepath.Join(homeDir, ".config", "fish", "fish_history"),       // Older Fish
 		// filepath.Join(homeDir, ".history"), // A generic fallback some might use
-	}
+	)
 
+	var historyFiles []string
+	seen := make(map[string]bool)
 	for _, p := range potentialPaths {
-		if _, err := os.Stat(p); err == nil {
-			return p, nil // Found a history file
+		cleaned := filepath.Clean(p)
+		if seen[cleaned] {
+			continue // HISTFILE usually points to one of the defaults.
+		}
+		seen[cleaned] = true
+		if _, err := os.Stat(cleaned); err == nil {
+			historyFiles = append(historyFiles, cleaned) // Found a history file
 		}
 	}
+	return historyFiles, nil
+}
+
+// findDirectoryHistoryFile returns the per-directory history file for dir, as written by the
+// zsh per-directory-history plugin under $HISTORY_BASE (default ~/.directory_history).
+func findDirectoryHistoryFile(dir string) (string, error) {
+	historyBase := os.Getenv("HISTORY_BASE")
+	if historyBase == "" {
+		usr, err := user.Current()
+		if err != nil {
+			return "", fmt.Errorf("getting current user: %w", err)
+		}
+		historyBase = filepath.Join(usr.HomeDir, ".directory_history")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving directory %s: %w", dir, err)
+	}
 
-	return "", fmt.Errorf("could not automatically find a common shell history file. Please ensure your history file is in a standard location (e.g., ~/.bash_history, ~/.zsh_history) or set the HISTFILE environment variable")
+	dirHistoryFile := filepath.Join(historyBase, absDir, "history")
+	if _, err := os.Stat(dirHistoryFile); err != nil {
+		return "", fmt.Errorf("%w: no per-directory history found for %s (looked for %s). Directory-aware history requires the zsh per-directory-history plugin", history.ErrHistoryNotFound, toUserFriendlyPath(absDir), toUserFriendlyPath(dirHistoryFile))
+	}
+	return dirHistoryFile, nil
 }
 
-// parsePipelineOutput is a helper to parse the output of the shell pipeline.
-func parsePipelineOutput(output string) ([]history.CommandFrequency, error) {
-	frequencies := []history.CommandFrequency{}
-	lines := strings.SplitSeq(output, "\n") // Changed from strings.SplitSeq for broader compatibility if needed
-	for line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
-			continue
+// zshExtendedHistoryRegex matches zsh EXTENDED_HISTORY lines: ": <start>:<elapsed>;<command>".
+var zshExtendedHistoryRegex = regexp.MustCompile(`^: (\d+):(\d+);(.*)$`)
+
+// bashTimestampRegex matches the "#<epoch>" comment lines bash writes when HISTTIMEFORMAT is set.
+var bashTimestampRegex = regexp.MustCompile(`^#(\d+)$`)
+
+// readHistoryFileEntries parses a bash or zsh history file and returns its last scanCount entries, oldest first.
+// zsh multi-line commands (lines ending with a backslash) are joined into a single entry.
+func readHistoryFileEntries(historyFilePath string, scanCount int) ([]history.Entry, error) {
+	content, err := os.ReadFile(historyFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading history file %s: %w", toUserFriendlyPath(historyFilePath), err)
+	}
+
+	var entries []history.Entry
+	var pendingTimestamp time.Time
+	var current *history.Entry
+	for _, line := range strings.Split(string(content), "\n") {
+		if current != nil {
+			// Continuation of a multi-line zsh command.
+			current.Command += "\n" + line
+		} else {
+			if m := bashTimestampRegex.FindStringSubmatch(line); m != nil {
+				if ts, convErr := strconv.ParseInt(m[1], 10, 64); convErr == nil {
+					pendingTimestamp = time.Unix(ts, 0)
+				}
+				continue
+			}
+			entry := history.Entry{Command: line, Timestamp: pendingTimestamp}
+			pendingTimestamp = time.Time{}
+			if m := zshExtendedHistoryRegex.FindStringSubmatch(line); m != nil {
+				start, _ := strconv.ParseInt(m[1], 10, 64)
+				elapsed, _ := strconv.ParseInt(m[2], 10, 64)
+				entry = history.Entry{
+					Command:   m[3],
+					Timestamp: time.Unix(start, 0),
+					Duration:  time.Duration(elapsed) * time.Second,
+				}
+			}
+			current = &entry
 		}
-		parts := strings.Fields(trimmedLine)
-		if len(parts) < 2 {
-			continue // Skip lines that don't have at least count and command
+
+		if strings.HasSuffix(current.Command, "\\") {
+			current.Command = strings.TrimSuffix(current.Command, "\\")
+			continue // The command continues on the next line.
 		}
-		count, err := strconv.Atoi(parts[0])
-		if err != nil {
-			// Log or skip malformed lines
-			// fmt.Fprintf(os.Stderr, "Warning: could not parse count from history line: %s\n", trimmedLine)
+		current.Command = strings.TrimRightFunc(current.Command, unicode.IsSpace)
+		if current.Command != "" {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
+
+	if scanCount > 0 && len(entries) > scanCount {
+		entries = entries[len(entries)-scanCount:]
+	}
+	return entries, nil
+}
+
+// readHistoryFile reads the scanCount most recent entries of a history file, logging which file is read.
+func (p *HistoryProvider) readHistoryFile(historyFilePath string, scanCount int) ([]history.Entry, error) {
+	entries, err := readHistoryFileEntries(historyFilePath, scanCount)
+	if err != nil {
+		return nil, err
+	}
+	p.log().Info("read history file", "file", toUserFriendlyPath(historyFilePath), "entries", len(entries))
+	return entries, nil
+}
+
+// countFrequencies counts how often each command occurs in entries and returns the
+// outputLimit most frequent ones, most frequent first (ties are ordered by command).
+func countFrequencies(entries []history.Entry, outputLimit int) []history.CommandFrequency {
+	return topFrequencies(tallyCommands(entries), outputLimit)
+}
+
+// tallyCommands counts how often each command occurs in entries.
+// Entries known to have failed are not counted, except for commands that were not found:
+// those are likely typos, which the alias generator leaves out of shortcuts but uses
+// to suggest corrections.
+func tallyCommands(entries []history.Entry) map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		if entry.Failed() && !entry.CommandNotFound() {
 			continue
 		}
-		command := strings.Join(parts[1:], " ")
+		command := strings.TrimRightFunc(entry.Command, unicode.IsSpace)
+		if command == "" {
+			continue
+		}
+		counts[command]++
+	}
+	return counts
+}
+
+// topFrequencies returns the outputLimit most frequent commands of counts, most frequent
+// first (ties are ordered by command).
+func topFrequencies(counts map[string]int, outputLimit int) []history.CommandFrequency {
+	frequencies := make([]history.CommandFrequency, 0, len(counts))
+	for command, count := range counts {
 		frequencies = append(frequencies, history.CommandFrequency{Command: command, Count: count})
 	}
-	return frequencies, nil
+	sort.Slice(frequencies, func(i, j int) bool {
+		if frequencies[i].Count != frequencies[j].Count {
+			return frequencies[i].Count > frequencies[j].Count
+		}
+		return frequencies[i].Command < frequencies[j].Command
+	})
+
+	if outputLimit <= 0 {
+		outputLimit = 10 // Default to a sensible limit if non-positive.
+	}
+	if len(frequencies) > outputLimit {
+		frequencies = frequencies[:outputLimit]
+	}
+	return frequencies
 }
 
 // determineScanCount determines how many history entries to scan.
@@ -123,45 +256,13 @@ func determineScanCount(fcHistoryScanLimit int) (int, error) {
 // It uses p.HistoryFile, which should be populated by calling findUserHistoryFile() during provider initialization.
 func (p *HistoryProvider) getHistoryFrequencies(scanLimit, outputLimit int) ([]history.CommandFrequency, error) {
 	if p.HistoryFile == "" {
-		return nil, fmt.Errorf("history file path is not set in HistoryProvider")
-	}
-	scanCountVal, _ := determineScanCount(scanLimit) // Error from determineScanCount is ignored as it provides a default
-	pipeline, err := buildShellPipeline(p.HistoryFile, strconv.Itoa(scanCountVal), outputLimit)
-	if err != nil {
-		return nil, fmt.Errorf("building shell pipeline: %w", err)
-	}
-
-	// Use the injected command executor (p.cmdExecutor) and shell (p.Shell)
-	stdout, stderrOutput, err := p.cmdExecutor.Execute(p.Shell, pipeline)
-	if err != nil {
-		// The error from OSCommandExecutor.Execute might already include stderr.
-		// Consider how to best present this error.
-		errMsg := fmt.Sprintf("executing shell pipeline: %v", err)
-		if stderrOutput != "" {
-			errMsg = fmt.Sprintf("%s. Stderr: %s", errMsg, stderrOutput)
-		}
-		if stdout != "" {
-			return nil, fmt.Errorf("%s. Stdout: %s", errMsg, stdout)
-		}
-		return nil, errors.New("history file path is not set in HistoryProvider")
-
+		return nil, fmt.Errorf("%w: the history file path is not set", history.ErrHistoryNotFound)
 	}
-	// if stderrOutput != "" { // Log non-fatal stderr if necessary
-	// 	fmt.Fprintf(os.Stderr, "Shell pipeline stderr: %s\n", stderrOutput)
-	// }
-
-	return parsePipelineOutput(stdout)
+	return p.getFrequenciesFromFile(p.HistoryFile, scanLimit, outputLimit)
 }
 
-// buildShellPipeline constructs the shell command pipeline for bash/zsh.
-func buildShellPipeline(historyFilePath, historyScanCountStr string, outputLimit int) (string, error) {
-	if _, err := os.Stat(historyFilePath); os.IsNotExist(err) {
-		// Use toUserFriendlyPath for displaying the path in the error message
-		return "", fmt.Errorf("history file does not exist: %s", toUserFriendlyPath(historyFilePath))
-	}
-	// Ensure outputLimit is positive
-	if outputLimit <= 0 {
-		outputLimit = 10 // Default to a sensible limit if non-positive
-	}
-	return fmt.Sprintf("cat '%s' | tail -n %s | sed 's/[[:space:]]*$//' | sort | uniq -c | sort -nr | head -n %d", historyFilePath, historyScanCountStr, outputLimit), nil
+// getFrequenciesFromFile counts the commands of the given history file, like a merged
+// source of weight 1.
+func (p *HistoryProvider) getFrequenciesFromFile(historyFilePath string, scanLimit, outputLimit int) ([]history.CommandFrequency, error) {
+	return p.countSourceFrequencies([]historySource{{path: historyFilePath, weight: 1}}, scanLimit, outputLimit)
 }
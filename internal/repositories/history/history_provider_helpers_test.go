@@ -1,17 +1,15 @@
 package history
 
 import (
-	"errors"
-	"fmt"
 	"os"
 	"os/user"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
-	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
 )
 
 // setupEnvVar sets an environment variable for the duration of the test.
@@ -88,18 +86,16 @@ func TestFindUserHistoryFile(t *testing.T) {
 	defaultBashHistoryPath := filepath.Join(homeDir, ".bash_history")
 
 	tests := []struct {
-		name                  string
-		providerSetup         func() *HistoryProvider // Allows for different provider states
-		scanLimit             int
-		outputLimit           int
-		setupFunc             func(t *testing.T) // For setting env vars and creating files
-		wantPath              string
-		wantErr               bool
-		wantErrorContain      string
-		mockExecuteFunc       func(shellName, pipeline string) (string, string, error)
-		wantFreqs             []history.CommandFrequency
-		wantErrorContains     string
-		checkPipelineContains []string // Optional: check parts of the generated pipeline
+		name              string
+		providerSetup     func() *HistoryProvider // Allows for different provider states
+		scanLimit         int
+		outputLimit       int
+		setupFunc         func(t *testing.T) // For setting env vars and creating files
+		wantPath          string
+		wantErr           bool
+		wantErrorContain  string
+		wantFreqs         []history.CommandFrequency
+		wantErrorContains string
 	}{
 		{
 			name: "HISTFILE set to existing absolute path",
@@ -192,85 +188,6 @@ func TestFindUserHistoryFile(t *testing.T) {
 	}
 }
 
-func TestParsePipelineOutput(t *testing.T) {
-	tests := []struct {
-		name    string
-		output  string
-		want    []history.CommandFrequency
-		wantErr bool // parsePipelineOutput currently doesn't return errors
-	}{
-		{
-			name: "valid input",
-			output: `
-      5 git status
-      3 ls -l
-      1 echo hello
-`,
-			want: []history.CommandFrequency{
-				{Command: "git status", Count: 5},
-				{Command: "ls -l", Count: 3},
-				{Command: "echo hello", Count: 1},
-			},
-		},
-		{
-			name:   "empty input",
-			output: "",
-			want:   []history.CommandFrequency{},
-		},
-		{
-			name:   "input with only whitespace lines",
-			output: "   \n   \n",
-			want:   []history.CommandFrequency{},
-		},
-		{
-			name: "input with invalid count, valid lines are still processed",
-			output: `
-      five git status
-      3 ls -l
-`,
-			want: []history.CommandFrequency{
-				{Command: "ls -l", Count: 3}, // "five git status" is skipped
-			},
-		},
-		{
-			name: "input with lines too short, valid lines are still processed",
-			output: `
-      5
-      3 ls -l
-`,
-			want: []history.CommandFrequency{
-				{Command: "ls -l", Count: 3}, // "5" is skipped
-			},
-		},
-		{
-			name:   "input with leading/trailing spaces in command part",
-			output: `  2  my command  with  spaces  `,
-			want: []history.CommandFrequency{
-				{Command: "my command with spaces", Count: 2},
-			},
-		},
-		{
-			name:   "input with tabs instead of spaces",
-			output: "\t7\tgit\tcommit\t-m\t'message'",
-			want: []history.CommandFrequency{
-				{Command: "git commit -m 'message'", Count: 7},
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := parsePipelineOutput(tt.output) // parsePipelineOutput itself doesn't return error
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parsePipelineOutput() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("parsePipelineOutput() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestDetermineScanCount(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -307,224 +224,201 @@ func TestDetermineScanCount(t *testing.T) {
 	}
 }
 
-func TestBuildShellPipeline(t *testing.T) {
-	tmpFile, err := os.CreateTemp(t.TempDir(), "history_for_pipeline_")
-	if err != nil {
-		t.Fatalf("Failed to create temp file: %v", err)
-	}
-	tmpFilePath := tmpFile.Name()
-	tmpFile.Close() // Close immediately, we just need the path for os.Stat
-
-	nonExistentFilePath := filepath.Join(t.TempDir(), "non_existent_history_file")
-
+func TestHistoryProvider_getHistoryFrequencies(t *testing.T) {
 	tests := []struct {
-		name                string
-		historyFilePath     string
-		historyScanCountStr string
-		outputLimit         int
-		wantPipelineParts   []string // Substrings the pipeline should contain
-		wantErr             bool
-		wantErrorContain    string
+		name              string
+		content           string // History file content; the file is not created if empty.
+		historyFileUnset  bool
+		scanLimit         int
+		outputLimit       int
+		wantFreqs         []history.CommandFrequency
+		wantErr           bool
+		wantErrorContains string
 	}{
 		{
-			name:                "generic pipeline construction",
-			historyFilePath:     tmpFilePath,
-			historyScanCountStr: "1000",
-			outputLimit:         10,
-			wantPipelineParts:   []string{fmt.Sprintf("cat '%s'", tmpFilePath), "tail -n 1000", "sed 's/[[:space:]]*$//'", "sort | uniq -c | sort -nr", "head -n 10"},
+			name:        "plain history",
+			content:     "some command\nanother command\nsome command  \n",
+			scanLimit:   100,
+			outputLimit: 10,
+			wantFreqs: []history.CommandFrequency{
+				{Command: "some command", Count: 2},
+				{Command: "another command", Count: 1},
+			},
+		},
+		{
+			name:        "zsh extended history prefixes are stripped",
+			content:     ": 1700000000:0;git status\n: 1700000005:2;git status\n: 1700000009:0;make\n",
+			scanLimit:   100,
+			outputLimit: 10,
+			wantFreqs: []history.CommandFrequency{
+				{Command: "git status", Count: 2},
+				{Command: "make", Count: 1},
+			},
 		},
 		{
-			name:                "different scan count and output limit",
-			historyFilePath:     tmpFilePath,
-			historyScanCountStr: "500",
-			outputLimit:         5,
-			wantPipelineParts:   []string{fmt.Sprintf("cat '%s'", tmpFilePath), "tail -n 500", "sed 's/[[:space:]]*$//'", "sort | uniq -c | sort -nr", "head -n 5"},
+			name:        "scan and output limits",
+			content:     "a\nb\nb\nc\nc\nc\n",
+			scanLimit:   3,
+			outputLimit: 1,
+			wantFreqs:   []history.CommandFrequency{{Command: "c", Count: 3}},
 		},
 		{
-			name:                "output limit zero or negative (defaults to 10)",
-			historyFilePath:     tmpFilePath,
-			historyScanCountStr: "100",
-			outputLimit:         0,
-			wantPipelineParts:   []string{"head -n 10"},
+			name:              "history file not set in provider",
+			historyFileUnset:  true,
+			scanLimit:         100,
+			outputLimit:       10,
+			wantErr:           true,
+			wantErrorContains: "history file path is not set",
 		},
 		{
-			name:                "history file does not exist",
-			historyFilePath:     nonExistentFilePath,
-			historyScanCountStr: "100",
-			outputLimit:         10,
-			wantErr:             true,
-			wantErrorContain:    fmt.Sprintf("history file does not exist: %s", toUserFriendlyPath(nonExistentFilePath)),
+			name:              "history file does not exist",
+			scanLimit:         100,
+			outputLimit:       10,
+			wantErr:           true,
+			wantErrorContains: "reading history file",
 		},
 	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Ensure the temp file exists for non-error cases, or doesn't for error cases
-			if !tt.wantErr && tt.historyFilePath == tmpFilePath {
-				manageTestFile(t, tmpFilePath, []byte("content"))
-			} else if tt.wantErr && tt.historyFilePath == nonExistentFilePath {
-				os.Remove(nonExistentFilePath) // Ensure it doesn't exist
+			provider := &HistoryProvider{Shell: "zsh"}
+			if !tt.historyFileUnset {
+				provider.HistoryFile = filepath.Join(t.TempDir(), ".zsh_history")
+				if tt.content != "" {
+					manageTestFile(t, provider.HistoryFile, []byte(tt.content))
+				}
 			}
 
-			gotPipeline, err := buildShellPipeline(tt.historyFilePath, tt.historyScanCountStr, tt.outputLimit)
+			freqs, err := provider.getHistoryFrequencies(tt.scanLimit, tt.outputLimit)
 
 			if (err != nil) != tt.wantErr {
-				t.Errorf("buildShellPipeline() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("getHistoryFrequencies() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if tt.wantErr {
-				if !strings.Contains(err.Error(), tt.wantErrorContain) {
-					t.Errorf("buildShellPipeline() error %q does not contain %q", err.Error(), tt.wantErrorContain)
+				if !strings.Contains(err.Error(), tt.wantErrorContains) {
+					t.Errorf("getHistoryFrequencies() error %q does not contain %q", err.Error(), tt.wantErrorContains)
 				}
 				return
 			}
-			for _, part := range tt.wantPipelineParts {
-				if !strings.Contains(gotPipeline, part) {
-					t.Errorf("buildShellPipeline() = %q, does not contain %q", gotPipeline, part)
-				}
+			if !reflect.DeepEqual(freqs, tt.wantFreqs) {
+				t.Errorf("getHistoryFrequencies() freqs = %#v, want %#v", freqs, tt.wantFreqs)
 			}
 		})
 	}
 }
 
-func TestHistoryProvider_getHistoryFrequencies(t *testing.T) {
-	tmpHistoryFile, err := os.CreateTemp(t.TempDir(), "provider_history_")
+// TestHistoryProvider_getHistoryFrequenciesWithAndWithoutExclusions checks that configuring
+// exclusions which match nothing doesn't change the counts of a zsh extended history file.
+func TestHistoryProvider_getHistoryFrequenciesWithAndWithoutExclusions(t *testing.T) {
+	historyFilePath := filepath.Join(t.TempDir(), ".zsh_history")
+	manageTestFile(t, historyFilePath, []byte(": 1700000000:0;git status\n: 1700000001:0;ls -la\n: 1700000002:0;git status\n: 1700000003:1;make \\\ntest\n"))
+
+	exclusions, err := newHistoryExclusions("never-run", "", exclusionsFile{})
+	if err != nil {
+		t.Fatalf("newHistoryExclusions() unexpected error: %v", err)
+	}
+	plain := &HistoryProvider{Shell: "zsh", HistoryFile: historyFilePath}
+	excluding := &HistoryProvider{Shell: "zsh", HistoryFile: historyFilePath, exclusions: exclusions}
+
+	got, err := plain.getHistoryFrequencies(100, 10)
 	if err != nil {
-		t.Fatalf("Failed to create temp history file: %v", err)
+		t.Fatalf("getHistoryFrequencies() unexpected error: %v", err)
+	}
+	gotExcluding, err := excluding.getHistoryFrequencies(100, 10)
+	if err != nil {
+		t.Fatalf("getHistoryFrequencies() with exclusions unexpected error: %v", err)
+	}
+
+	want := []history.CommandFrequency{
+		{Command: "git status", Count: 2},
+		{Command: "ls -la", Count: 1},
+		{Command: "make \ntest", Count: 1},
 	}
-	historyFilePath := tmpHistoryFile.Name()
-	manageTestFile(t, historyFilePath, []byte("some command\nanother command\nsome command\n")) // Ensure file exists and has content
-	// Note: manageTestFile handles cleanup
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getHistoryFrequencies() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(gotExcluding, got) {
+		t.Errorf("getHistoryFrequencies() with exclusions = %q, without = %q", gotExcluding, got)
+	}
+}
 
+func TestReadHistoryFileEntries(t *testing.T) {
 	tests := []struct {
-		name                  string
-		providerSetup         func() *HistoryProvider // Allows for different provider states
-		scanLimit             int
-		outputLimit           int
-		mockExecuteFunc       func(shellName, pipeline string) (string, string, error)
-		wantFreqs             []history.CommandFrequency
-		wantErr               bool
-		wantErrorContains     string
-		checkPipelineContains []string // Optional: check parts of the generated pipeline
+		name      string
+		content   string
+		scanCount int
+		want      []history.Entry
 	}{
 		{
-			name: "successful pipeline execution",
-			providerSetup: func() *HistoryProvider {
-				return &HistoryProvider{Shell: "bash", HistoryFile: historyFilePath}
-			},
-			scanLimit:   100,
-			outputLimit: 10,
-			mockExecuteFunc: func(shellName, pipeline string) (string, string, error) {
-				return "2 some command\n1 another command", "", nil
-			},
-			wantFreqs: []history.CommandFrequency{
-				{Command: "some command", Count: 2},
-				{Command: "another command", Count: 1},
-			},
-			checkPipelineContains: []string{"tail -n 100", "head -n 10"}, // scanLimit is 100 (from HISTSIZE or default if fcHistoryScanLimit is 0)
-		},
-		{
-			name: "pipeline execution error",
-			providerSetup: func() *HistoryProvider {
-				return &HistoryProvider{Shell: "zsh", HistoryFile: historyFilePath}
-			},
-			scanLimit:   50,
-			outputLimit: 5,
-			mockExecuteFunc: func(shellName, pipeline string) (string, string, error) {
-				return "", "permission denied", errors.New("exit status 1")
-			},
-			wantErr: true,
+			name:    "plain history",
+			content: "ls -la\ngit status\n\n",
+			want:    []history.Entry{{Command: "ls -la"}, {Command: "git status"}},
 		},
 		{
-			name: "history file not set in provider",
-			providerSetup: func() *HistoryProvider {
-				return &HistoryProvider{Shell: "bash", HistoryFile: ""} // HistoryFile is empty
-			},
-			scanLimit:   100,
-			outputLimit: 10,
-			mockExecuteFunc: func(shellName, pipeline string) (string, string, error) {
-				t.Fatal("cmdExecutor.Execute should not be called if HistoryFile is not set")
-				return "", "", nil
+			name:    "zsh extended history with multi-line command",
+			content: ": 1700000000:3;make test\n: 1700000010:0;echo one \\\ntwo\n",
+			want: []history.Entry{
+				{Command: "make test", Timestamp: time.Unix(1700000000, 0), Duration: 3 * time.Second},
+				{Command: "echo one \ntwo", Timestamp: time.Unix(1700000010, 0)},
 			},
-			wantErr:           true,
-			wantErrorContains: "history file path is not set",
 		},
 		{
-			name: "buildShellPipeline fails (e.g., history file becomes non-existent after provider init)",
-			providerSetup: func() *HistoryProvider {
-				// Simulate file existing at init, but removed before getHistoryFrequencies call
-				// For this test, we'll set a path that buildShellPipeline will fail on.
-				return &HistoryProvider{Shell: "bash", HistoryFile: filepath.Join(t.TempDir(), "file_will_be_gone")}
-			},
-			scanLimit:   100,
-			outputLimit: 10,
-			mockExecuteFunc: func(shellName, pipeline string) (string, string, error) {
-				t.Fatal("cmdExecutor.Execute should not be called if buildShellPipeline fails")
-				return "", "", nil
-			},
-			wantErr:           true,
-			wantErrorContains: "building shell pipeline: history file does not exist",
+			name:    "bash timestamps",
+			content: "#1700000000\ngit pull\nls\n",
+			want:    []history.Entry{{Command: "git pull", Timestamp: time.Unix(1700000000, 0)}, {Command: "ls"}},
 		},
 		{
-			name: "parsePipelineOutput yields empty due to malformed executor output",
-			providerSetup: func() *HistoryProvider {
-				return &HistoryProvider{Shell: "bash", HistoryFile: historyFilePath}
-			},
-			scanLimit:   100,
-			outputLimit: 10,
-			mockExecuteFunc: func(shellName, pipeline string) (string, string, error) {
-				return "this is not valid output format", "", nil // Malformed output
-			},
-			wantFreqs: []history.CommandFrequency{}, // Expect empty slice, not an error
+			name:      "scan count keeps the most recent entries",
+			content:   "one\ntwo\nthree\n",
+			scanCount: 2,
+			want:      []history.Entry{{Command: "two"}, {Command: "three"}},
 		},
-		// Note on the potential bug in source:
-		// If cmdExecutor.Execute returns an error, and stdout is empty,
-		// the current source code returns: errors.New("history file path is not set in HistoryProvider")
-		// This shadows the actual execution error. The tests here assume this bug might be fixed
-		// to return the more detailed error (like in "pipeline execution error" case).
-		// If the bug persists, the wantErrorContains for such a specific case would need to be
-		// "history file path is not set in HistoryProvider".
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			provider := tt.providerSetup()
-			provider.cmdExecutor = &testutil.MockCommandExecutor{
-				ExecuteFunc: func(shellName, pipeline string) (string, string, error) {
-					if tt.checkPipelineContains != nil {
-						for _, part := range tt.checkPipelineContains {
-							if !strings.Contains(pipeline, part) {
-								t.Errorf("Pipeline %q was expected to contain %q", pipeline, part)
-							}
-						}
-					}
-					if tt.mockExecuteFunc != nil {
-						return tt.mockExecuteFunc(shellName, pipeline)
-					}
-					return "", "", errors.New("mockExecuteFunc not provided")
-				},
+			path := filepath.Join(t.TempDir(), "history")
+			manageTestFile(t, path, []byte(tt.content))
+			got, err := readHistoryFileEntries(path, tt.scanCount)
+			if err != nil {
+				t.Fatalf("readHistoryFileEntries() unexpected error: %v", err)
 			}
-
-			// Special handling for the "buildShellPipeline fails" case
-			// Ensure the file does not exist if that's what we're testing for buildShellPipeline
-			if tt.name == "buildShellPipeline fails (e.g., history file becomes non-existent after provider init)" {
-				os.Remove(provider.HistoryFile)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readHistoryFileEntries() = %+v, want %+v", got, tt.want)
 			}
+		})
+	}
 
-			freqs, err := provider.getHistoryFrequencies(tt.scanLimit, tt.outputLimit)
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := readHistoryFileEntries(filepath.Join(t.TempDir(), "missing"), 0); err == nil {
+			t.Error("readHistoryFileEntries() expected error for missing file")
+		}
+	})
+}
 
-			if (err != nil) != tt.wantErr {
-				t.Errorf("getHistoryFrequencies() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if tt.wantErr {
-				if !strings.Contains(err.Error(), tt.wantErrorContains) {
-					t.Errorf("getHistoryFrequencies() error %q does not contain %q", err.Error(), tt.wantErrorContains)
-				}
-				return
-			}
-			if !reflect.DeepEqual(freqs, tt.wantFreqs) {
-				t.Errorf("getHistoryFrequencies() freqs = %#v, want %#v", freqs, tt.wantFreqs)
-			}
-		})
+func TestCountFrequencies(t *testing.T) {
+	entries := []history.Entry{
+		{Command: "ls"}, {Command: "git status"}, {Command: "ls "}, {Command: "  "},
+		{Command: "make"}, {Command: "git status"}, {Command: "cd"},
+		{Command: "make test", ExitCode: 2, HasExitCode: true},
+		{Command: "make test", ExitCode: 2, HasExitCode: true},
+		{Command: "make test", ExitCode: 2, HasExitCode: true},
+		{Command: "gti status", ExitCode: 127, HasExitCode: true}, // Not found: kept for typo corrections.
+		{Command: "cd", ExitCode: 0, HasExitCode: true},
+	}
+
+	got := countFrequencies(entries, 3)
+	want := []history.CommandFrequency{
+		{Command: "cd", Count: 2},
+		{Command: "git status", Count: 2},
+		{Command: "ls", Count: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countFrequencies() = %v, want %v", got, want)
+	}
+
+	if got := countFrequencies(entries, 0); len(got) != 5 {
+		t.Errorf("countFrequencies() with default limit returned %d frequencies, want 5", len(got))
 	}
 }
@@ -23,7 +23,6 @@ func TestNewHistoryProvider(t *testing.T) {
 	currentUser, _ := user.Current()
 	homeDir := currentUser.HomeDir
 	tempDir := t.TempDir() // For generating realistic temp paths if needed by mocks
-	mockCmdExecutor := &testutil.MockCommandExecutor{}
 
 	tests := []struct {
 		name                  string
@@ -35,6 +34,7 @@ func TestNewHistoryProvider(t *testing.T) {
 		wantHistoryFile       string // Expected absolute path
 		wantSourceIdentifier  string
 		checkSourceIdentifier bool
+		wantWarning           bool
 	}{
 		{
 			name: "SHELL not set",
@@ -77,6 +77,7 @@ func TestNewHistoryProvider(t *testing.T) {
 			wantHistoryFile:       "",
 			wantSourceIdentifier:  "Shell: bash (history file not found or configured)",
 			checkSourceIdentifier: true,
+			wantWarning:           true,
 		},
 		{
 			name: "SHELL set, HISTFILE points to a temp file (using DefaultFileFinder for this specific case to test integration)",
@@ -103,17 +104,11 @@ func TestNewHistoryProvider(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupShellEnv()
 
-			// Redirect stderr to capture warning
-			oldStderr := os.Stderr
-			r, w, _ := os.Pipe()
-			os.Stderr = w
-
-			provider, err := NewHistoryProvider(mockCmdExecutor, tt.mockFileFinder)
-
-			w.Close()
-			// capturedStderrBytes, _ := io.ReadAll(r) // If you need to assert warnings
-			os.Stderr = oldStderr
-			r.Close()
+			logger := &testutil.MockLogger{}
+			provider, err := NewHistoryProvider(tt.mockFileFinder, nil, logger)
+			if gotWarning := len(logger.Entries) > 0; gotWarning != tt.wantWarning {
+				t.Errorf("NewHistoryProvider() logged %q, want a warning: %v", logger.Entries, tt.wantWarning)
+			}
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewHistoryProvider() error = %v, wantErr %v", err, tt.wantErr)
@@ -145,9 +140,6 @@ func TestNewHistoryProvider(t *testing.T) {
 				if tt.checkSourceIdentifier && hp.GetSourceIdentifier() != tt.wantSourceIdentifier {
 					t.Errorf("NewHistoryProvider() SourceIdentifier = %q, want %q", hp.GetSourceIdentifier(), tt.wantSourceIdentifier)
 				}
-				if hp.cmdExecutor == nil {
-					t.Error("NewHistoryProvider() cmdExecutor is nil")
-				}
 			}
 		})
 	}
@@ -216,47 +208,43 @@ func TestHistoryProvider_GetCommandFrequencies(t *testing.T) {
 	historyFilePath := filepath.Join(tempDir, ".test_history")
 	manageTestFile(t, historyFilePath, []byte("cmd1\ncmd2\ncmd1"))
 
-	mockExecutor := &testutil.MockCommandExecutor{}
 	providerWithFile := &HistoryProvider{
 		Shell:            "bash",
 		HistoryFile:      historyFilePath,
-		cmdExecutor:      mockExecutor,
 		sourceIdentifier: fmt.Sprintf("File: %s", toUserFriendlyPath(historyFilePath)),
 	}
 	providerWithoutFile := &HistoryProvider{
 		Shell:            "zsh",
 		HistoryFile:      "", // No history file
-		cmdExecutor:      mockExecutor,
 		sourceIdentifier: "Shell: zsh (history file not found or configured)",
 	}
+	providerWithMissingFile := &HistoryProvider{
+		Shell:       "bash",
+		HistoryFile: filepath.Join(tempDir, "missing_history"),
+	}
 
 	tests := []struct {
 		name              string
 		provider          ports.HistoryProvider
-		setupMockExecutor func()
 		scanLimit         int
 		outputLimit       int
 		wantFreqs         []history.CommandFrequency
 		wantErr           bool
 		wantErrorContains string
+		wantErrIs         error
 	}{
 		{
 			name:              "HistoryFile not set on provider",
 			provider:          providerWithoutFile,
-			setupMockExecutor: func() {},
 			scanLimit:         100,
 			outputLimit:       10,
 			wantErr:           true,
-			wantErrorContains: "history file not found or configured",
+			wantErrorContains: "no history file found or configured",
+			wantErrIs:         history.ErrHistoryNotFound,
 		},
 		{
-			name:     "Successful fetch",
-			provider: providerWithFile,
-			setupMockExecutor: func() {
-				mockExecutor.ExecuteFunc = func(shellName, pipeline string) (string, string, error) {
-					return "2 cmd1\n1 cmd2", "", nil
-				}
-			},
+			name:        "Successful fetch",
+			provider:    providerWithFile,
 			scanLimit:   100,
 			outputLimit: 10,
 			wantFreqs: []history.CommandFrequency{
@@ -266,35 +254,17 @@ func TestHistoryProvider_GetCommandFrequencies(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:     "Error from command executor",
-			provider: providerWithFile,
-			setupMockExecutor: func() {
-				mockExecutor.ExecuteFunc = func(shellName, pipeline string) (string, string, error) {
-					return "", "exec error", errors.New("pipeline failed")
-				}
-			},
-			scanLimit:   100,
-			outputLimit: 10,
-			wantErr:     true,
-		},
-		{
-			name:     "Error from command executor with empty stdout (specific error shadowing case)",
-			provider: providerWithFile,
-			setupMockExecutor: func() {
-				mockExecutor.ExecuteFunc = func(shellName, pipeline string) (string, string, error) {
-					return "", "", errors.New("pipeline failed with empty stdout") // stdout is empty
-				}
-			},
+			name:              "History file removed after the provider was created",
+			provider:          providerWithMissingFile,
 			scanLimit:         100,
 			outputLimit:       10,
 			wantErr:           true,
-			wantErrorContains: "history file path is not set in HistoryProvider", // This reflects the current behavior noted in history_provider_helpers_test.go
+			wantErrorContains: "reading history file",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setupMockExecutor()
 			freqs, err := tt.provider.GetCommandFrequencies(tt.scanLimit, tt.outputLimit)
 
 			if (err != nil) != tt.wantErr {
@@ -305,6 +275,9 @@ func TestHistoryProvider_GetCommandFrequencies(t *testing.T) {
 				if !strings.Contains(err.Error(), tt.wantErrorContains) {
 					t.Errorf("GetCommandFrequencies() error = %q, want error containing %q", err.Error(), tt.wantErrorContains)
 				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("GetCommandFrequencies() error = %v, want errors.Is(%v)", err, tt.wantErrIs)
+				}
 				return
 			}
 			if !reflect.DeepEqual(freqs, tt.wantFreqs) {
@@ -345,3 +318,34 @@ func TestHistoryProvider_GetHistoryFilePath(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoryProvider_GetCommandFrequenciesInDir(t *testing.T) {
+	historyBase := t.TempDir()
+	setupEnvVar(t, "HISTORY_BASE", historyBase)
+	projectDir := filepath.Join(t.TempDir(), "project")
+	dirHistoryFile := filepath.Join(historyBase, projectDir, "history")
+	if err := os.MkdirAll(filepath.Dir(dirHistoryFile), 0755); err != nil {
+		t.Fatalf("Failed to create directory history dir: %v", err)
+	}
+	manageTestFile(t, dirHistoryFile, []byte("make test\nmake test\n"))
+
+	provider := &HistoryProvider{Shell: "zsh"}
+
+	t.Run("directory with per-directory history", func(t *testing.T) {
+		freqs, err := provider.GetCommandFrequenciesInDir(projectDir, 100, 10)
+		if err != nil {
+			t.Fatalf("GetCommandFrequenciesInDir() unexpected error: %v", err)
+		}
+		want := []history.CommandFrequency{{Command: "make test", Count: 2}}
+		if !reflect.DeepEqual(freqs, want) {
+			t.Errorf("GetCommandFrequenciesInDir() = %v, want %v", freqs, want)
+		}
+	})
+
+	t.Run("directory without per-directory history", func(t *testing.T) {
+		_, err := provider.GetCommandFrequenciesInDir(t.TempDir(), 100, 10)
+		if err == nil || !strings.Contains(err.Error(), "no per-directory history found") {
+			t.Errorf("GetCommandFrequenciesInDir() error = %v, want error containing %q", err, "no per-directory history found")
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
+
+// historySource is one of the history files a HistoryProvider merges.
+type historySource struct {
+	path           string  // Absolute path of the history file.
+	weight         float64 // Multiplies the counts of the file's commands.
+	scannedEntries int     // Entries read from the file by the last scan, for the source breakdown.
+	scanned        bool    // Whether scannedEntries is known.
+}
+
+// parseHistorySourceSpec parses a "PATH" or "PATH:WEIGHT" history source. A leading "~/"
+// is expanded to the home directory. The file must exist and the weight must be positive.
+func parseHistorySourceSpec(spec string) (historySource, error) {
+	path, weight := spec, 1.0
+	if i := strings.LastIndex(spec, ":"); i > 0 {
+		if parsed, err := strconv.ParseFloat(spec[i+1:], 64); err == nil {
+			if parsed <= 0 || math.IsInf(parsed, 0) || math.IsNaN(parsed) {
+				return historySource{}, fmt.Errorf("invalid weight in history source '%s': must be a positive number", spec)
+			}
+			path, weight = spec[:i], parsed
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		usr, err := user.Current()
+		if err != nil {
+			return historySource{}, fmt.Errorf("getting current user: %w", err)
+		}
+		path = filepath.Join(usr.HomeDir, rest)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return historySource{}, fmt.Errorf("resolving history source %s: %w", path, err)
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return historySource{}, fmt.Errorf("%w: history source %s not found", history.ErrHistoryNotFound, toUserFriendlyPath(absPath))
+	}
+	return historySource{path: absPath, weight: weight}, nil
+}
+
+// SelectHistorySources implements the ports.HistorySourceSelector interface.
+// If no history file is found (or given), the provider keeps the file it was created with.
+func (hp *HistoryProvider) SelectHistorySources(specs []string) error {
+	var sources []historySource
+	if len(specs) == 0 {
+		if hp.fileFinder == nil {
+			return nil
+		}
+		paths, err := hp.fileFinder.FindAll()
+		if err != nil {
+			return fmt.Errorf("finding history files: %w", err)
+		}
+		for _, path := range paths {
+			sources = append(sources, historySource{path: path, weight: 1})
+		}
+	}
+	for _, spec := range specs {
+		source, err := parseHistorySourceSpec(spec)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	hp.sources = sources
+	hp.HistoryFile = sources[0].path
+	hp.sourceIdentifier = fmt.Sprintf("File: %s", toUserFriendlyPath(sources[0].path))
+	return nil
+}
+
+// mergesSources reports whether frequencies must be merged from weighted sources,
+// rather than counted from the single history file.
+func (hp *HistoryProvider) mergesSources() bool {
+	return len(hp.sources) > 1 || (len(hp.sources) == 1 && hp.sources[0].weight != 1)
+}
+
+// countSourceFrequencies counts the commands of the scanCount most recent entries of each
+// source, leaving out excluded and failed entries, multiplies each source's counts by its
+// weight and merges them. Merged counts are rounded to the nearest integer.
+// A single history file is counted as a source of weight 1, so the counts of a file don't
+// depend on whether other files are merged with it.
+func (hp *HistoryProvider) countSourceFrequencies(sources []historySource, scanLimit, outputLimit int) ([]history.CommandFrequency, error) {
+	scanCount, _ := determineScanCount(scanLimit)
+	weightedCounts := make(map[string]float64)
+	for i := range sources {
+		source := &sources[i]
+		entries, err := hp.readHistoryFile(source.path, scanCount)
+		if err != nil {
+			return nil, err
+		}
+		source.scannedEntries, source.scanned = len(entries), true
+
+		kept, _, err := hp.exclusions.apply(entries, hp.log())
+		if err != nil {
+			return nil, err
+		}
+		for command, count := range tallyCommands(kept) {
+			weightedCounts[command] += float64(count) * source.weight
+		}
+	}
+
+	counts := make(map[string]int, len(weightedCounts))
+	for command, weightedCount := range weightedCounts {
+		if rounded := int(math.Round(weightedCount)); rounded > 0 {
+			counts[command] = rounded
+		}
+	}
+	return topFrequencies(counts, outputLimit), nil
+}
+
+// readSourceEntries reads the scanCount most recent entries of each history source
+// (or of the history file, if no sources were selected), one source after the other.
+func (hp *HistoryProvider) readSourceEntries(scanCount int) ([]history.Entry, error) {
+	if len(hp.sources) == 0 {
+		return hp.readHistoryFile(hp.HistoryFile, scanCount)
+	}
+	var entries []history.Entry
+	for i := range hp.sources {
+		sourceEntries, err := hp.readHistoryFile(hp.sources[i].path, scanCount)
+		if err != nil {
+			return nil, err
+		}
+		hp.sources[i].scannedEntries, hp.sources[i].scanned = len(sourceEntries), true
+		entries = append(entries, sourceEntries...)
+	}
+	return entries, nil
+}
+
+// sourcesIdentifier describes the merged history sources, with their weights and,
+// once they were scanned, how many entries were read from each.
+func (hp *HistoryProvider) sourcesIdentifier() string {
+	descriptions := make([]string, 0, len(hp.sources))
+	for _, source := range hp.sources {
+		var details []string
+		if source.weight != 1 {
+			details = append(details, "weight "+strconv.FormatFloat(source.weight, 'g', -1, 64))
+		}
+		if source.scanned {
+			details = append(details, fmt.Sprintf("%d entries", source.scannedEntries))
+		}
+		description := toUserFriendlyPath(source.path)
+		if len(details) > 0 {
+			description += " [" + strings.Join(details, ", ") + "]"
+		}
+		descriptions = append(descriptions, description)
+	}
+	return "Files: " + strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,212 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
+)
+
+func TestParseHistorySourceSpec(t *testing.T) {
+	dir := t.TempDir()
+	historyPath := filepath.Join(dir, "laptop_history")
+	manageTestFile(t, historyPath, []byte("ls\n"))
+	colonPath := filepath.Join(dir, "odd:name")
+	manageTestFile(t, colonPath, []byte("ls\n"))
+
+	tests := []struct {
+		name            string
+		spec            string
+		want            historySource
+		wantErrContains string
+	}{
+		{name: "path only", spec: historyPath, want: historySource{path: historyPath, weight: 1}},
+		{name: "path with weight", spec: historyPath + ":0.5", want: historySource{path: historyPath, weight: 0.5}},
+		{name: "colon that is not a weight", spec: colonPath, want: historySource{path: colonPath, weight: 1}},
+		{name: "zero weight", spec: historyPath + ":0", wantErrContains: "must be a positive number"},
+		{name: "missing file", spec: filepath.Join(dir, "missing") + ":2", wantErrContains: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHistorySourceSpec(tt.spec)
+			if tt.wantErrContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+					t.Fatalf("parseHistorySourceSpec(%q) error = %v, want error containing %q", tt.spec, err, tt.wantErrContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHistorySourceSpec(%q) unexpected error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseHistorySourceSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("home directory is expanded", func(t *testing.T) {
+		usr, err := user.Current()
+		if err != nil {
+			t.Skipf("cannot determine the current user: %v", err)
+		}
+		_, err = parseHistorySourceSpec("~/surely-not-a-history-file")
+		wantPath := toUserFriendlyPath(filepath.Join(usr.HomeDir, "surely-not-a-history-file"))
+		if err == nil || !strings.Contains(err.Error(), wantPath) {
+			t.Errorf("parseHistorySourceSpec() error = %v, want it to mention %s", err, wantPath)
+		}
+	})
+}
+
+func TestHistoryProvider_SelectHistorySources(t *testing.T) {
+	dir := t.TempDir()
+	zshHistory := filepath.Join(dir, ".zsh_history")
+	manageTestFile(t, zshHistory, []byte(": 1700000000:0;git status\n: 1700000001:0;git status\n: 1700000002:0;make\n"))
+	bashHistory := filepath.Join(dir, ".bash_history")
+	manageTestFile(t, bashHistory, []byte("make\nmake\nmake\nls\n"))
+
+	t.Run("auto-detected files are merged", func(t *testing.T) {
+		provider := &HistoryProvider{
+			Shell: "zsh",
+			fileFinder: &testutil.MockHistoryFileFinder{FindAllFunc: func() ([]string, error) {
+				return []string{zshHistory, bashHistory}, nil
+			}},
+		}
+		if err := provider.SelectHistorySources(nil); err != nil {
+			t.Fatalf("SelectHistorySources() unexpected error: %v", err)
+		}
+
+		got, err := provider.GetCommandFrequencies(0, 10)
+		if err != nil {
+			t.Fatalf("GetCommandFrequencies() unexpected error: %v", err)
+		}
+		want := []history.CommandFrequency{{Command: "make", Count: 4}, {Command: "git status", Count: 2}, {Command: "ls", Count: 1}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetCommandFrequencies() = %v, want %v", got, want)
+		}
+		wantSource := fmt.Sprintf("Files: %s [3 entries], %s [4 entries]", toUserFriendlyPath(zshHistory), toUserFriendlyPath(bashHistory))
+		if got := provider.GetSourceIdentifier(); got != wantSource {
+			t.Errorf("GetSourceIdentifier() = %q, want %q", got, wantSource)
+		}
+		if got := provider.GetHistoryFilePath(); got != zshHistory {
+			t.Errorf("GetHistoryFilePath() = %q, want the first source %q", got, zshHistory)
+		}
+	})
+
+	t.Run("explicit files are weighted", func(t *testing.T) {
+		provider := &HistoryProvider{Shell: "zsh"}
+		if err := provider.SelectHistorySources([]string{zshHistory + ":2", bashHistory + ":0.5"}); err != nil {
+			t.Fatalf("SelectHistorySources() unexpected error: %v", err)
+		}
+		wantSource := fmt.Sprintf("Files: %s [weight 2], %s [weight 0.5]", toUserFriendlyPath(zshHistory), toUserFriendlyPath(bashHistory))
+		if got := provider.GetSourceIdentifier(); got != wantSource {
+			t.Errorf("GetSourceIdentifier() before scanning = %q, want %q", got, wantSource)
+		}
+
+		got, err := provider.GetCommandFrequencies(0, 10)
+		if err != nil {
+			t.Fatalf("GetCommandFrequencies() unexpected error: %v", err)
+		}
+		// make: 1*2 + 3*0.5 = 3.5 rounds to 4; ls: 0.5 rounds to 1.
+		want := []history.CommandFrequency{{Command: "git status", Count: 4}, {Command: "make", Count: 4}, {Command: "ls", Count: 1}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetCommandFrequencies() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("single file keeps the plain identifier", func(t *testing.T) {
+		provider := &HistoryProvider{Shell: "bash"}
+		if err := provider.SelectHistorySources([]string{bashHistory}); err != nil {
+			t.Fatalf("SelectHistorySources() unexpected error: %v", err)
+		}
+		if got, want := provider.GetSourceIdentifier(), "File: "+toUserFriendlyPath(bashHistory); got != want {
+			t.Errorf("GetSourceIdentifier() = %q, want %q", got, want)
+		}
+		entries, err := provider.GetEntries(0)
+		if err != nil || len(entries) != 4 {
+			t.Errorf("GetEntries() = %v, %v, want the 4 entries of the file", entries, err)
+		}
+	})
+
+	t.Run("a file counts the same alone, weighted 1 or merged", func(t *testing.T) {
+		otherHistory := filepath.Join(dir, "other_history")
+		manageTestFile(t, otherHistory, []byte("docker ps\n"))
+		want := []history.CommandFrequency{{Command: "git status", Count: 2}, {Command: "make", Count: 1}}
+
+		for _, specs := range [][]string{{zshHistory}, {zshHistory + ":1"}, {zshHistory, otherHistory}} {
+			provider := &HistoryProvider{Shell: "zsh"}
+			if err := provider.SelectHistorySources(specs); err != nil {
+				t.Fatalf("SelectHistorySources(%v) unexpected error: %v", specs, err)
+			}
+			got, err := provider.GetCommandFrequencies(0, 10)
+			if err != nil {
+				t.Fatalf("GetCommandFrequencies() with %v unexpected error: %v", specs, err)
+			}
+			got = slices.DeleteFunc(got, func(f history.CommandFrequency) bool { return f.Command == "docker ps" })
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GetCommandFrequencies() with %v = %v, want %v", specs, got, want)
+			}
+		}
+	})
+
+	t.Run("nothing found keeps the original file", func(t *testing.T) {
+		provider := &HistoryProvider{Shell: "bash", HistoryFile: bashHistory, fileFinder: &testutil.MockHistoryFileFinder{}}
+		if err := provider.SelectHistorySources(nil); err != nil {
+			t.Fatalf("SelectHistorySources() unexpected error: %v", err)
+		}
+		if provider.HistoryFile != bashHistory || len(provider.sources) != 0 {
+			t.Errorf("SelectHistorySources() changed the provider: %+v", provider)
+		}
+	})
+
+	t.Run("finder error", func(t *testing.T) {
+		provider := &HistoryProvider{Shell: "bash", fileFinder: &testutil.MockHistoryFileFinder{FindAllFunc: func() ([]string, error) {
+			return nil, errors.New("boom")
+		}}}
+		if err := provider.SelectHistorySources(nil); err == nil || !strings.Contains(err.Error(), "finding history files: boom") {
+			t.Errorf("SelectHistorySources() error = %v, want finder error", err)
+		}
+	})
+}
+
+func TestHistoryBackendSelector_SelectHistorySources(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history")
+	manageTestFile(t, historyPath, []byte("ls\n"))
+
+	fileProvider := &HistoryProvider{Shell: "bash"}
+	selector := NewHistoryBackendSelector(fileProvider, nil, nil)
+	selector.newStructured = func(name string) (ports.HistoryProvider, error) {
+		return sourceProvider(name), nil // Every structured backend is available.
+	}
+
+	if err := selector.SelectHistorySources([]string{historyPath}); err != nil {
+		t.Fatalf("SelectHistorySources() unexpected error: %v", err)
+	}
+	if err := selector.SelectHistoryBackend("auto"); err != nil {
+		t.Fatalf("SelectHistoryBackend(auto) unexpected error: %v", err)
+	}
+	if got, want := selector.GetSourceIdentifier(), "File: "+toUserFriendlyPath(historyPath); got != want {
+		t.Errorf("GetSourceIdentifier() = %q, want explicit files to win over structured backends (%q)", got, want)
+	}
+	if err := selector.SelectHistoryBackend("atuin"); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("SelectHistoryBackend(atuin) error = %v, want an error about explicit history files", err)
+	}
+
+	t.Run("file provider without source selection", func(t *testing.T) {
+		selector, _ := newTestSelector(nil)
+		if err := selector.SelectHistorySources(nil); err != nil {
+			t.Errorf("SelectHistorySources(nil) unexpected error: %v", err)
+		}
+		if err := selector.SelectHistorySources([]string{historyPath}); err == nil {
+			t.Error("SelectHistorySources() expected an error when the file provider cannot select files")
+		}
+	})
+}
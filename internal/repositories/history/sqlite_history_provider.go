@@ -0,0 +1,149 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+
+	_ "modernc.org/sqlite" // Registers the pure Go "sqlite" database/sql driver.
+)
+
+/*
+SQLiteHistoryProvider provides access to shell command history stored in the
+SQLite database of a structured history tool (atuin, zsh-histdb or mcfly).
+Databases are always opened read-only.
+It implements the ports.HistoryProvider interface.
+*/
+type SQLiteHistoryProvider struct {
+	backend    sqliteBackend
+	dbPath     string             // Absolute path of the history database.
+	exclusions *HistoryExclusions // Entries to leave out of frequencies; nil excludes nothing.
+	logger     ports.Logger       // Can be nil, in which case nothing is logged.
+}
+
+// log returns the provider's logger, or ports.NopLogger if it has none.
+func (p *SQLiteHistoryProvider) log() ports.Logger {
+	if p.logger == nil {
+		return ports.NopLogger
+	}
+	return p.logger
+}
+
+// NewStructuredHistoryProvider creates a history provider for the named structured
+// backend ("atuin", "histdb" or "mcfly"). It returns an error if the backend is
+// unknown or its database cannot be found. exclusions can be nil if no history entries
+// should be excluded, and logger if nothing should be logged.
+func NewStructuredHistoryProvider(backendName string, exclusions *HistoryExclusions, logger ports.Logger) (ports.HistoryProvider, error) {
+	backend, ok := findSQLiteBackend(backendName)
+	if !ok {
+		return nil, fmt.Errorf("unknown structured history backend '%s'", backendName)
+	}
+	dbPath, err := backend.findDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return &SQLiteHistoryProvider{backend: backend, dbPath: dbPath, exclusions: exclusions, logger: logger}, nil
+}
+
+// GetCommandFrequencies implements the ports.HistoryProvider interface.
+func (p *SQLiteHistoryProvider) GetCommandFrequencies(scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
+	entries, err := p.GetEntries(scanLimit)
+	if err != nil {
+		return nil, err
+	}
+	return countFrequencies(entries, outputLimit), nil
+}
+
+// GetCommandFrequenciesInDir implements the ports.HistoryProvider interface.
+func (p *SQLiteHistoryProvider) GetCommandFrequenciesInDir(dir string, scanLimit int, outputLimit int) ([]history.CommandFrequency, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving directory %s: %w", dir, err)
+	}
+	scanCount, _ := determineScanCount(scanLimit)
+	entries, err := p.queryEntries(absDir, scanCount)
+	if err != nil {
+		return nil, err
+	}
+	kept, _, err := p.exclusions.apply(entries, p.log())
+	if err != nil {
+		return nil, err
+	}
+	return countFrequencies(kept, outputLimit), nil
+}
+
+// GetEntries implements the ports.HistoryProvider interface.
+func (p *SQLiteHistoryProvider) GetEntries(scanLimit int) ([]history.Entry, error) {
+	scanCount, _ := determineScanCount(scanLimit)
+	entries, err := p.queryEntries("", scanCount)
+	if err != nil {
+		return nil, err
+	}
+	kept, _, err := p.exclusions.apply(entries, p.log())
+	if err != nil {
+		return nil, err
+	}
+	return kept, nil
+}
+
+// ExplainExclusions implements the ports.HistoryProvider interface.
+func (p *SQLiteHistoryProvider) ExplainExclusions(scanLimit int) (history.ExclusionReport, error) {
+	scanCount, _ := determineScanCount(scanLimit)
+	entries, err := p.queryEntries("", scanCount)
+	if err != nil {
+		return history.ExclusionReport{}, err
+	}
+	_, report, err := p.exclusions.apply(entries, ports.NopLogger)
+	return report, err
+}
+
+// GetHistoryFilePath implements the ports.HistoryProvider interface.
+func (p *SQLiteHistoryProvider) GetHistoryFilePath() string {
+	return p.dbPath
+}
+
+// GetSourceIdentifier implements the ports.HistoryProvider interface.
+func (p *SQLiteHistoryProvider) GetSourceIdentifier() string {
+	return fmt.Sprintf("%s: %s", p.backend.displayName, toUserFriendlyPath(p.dbPath))
+}
+
+// queryEntries reads the limit most recent entries, oldest first.
+// If dir is not empty, only entries run in that directory are read.
+func (p *SQLiteHistoryProvider) queryEntries(dir string, limit int) ([]history.Entry, error) {
+	db, err := sql.Open("sqlite", readOnlyDSN(p.dbPath))
+	if err != nil {
+		return nil, fmt.Errorf("opening %s history database %s: %w", p.backend.displayName, toUserFriendlyPath(p.dbPath), err)
+	}
+	defer db.Close()
+
+	query, args := p.backend.buildQuery(dir, limit)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s history database %s: %w", p.backend.displayName, toUserFriendlyPath(p.dbPath), err)
+	}
+	defer rows.Close()
+
+	var entries []history.Entry
+	for rows.Next() {
+		var row sqliteHistoryRow
+		if err := rows.Scan(&row.command, &row.directory, &row.exitCode, &row.duration, &row.host, &row.timestamp); err != nil {
+			return nil, fmt.Errorf("reading %s history row: %w", p.backend.displayName, err)
+		}
+		if entry, ok := p.backend.toEntry(row); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s history rows: %w", p.backend.displayName, err)
+	}
+	p.log().Info("read history database", "backend", p.backend.name, "file", toUserFriendlyPath(p.dbPath), "entries", len(entries))
+
+	// Rows are read most recent first; return them in chronological order.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
@@ -0,0 +1,179 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
+
+/*
+sqliteBackend describes where a structured history tool keeps its database
+and how to read it. Every backend query selects, in order: command, directory,
+exit code, duration, host and start timestamp.
+*/
+type sqliteBackend struct {
+	name         string // Name used to select the backend, e.g. "atuin".
+	displayName  string // Name shown to the user, e.g. "Atuin".
+	envVar       string // Environment variable overriding the database path, if any.
+	defaultPaths func(homeDir, dataHome string) []string
+	selectFrom   string   // SELECT ... FROM ... part of the query.
+	conditions   []string // WHERE conditions always applied.
+	dirColumn    string
+	orderColumn  string
+	timeUnit     time.Duration // Unit of the timestamp column.
+	durationUnit time.Duration // Unit of the duration column.
+}
+
+// sqliteHistoryRow holds the raw, nullable columns of a history query.
+type sqliteHistoryRow struct {
+	command   sql.NullString
+	directory sql.NullString
+	exitCode  sql.NullInt64
+	duration  sql.NullInt64
+	host      sql.NullString
+	timestamp sql.NullInt64
+}
+
+// sqliteBackends lists the supported structured history backends, in auto-detection order.
+var sqliteBackends = []sqliteBackend{
+	{
+		name:        "atuin",
+		displayName: "Atuin",
+		envVar:      "ATUIN_DB_PATH",
+		defaultPaths: func(homeDir, dataHome string) []string {
+			return []string{filepath.Join(dataHome, "atuin", "history.db")}
+		},
+		selectFrom:   "SELECT command, cwd, exit, duration, hostname, timestamp FROM history",
+		conditions:   []string{"deleted_at IS NULL"},
+		dirColumn:    "cwd",
+		orderColumn:  "timestamp",
+		timeUnit:     time.Nanosecond,
+		durationUnit: time.Nanosecond,
+	},
+	{
+		name:        "histdb",
+		displayName: "zsh-histdb",
+		envVar:      "HISTDB_FILE",
+		defaultPaths: func(homeDir, dataHome string) []string {
+			return []string{filepath.Join(homeDir, ".histdb", "zsh-history.db")}
+		},
+		selectFrom: "SELECT commands.argv, places.dir, history.exit_status, history.duration, places.host, history.start_time " +
+			"FROM history LEFT JOIN commands ON history.command_id = commands.id LEFT JOIN places ON history.place_id = places.id",
+		dirColumn:    "places.dir",
+		orderColumn:  "history.start_time",
+		timeUnit:     time.Second,
+		durationUnit: time.Second,
+	},
+	{
+		name:        "mcfly",
+		displayName: "McFly",
+		defaultPaths: func(homeDir, dataHome string) []string {
+			return []string{
+				filepath.Join(dataHome, "mcfly", "history.db"),
+				filepath.Join(homeDir, ".mcfly", "history.db"), // Location used by older McFly versions.
+			}
+		},
+		selectFrom:   "SELECT cmd, dir, exit_code, NULL, NULL, when_run FROM commands",
+		dirColumn:    "dir",
+		orderColumn:  "when_run",
+		timeUnit:     time.Second,
+		durationUnit: time.Second,
+	},
+}
+
+// findSQLiteBackend looks up a structured history backend by name.
+func findSQLiteBackend(name string) (sqliteBackend, bool) {
+	for _, backend := range sqliteBackends {
+		if backend.name == name {
+			return backend, true
+		}
+	}
+	return sqliteBackend{}, false
+}
+
+// findDatabase returns the path of the backend's database, checking the
+// override environment variable first and then the default locations.
+func (b sqliteBackend) findDatabase() (string, error) {
+	if b.envVar != "" {
+		if envPath := os.Getenv(b.envVar); envPath != "" {
+			if _, err := os.Stat(envPath); err != nil {
+				return "", fmt.Errorf("%w: %s database set by %s not found at %s", history.ErrHistoryNotFound, b.displayName, b.envVar, envPath)
+			}
+			return envPath, nil
+		}
+	}
+
+	usr, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("getting current user: %w", err)
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(usr.HomeDir, ".local", "share")
+	}
+
+	candidates := b.defaultPaths(usr.HomeDir, dataHome)
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s history database not found (looked in %s)", history.ErrHistoryNotFound, b.displayName, strings.Join(candidates, ", "))
+}
+
+// buildQuery returns the query reading the limit most recent entries, most recent first,
+// optionally restricted to the given directory.
+func (b sqliteBackend) buildQuery(dir string, limit int) (string, []any) {
+	conditions := append([]string{}, b.conditions...)
+	args := []any{}
+	if dir != "" {
+		conditions = append(conditions, b.dirColumn+" = ?")
+		args = append(args, dir)
+	}
+
+	query := b.selectFrom
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY " + b.orderColumn + " DESC LIMIT ?"
+	args = append(args, limit)
+	return query, args
+}
+
+// toEntry converts a raw row to a history entry. It returns false for rows without a command.
+// Negative exit codes and durations are used by some tools for "not recorded yet".
+func (b sqliteBackend) toEntry(row sqliteHistoryRow) (history.Entry, bool) {
+	command := strings.TrimSpace(row.command.String)
+	if !row.command.Valid || command == "" {
+		return history.Entry{}, false
+	}
+
+	entry := history.Entry{
+		Command:   command,
+		Directory: row.directory.String,
+		Host:      row.host.String,
+	}
+	if row.exitCode.Valid && row.exitCode.Int64 >= 0 {
+		entry.ExitCode = int(row.exitCode.Int64)
+		entry.HasExitCode = true
+	}
+	if row.duration.Valid && row.duration.Int64 > 0 {
+		entry.Duration = time.Duration(row.duration.Int64) * b.durationUnit
+	}
+	if row.timestamp.Valid && row.timestamp.Int64 > 0 {
+		entry.Timestamp = time.Unix(0, row.timestamp.Int64*int64(b.timeUnit))
+	}
+	return entry, true
+}
+
+// readOnlyDSN returns a data source name opening the database at dbPath in read-only mode.
+func readOnlyDSN(dbPath string) string {
+	return (&url.URL{Scheme: "file", Path: dbPath, RawQuery: "mode=ro"}).String()
+}
@@ -0,0 +1,172 @@
+package history
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/history"
+)
+
+// createTestDatabase creates a SQLite database at path and runs the given statements.
+func createTestDatabase(t *testing.T, path string, statements ...string) {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to run %q: %v", stmt, err)
+		}
+	}
+}
+
+func TestSQLiteHistoryProvider_Backends(t *testing.T) {
+	const projectDir = "/work/app"
+	base := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		backend     string
+		statements  []string
+		wantEntries []history.Entry
+	}{
+		{
+			name:    "atuin",
+			backend: "atuin",
+			statements: []string{
+				`CREATE TABLE history (id TEXT PRIMARY KEY, timestamp INTEGER NOT NULL, duration INTEGER NOT NULL, exit INTEGER NOT NULL,
+					command TEXT NOT NULL, cwd TEXT NOT NULL, session TEXT NOT NULL, hostname TEXT NOT NULL, deleted_at INTEGER)`,
+				`INSERT INTO history VALUES ('1', ` + strconv.FormatInt(base.UnixNano(), 10) + `, 2000000000, 0, 'make test', '/work/app', 's', 'laptop:me', NULL)`,
+				`INSERT INTO history VALUES ('2', ` + strconv.FormatInt(base.Add(time.Minute).UnixNano(), 10) + `, -1, 127, 'gti status', '/tmp', 's', 'laptop:me', NULL)`,
+				`INSERT INTO history VALUES ('3', ` + strconv.FormatInt(base.Add(2*time.Minute).UnixNano(), 10) + `, 1, 0, 'rm secret', '/tmp', 's', 'laptop:me', 1)`,
+			},
+			wantEntries: []history.Entry{
+				{Command: "make test", Directory: "/work/app", ExitCode: 0, HasExitCode: true, Duration: 2 * time.Second, Host: "laptop:me", Timestamp: base},
+				{Command: "gti status", Directory: "/tmp", ExitCode: 127, HasExitCode: true, Host: "laptop:me", Timestamp: base.Add(time.Minute)},
+			},
+		},
+		{
+			name:    "zsh-histdb",
+			backend: "histdb",
+			statements: []string{
+				`CREATE TABLE commands (id INTEGER PRIMARY KEY, argv TEXT)`,
+				`CREATE TABLE places (id INTEGER PRIMARY KEY, host TEXT, dir TEXT)`,
+				`CREATE TABLE history (id INTEGER PRIMARY KEY, session INT, command_id INT, place_id INT, exit_status INT, start_time INT, duration INT)`,
+				`INSERT INTO commands VALUES (1, 'make test'), (2, 'gti status')`,
+				`INSERT INTO places VALUES (1, 'laptop', '/work/app'), (2, 'laptop', '/tmp')`,
+				`INSERT INTO history VALUES (1, 1, 1, 1, 0, ` + strconv.FormatInt(base.Unix(), 10) + `, 2)`,
+				`INSERT INTO history VALUES (2, 1, 2, 2, NULL, ` + strconv.FormatInt(base.Add(time.Minute).Unix(), 10) + `, NULL)`,
+			},
+			wantEntries: []history.Entry{
+				{Command: "make test", Directory: "/work/app", ExitCode: 0, HasExitCode: true, Duration: 2 * time.Second, Host: "laptop", Timestamp: base},
+				{Command: "gti status", Directory: "/tmp", Host: "laptop", Timestamp: base.Add(time.Minute)},
+			},
+		},
+		{
+			name:    "mcfly",
+			backend: "mcfly",
+			statements: []string{
+				`CREATE TABLE commands (id INTEGER PRIMARY KEY, cmd TEXT NOT NULL, cmd_tpl TEXT, session_id TEXT, when_run INTEGER, exit_code INTEGER, selected INTEGER, dir TEXT, old_dir TEXT)`,
+				`INSERT INTO commands VALUES (1, 'make test', '', 's', ` + strconv.FormatInt(base.Unix(), 10) + `, 0, 0, '/work/app', NULL)`,
+				`INSERT INTO commands VALUES (2, 'gti status', '', 's', ` + strconv.FormatInt(base.Add(time.Minute).Unix(), 10) + `, 127, 0, '/tmp', NULL)`,
+			},
+			wantEntries: []history.Entry{
+				{Command: "make test", Directory: "/work/app", ExitCode: 0, HasExitCode: true, Timestamp: base},
+				{Command: "gti status", Directory: "/tmp", ExitCode: 127, HasExitCode: true, Timestamp: base.Add(time.Minute)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, ok := findSQLiteBackend(tt.backend)
+			if !ok {
+				t.Fatalf("backend %q not registered", tt.backend)
+			}
+			dbPath := filepath.Join(t.TempDir(), "history.db")
+			createTestDatabase(t, dbPath, tt.statements...)
+			provider := &SQLiteHistoryProvider{backend: backend, dbPath: dbPath}
+
+			entries, err := provider.GetEntries(100)
+			if err != nil {
+				t.Fatalf("GetEntries() unexpected error: %v", err)
+			}
+			for i := range entries {
+				entries[i].Timestamp = entries[i].Timestamp.UTC()
+			}
+			if !reflect.DeepEqual(entries, tt.wantEntries) {
+				t.Errorf("GetEntries() = %+v, want %+v", entries, tt.wantEntries)
+			}
+
+			inDir, err := provider.GetCommandFrequenciesInDir(projectDir, 100, 10)
+			if err != nil {
+				t.Fatalf("GetCommandFrequenciesInDir() unexpected error: %v", err)
+			}
+			wantInDir := []history.CommandFrequency{{Command: "make test", Count: 1}}
+			if !reflect.DeepEqual(inDir, wantInDir) {
+				t.Errorf("GetCommandFrequenciesInDir() = %v, want %v", inDir, wantInDir)
+			}
+
+			if !strings.HasPrefix(provider.GetSourceIdentifier(), backend.displayName+": ") {
+				t.Errorf("GetSourceIdentifier() = %q, want prefix %q", provider.GetSourceIdentifier(), backend.displayName+": ")
+			}
+		})
+	}
+}
+
+func TestSQLiteHistoryProvider_ReadOnly(t *testing.T) {
+	backend, _ := findSQLiteBackend("mcfly")
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+	createTestDatabase(t, dbPath, `CREATE TABLE commands (id INTEGER PRIMARY KEY, cmd TEXT, when_run INTEGER, exit_code INTEGER, dir TEXT)`)
+
+	db, err := sql.Open("sqlite", readOnlyDSN(dbPath))
+	if err != nil {
+		t.Fatalf("Failed to open read-only database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`INSERT INTO commands (cmd) VALUES ('x')`); err == nil {
+		t.Errorf("write through readOnlyDSN(%q) succeeded, want read-only error", dbPath)
+	}
+
+	provider := &SQLiteHistoryProvider{backend: backend, dbPath: dbPath}
+	if _, err := provider.GetEntries(10); err != nil {
+		t.Errorf("GetEntries() on empty database unexpected error: %v", err)
+	}
+}
+
+func TestNewStructuredHistoryProvider(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "zsh-history.db")
+	createTestDatabase(t, dbPath, `CREATE TABLE commands (id INTEGER PRIMARY KEY, argv TEXT)`)
+
+	t.Run("database from environment variable", func(t *testing.T) {
+		setupEnvVar(t, "HISTDB_FILE", dbPath)
+		provider, err := NewStructuredHistoryProvider("histdb", nil, nil)
+		if err != nil {
+			t.Fatalf("NewStructuredHistoryProvider() unexpected error: %v", err)
+		}
+		if provider.GetHistoryFilePath() != dbPath {
+			t.Errorf("GetHistoryFilePath() = %q, want %q", provider.GetHistoryFilePath(), dbPath)
+		}
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		setupEnvVar(t, "HISTDB_FILE", filepath.Join(t.TempDir(), "missing.db"))
+		if _, err := NewStructuredHistoryProvider("histdb", nil, nil); err == nil {
+			t.Error("NewStructuredHistoryProvider() expected error for missing database")
+		}
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := NewStructuredHistoryProvider("fish", nil, nil)
+		if err == nil || !strings.Contains(err.Error(), "unknown structured history backend") {
+			t.Errorf("NewStructuredHistoryProvider() error = %v, want unknown backend error", err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package projectconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectAliasesFilename is the name of the project-local alias file.
+const ProjectAliasesFilename = ".nicksh.yaml"
+
+/*
+ProjectAliasProvider reads project-local aliases from .nicksh.yaml files.
+The file uses the same schema as predefined_aliases.yaml: a list of
+entries with an "alias" and a "command" key.
+It implements the ports.ProjectAliasProvider interface.
+*/
+type ProjectAliasProvider struct {
+	logger ports.Logger
+}
+
+// NewProjectAliasProvider creates a new ProjectAliasProvider.
+// logger receives the project alias files found and read, and can be nil.
+func NewProjectAliasProvider(logger ports.Logger) ports.ProjectAliasProvider {
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &ProjectAliasProvider{logger: logger}
+}
+
+// FindProjectFile implements the ports.ProjectAliasProvider interface.
+// It checks dir and each of its parents for a .nicksh.yaml file and returns the nearest one.
+func (p *ProjectAliasProvider) FindProjectFile(dir string) (string, error) {
+	currentDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving directory %s: %w", dir, err)
+	}
+
+	for {
+		candidate := filepath.Join(currentDir, ProjectAliasesFilename)
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			p.logger.Debug("found project alias file", "file", candidate)
+			return candidate, nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("checking project alias file %s: %w", candidate, err)
+		}
+
+		parentDir := filepath.Dir(currentDir)
+		if parentDir == currentDir {
+			return "", nil // Reached the filesystem root without finding a project file.
+		}
+		currentDir = parentDir
+	}
+}
+
+// GetProjectAliases implements the ports.ProjectAliasProvider interface.
+func (p *ProjectAliasProvider) GetProjectAliases(projectFile string) ([]alias.Alias, error) {
+	content, err := os.ReadFile(projectFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project alias file %s: %w", projectFile, err)
+	}
+
+	projectAliases := []alias.Alias{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true) // Catch typos in the YAML structure
+
+	if err := decoder.Decode(&projectAliases); err != nil {
+		if errors.Is(err, io.EOF) {
+			// An empty file (or one with only comments) defines no aliases.
+			return []alias.Alias{}, nil
+		}
+		return nil, fmt.Errorf("failed to parse project alias file %s: %w", projectFile, err)
+	}
+	p.logger.Debug("read project alias file", "file", projectFile, "aliases", len(projectAliases))
+	return projectAliases, nil
+}
@@ -0,0 +1,111 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestProjectAliasProvider_FindProjectFile(t *testing.T) {
+	root := t.TempDir()
+	projectDir := filepath.Join(root, "project")
+	nestedDir := filepath.Join(projectDir, "src", "pkg")
+	otherDir := filepath.Join(root, "other")
+	writeTestFile(t, filepath.Join(projectDir, ProjectAliasesFilename), "- alias: ti\n  command: make test-integration\n")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+	if err := os.MkdirAll(otherDir, 0755); err != nil {
+		t.Fatalf("Failed to create other dir: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		startDir string
+		want     string
+	}{
+		{name: "file in start directory", startDir: projectDir, want: filepath.Join(projectDir, ProjectAliasesFilename)},
+		{name: "file in a parent directory", startDir: nestedDir, want: filepath.Join(projectDir, ProjectAliasesFilename)},
+		{name: "no file up to the root", startDir: otherDir, want: ""},
+	}
+
+	p := NewProjectAliasProvider(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.FindProjectFile(tt.startDir)
+			if err != nil {
+				t.Fatalf("FindProjectFile() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FindProjectFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectAliasProvider_GetProjectAliases(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		content    string
+		want       []alias.Alias
+		wantErrMsg string
+	}{
+		{
+			name:    "valid aliases",
+			content: "- alias: ti\n  command: \"make test-integration\"\n- alias: dev\n  command: \"npm run dev\"\n",
+			want: []alias.Alias{
+				{Name: "ti", Command: "make test-integration"},
+				{Name: "dev", Command: "npm run dev"},
+			},
+		},
+		{
+			name:    "empty file",
+			content: "# nothing here yet\n",
+			want:    []alias.Alias{},
+		},
+		{
+			name:       "unknown field",
+			content:    "- alias: ti\n  cmd: make\n",
+			wantErrMsg: "failed to parse project alias file",
+		},
+	}
+
+	p := NewProjectAliasProvider(nil)
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, string(rune('a'+i)), ProjectAliasesFilename)
+			writeTestFile(t, path, tt.content)
+
+			got, err := p.GetProjectAliases(path)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("GetProjectAliases() error = %v, want error containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetProjectAliases() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetProjectAliases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
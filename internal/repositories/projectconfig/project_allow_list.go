@@ -0,0 +1,171 @@
+package projectconfig
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+// allowListFilename is the name of the allow list, in the nicksh directory of the user's data directory.
+const allowListFilename = "allowed_projects"
+
+/*
+ProjectAllowList records the project alias files the user allowed, like direnv's
+'direnv allow'. Each line of the allow list holds the SHA-256 of an allowed file's
+content and its absolute path, as written by sha256sum, so a file that changed
+after being allowed is no longer allowed.
+It implements the ports.ProjectAllowList interface.
+*/
+type ProjectAllowList struct {
+	path   string // Path of the allow list; "" is DefaultAllowListFile.
+	logger ports.Logger
+}
+
+// NewProjectAllowList creates a new ProjectAllowList, kept in DefaultAllowListFile.
+// logger receives the files allowed and denied, and can be nil.
+func NewProjectAllowList(logger ports.Logger) ports.ProjectAllowList {
+	if logger == nil {
+		logger = ports.NopLogger
+	}
+	return &ProjectAllowList{logger: logger}
+}
+
+// DefaultAllowListFile returns the path of the allow list: allowed_projects in the nicksh
+// directory of $XDG_DATA_HOME, or of ~/.local/share if it is not set.
+// It is kept out of $HOME/.nicksh, whose files are all sourced as alias files.
+func DefaultAllowListFile() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		usr, err := user.Current()
+		if err != nil {
+			return "", fmt.Errorf("locating the project allow list: %w", err)
+		}
+		dataHome = filepath.Join(usr.HomeDir, ".local", "share")
+	}
+	return filepath.Join(dataHome, "nicksh", allowListFilename), nil
+}
+
+// Allow implements the ports.ProjectAllowList interface.
+func (l *ProjectAllowList) Allow(projectFile string) error {
+	absPath, entry, err := allowListEntry(projectFile)
+	if err != nil {
+		return err
+	}
+	return l.update(absPath, entry)
+}
+
+// Deny implements the ports.ProjectAllowList interface.
+func (l *ProjectAllowList) Deny(projectFile string) error {
+	absPath, err := filepath.Abs(projectFile)
+	if err != nil {
+		return fmt.Errorf("resolving project alias file %s: %w", projectFile, err)
+	}
+	return l.update(absPath, "")
+}
+
+// IsAllowed implements the ports.ProjectAllowList interface.
+func (l *ProjectAllowList) IsAllowed(projectFile string) (bool, error) {
+	_, entry, err := allowListEntry(projectFile)
+	if err != nil {
+		return false, err
+	}
+	entries, err := l.readEntries()
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if e == entry {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// update rewrites the allow list without the entry of the file at absPath,
+// and with entry added if it is not empty.
+func (l *ProjectAllowList) update(absPath, entry string) error {
+	path, err := l.filePath()
+	if err != nil {
+		return err
+	}
+	entries, err := l.readEntries()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		if _, entryPath, _ := strings.Cut(e, "  "); entryPath != absPath {
+			b.WriteString(e + "\n")
+		}
+	}
+	if entry != "" {
+		b.WriteString(entry + "\n")
+		l.logger.Debug("allowed project alias file", "file", absPath)
+	} else {
+		l.logger.Debug("denied project alias file", "file", absPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating the directory of the project allow list: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("writing the project allow list %s: %w", path, err)
+	}
+	return nil
+}
+
+// readEntries returns the lines of the allow list. A missing allow list allows nothing.
+func (l *ProjectAllowList) readEntries() ([]string, error) {
+	path, err := l.filePath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the project allow list %s: %w", path, err)
+	}
+	var entries []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// filePath returns the path of the allow list.
+func (l *ProjectAllowList) filePath() (string, error) {
+	if l.path != "" {
+		return l.path, nil
+	}
+	return DefaultAllowListFile()
+}
+
+// allowListEntry returns the absolute path of the project alias file at projectFile,
+// and its allow list line, as it is now.
+func allowListEntry(projectFile string) (absPath string, entry string, err error) {
+	absPath, err = filepath.Abs(projectFile)
+	if err != nil {
+		return "", "", fmt.Errorf("resolving project alias file %s: %w", projectFile, err)
+	}
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read project alias file %s: %w", absPath, err)
+	}
+	sum := sha256.Sum256(content)
+	return absPath, hex.EncodeToString(sum[:]) + "  " + absPath, nil
+}
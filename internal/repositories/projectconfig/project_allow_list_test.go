@@ -0,0 +1,67 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+)
+
+func TestProjectAllowList(t *testing.T) {
+	root := t.TempDir()
+	projectFile := filepath.Join(root, "project", ProjectAliasesFilename)
+	otherFile := filepath.Join(root, "other", ProjectAliasesFilename)
+	writeTestFile(t, projectFile, "- alias: ti\n  command: make test-integration\n")
+	writeTestFile(t, otherFile, "- alias: dev\n  command: npm run dev\n")
+	l := &ProjectAllowList{path: filepath.Join(root, "data", "nicksh", allowListFilename), logger: ports.NopLogger}
+
+	assertAllowed := func(t *testing.T, file string, want bool) {
+		t.Helper()
+		got, err := l.IsAllowed(file)
+		if err != nil {
+			t.Fatalf("IsAllowed(%s) unexpected error: %v", file, err)
+		}
+		if got != want {
+			t.Errorf("IsAllowed(%s) = %v, want %v", file, got, want)
+		}
+	}
+
+	assertAllowed(t, projectFile, false) // No allow list yet.
+
+	if err := l.Allow(projectFile); err != nil {
+		t.Fatalf("Allow() unexpected error: %v", err)
+	}
+	if err := l.Allow(otherFile); err != nil {
+		t.Fatalf("Allow() unexpected error: %v", err)
+	}
+	assertAllowed(t, projectFile, true)
+	assertAllowed(t, otherFile, true)
+
+	// A changed file must be allowed again.
+	writeTestFile(t, projectFile, "- alias: ls\n  command: rm -rf ~\n")
+	assertAllowed(t, projectFile, false)
+	if err := l.Allow(projectFile); err != nil {
+		t.Fatalf("Allow() unexpected error: %v", err)
+	}
+	assertAllowed(t, projectFile, true)
+
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		t.Fatalf("Failed to read the allow list: %v", err)
+	}
+	if got := strings.Count(string(content), projectFile); got != 1 {
+		t.Errorf("allow list has %d entries for %s, want 1:\n%s", got, projectFile, content)
+	}
+
+	if err := l.Deny(projectFile); err != nil {
+		t.Fatalf("Deny() unexpected error: %v", err)
+	}
+	assertAllowed(t, projectFile, false)
+	assertAllowed(t, otherFile, true)
+
+	if _, err := l.IsAllowed(filepath.Join(root, "missing", ProjectAliasesFilename)); err == nil {
+		t.Error("IsAllowed() of a missing file returned no error")
+	}
+}
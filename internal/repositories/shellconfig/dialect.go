@@ -0,0 +1,300 @@
+package shellconfig
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+)
+
+// lineParser parses a line of an alias file, returning the alias it defines, if any.
+type lineParser func(line string) (name string, command string, kind string, isDefinition bool)
+
+// shellDialect describes how a shell defines aliases: the syntax and quoting of a definition,
+// how to read one back, and the startup file code loading the alias files of $HOME/.nicksh.
+type shellDialect struct {
+	name        string   // Name used to select the dialect, e.g. "nu".
+	displayName string   // Name shown to the user, e.g. "Nushell".
+	shells      []string // Other base names of $SHELL using the dialect, e.g. "powershell".
+	styles      []string // Styles definitions can be written in, the default first.
+	kinds       []string // Alias kinds the shell supports.
+	format      func(a alias.Alias, style string) string
+	quote       func(s string) string // Quotes s as a literal string argument.
+	// unload returns the command removing the definition of name, written in style, from a running shell.
+	unload      func(name, style string) string
+	parse       lineParser
+	loader      string // Code to add to a startup file to load the alias files.
+	startupFile string // Startup file the loader is added to, e.g. "~/.zshrc".
+	reload      string // Command reloading startupFile in a running shell; "" if it cannot be.
+	// singleFile is set if the loader only loads the default group file, generated_aliases,
+	// in which case no other group can be written to or read from.
+	singleFile bool
+}
+
+const posixAliasLoader = `if [ -d "$HOME/.nicksh" ]; then
+  for file in "$HOME/.nicksh"/*; do
+    [ -f "$file" ] && source "$file"
+  done
+fi`
+
+// shellDialects lists the supported dialects. The first one, POSIX aliases, is also used for unknown shells.
+var shellDialects = []shellDialect{
+	{
+		name:        "bash",
+		displayName: "bash",
+		shells:      []string{"sh", "dash", "ksh", "mksh"},
+		styles:      []string{aliasStyle},
+		kinds:       []string{alias.KindRegular},
+		format:      func(a alias.Alias, _ string) string { return formatAliasLine(a) },
+		quote:       posixSingleQuote,
+		unload:      func(name, _ string) string { return "unalias " + name },
+		parse:       parseAliasLineFromString,
+		loader:      posixAliasLoader,
+		startupFile: "~/.bashrc",
+		reload:      "source ~/.bashrc",
+	},
+	{
+		name:        "zsh",
+		displayName: "zsh",
+		styles:      []string{aliasStyle},
+		kinds:       []string{alias.KindRegular, alias.KindGlobal, alias.KindSuffix},
+		format:      func(a alias.Alias, _ string) string { return formatAliasLine(a) },
+		quote:       posixSingleQuote,
+		unload:      func(name, _ string) string { return "unalias " + name },
+		parse:       parseAliasLineFromString,
+		loader:      posixAliasLoader,
+		startupFile: "~/.zshrc",
+		reload:      "source ~/.zshrc",
+	},
+	{
+		name:        "fish",
+		displayName: "fish",
+		styles:      []string{abbrStyle, aliasStyle},
+		kinds:       []string{alias.KindRegular},
+		format: func(a alias.Alias, style string) string {
+			if style == aliasStyle {
+				return formatFishAliasLine(a)
+			}
+			return formatAbbrLine(a)
+		},
+		quote: fishSingleQuote,
+		unload: func(name, style string) string {
+			if style == aliasStyle {
+				return "functions --erase " + name // fish aliases are functions.
+			}
+			return "abbr --erase " + name
+		},
+		parse: parseFishDefinitionLine,
+		loader: `if test -d "$HOME/.nicksh"
+    for file in "$HOME/.nicksh"/*
+        if test -f "$file"
+            source "$file"
+        end
+    end
+end`,
+		startupFile: "~/.config/fish/config.fish",
+		reload:      "source ~/.config/fish/config.fish",
+	},
+	{
+		name:        "nu",
+		displayName: "Nushell",
+		shells:      []string{"nushell"},
+		styles:      []string{aliasStyle},
+		kinds:       []string{alias.KindRegular},
+		format:      func(a alias.Alias, _ string) string { return formatNuAliasLine(a) },
+		quote:       nuQuote,
+		unload:      func(name, _ string) string { return "hide " + name },
+		parse:       parseNuAliasLine,
+		// Nushell resolves the files to source when it parses the configuration, so they cannot be
+		// globbed: only the default group is loaded, and aliases cannot be put in other groups.
+		loader:      `source ~/.nicksh/generated_aliases`,
+		startupFile: "~/.config/nushell/config.nu",
+		singleFile:  true,
+	},
+	{
+		name:        "pwsh",
+		displayName: "PowerShell",
+		shells:      []string{"powershell"},
+		styles:      []string{aliasStyle},
+		kinds:       []string{alias.KindRegular},
+		format:      func(a alias.Alias, _ string) string { return formatPowerShellAliasLine(a) },
+		quote:       powerShellSingleQuote,
+		unload:      func(name, _ string) string { return "Remove-Item alias:" + name },
+		parse:       parsePowerShellAliasLine,
+		// The alias files have no .ps1 extension, so they are run as script blocks rather than dot-sourced.
+		loader: `if (Test-Path "$HOME/.nicksh") {
+    foreach ($file in Get-ChildItem "$HOME/.nicksh" -File) {
+        . ([scriptblock]::Create((Get-Content -Raw $file.FullName)))
+    }
+}`,
+		startupFile: "your PowerShell profile ($PROFILE)",
+		reload:      ". $PROFILE",
+	},
+}
+
+// findShellDialect returns the dialect named name, or used by the shell named name.
+func findShellDialect(name string) (shellDialect, bool) {
+	for _, d := range shellDialects {
+		if d.name == name || slices.Contains(d.shells, name) {
+			return d, true
+		}
+	}
+	return shellDialect{}, false
+}
+
+// dialectForShell returns the dialect of shell, the base name of $SHELL.
+// Unknown shells are assumed to define aliases as POSIX shells do.
+func dialectForShell(shell string) shellDialect {
+	if d, ok := findShellDialect(shell); ok {
+		return d
+	}
+	return shellDialects[0]
+}
+
+// formatFishAliasLine renders an alias as a single fish alias line, e.g. "alias gs='git status'".
+func formatFishAliasLine(a alias.Alias) string {
+	return fmt.Sprintf("alias %s=%s\n", a.Name, fishSingleQuote(a.Command))
+}
+
+// parseFishDefinitionLine parses a line defining a fish alias or abbreviation.
+// An abbreviation is read as a regular alias of the same name.
+func parseFishDefinitionLine(line string) (name string, command string, kind string, isDefinition bool) {
+	if name, command, isAbbr := parseAbbrLineFromString(line); isAbbr {
+		return name, command, alias.KindRegular, true
+	}
+	words, ok := fishWords(strings.TrimSpace(line))
+	if !ok || len(words) < 2 || words[0] != "alias" || strings.HasPrefix(words[1], "-") {
+		return "", "", "", false // Options such as --save are not definitions nicksh manages.
+	}
+	if len(words) == 2 {
+		name, command, found := strings.Cut(words[1], "=")
+		return name, command, alias.KindRegular, found
+	}
+	// Like fish, the words after the name are joined into the definition.
+	return words[1], strings.Join(words[2:], " "), alias.KindRegular, true
+}
+
+// formatNuAliasLine renders an alias as a single Nushell alias line, e.g. "alias gs = git status".
+// Nushell parses the right-hand side as a command, so it is not quoted.
+func formatNuAliasLine(a alias.Alias) string {
+	return fmt.Sprintf("alias %s = %s\n", a.Name, a.Command)
+}
+
+// nuQuote quotes s for Nushell. Single-quoted strings have no escapes, so strings
+// containing a single quote are written as raw strings, e.g. r#'it's'#.
+func nuQuote(s string) string {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	hashes := "#"
+	for strings.Contains(s, "'"+hashes) {
+		hashes += "#"
+	}
+	return "r" + hashes + "'" + s + "'" + hashes
+}
+
+// parseNuAliasLine parses a line defining a Nushell alias, e.g. "alias gs = git status"
+// or "export alias gs = git status".
+func parseNuAliasLine(line string) (name string, command string, kind string, isAlias bool) {
+	trimmedLine := strings.TrimSpace(line)
+	trimmedLine = strings.TrimPrefix(trimmedLine, "export ")
+	content, found := strings.CutPrefix(trimmedLine, "alias ")
+	if !found {
+		return "", "", "", false
+	}
+	name, command, found = strings.Cut(content, "=")
+	name, command = strings.TrimSpace(name), strings.TrimSpace(command)
+	if !found || name == "" || command == "" || strings.ContainsAny(name, " \t") {
+		return "", "", "", false
+	}
+	return name, command, alias.KindRegular, true
+}
+
+// powerShellCommandNameRegex matches a command without arguments, which Set-Alias can name directly.
+var powerShellCommandNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.~/\\:-]+$`)
+
+// powerShellFunctionRegex matches a single-line function forwarding its arguments, as written by
+// formatPowerShellAliasLine, e.g. "function gs { git status @args }".
+var powerShellFunctionRegex = regexp.MustCompile(`(?i)^function\s+([^\s{(]+)\s*\{\s*(.*?)\s*@args\s*\}$`)
+
+// formatPowerShellAliasLine renders an alias as a single PowerShell line. Set-Alias can only name
+// a command, so commands with arguments are wrapped in a function that passes its own arguments on.
+func formatPowerShellAliasLine(a alias.Alias) string {
+	if powerShellCommandNameRegex.MatchString(a.Command) {
+		return fmt.Sprintf("Set-Alias -Name %s -Value %s\n", a.Name, a.Command)
+	}
+	return fmt.Sprintf("function %s { %s @args }\n", a.Name, a.Command)
+}
+
+// powerShellSingleQuote quotes s for PowerShell, doubling embedded single quotes.
+func powerShellSingleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// parsePowerShellAliasLine parses a line defining a PowerShell alias, e.g. "Set-Alias -Name g -Value git"
+// or "Set-Alias g git", or a function forwarding its arguments, e.g. "function gs { git status @args }".
+// Other functions are not aliases.
+func parsePowerShellAliasLine(line string) (name string, command string, kind string, isAlias bool) {
+	trimmedLine := strings.TrimSpace(line)
+	if m := powerShellFunctionRegex.FindStringSubmatch(trimmedLine); m != nil {
+		if m[2] == "" {
+			return "", "", "", false
+		}
+		return m[1], m[2], alias.KindRegular, true
+	}
+
+	words := strings.Fields(trimmedLine)
+	if len(words) == 0 || !strings.EqualFold(words[0], "Set-Alias") {
+		return "", "", "", false
+	}
+	var positional []string
+	for i := 1; i < len(words); i++ {
+		word := words[i]
+		if !strings.HasPrefix(word, "-") {
+			positional = append(positional, unquotePowerShell(word))
+			continue
+		}
+		switch strings.ToLower(word) {
+		case "-force", "-passthru":
+			continue // Switches take no value.
+		case "-name", "-value", "-scope", "-option", "-description":
+			if i+1 >= len(words) {
+				return "", "", "", false
+			}
+			i++
+			switch strings.ToLower(word) {
+			case "-name":
+				name = unquotePowerShell(words[i])
+			case "-value":
+				command = unquotePowerShell(words[i])
+			}
+		default:
+			return "", "", "", false
+		}
+	}
+	// Unnamed arguments fill in the name first, then the value.
+	for _, arg := range positional {
+		switch {
+		case name == "":
+			name = arg
+		case command == "":
+			command = arg
+		default:
+			return "", "", "", false
+		}
+	}
+	if name == "" || command == "" {
+		return "", "", "", false
+	}
+	return name, command, alias.KindRegular, true
+}
+
+// unquotePowerShell removes the quotes around a PowerShell argument, if any.
+func unquotePowerShell(word string) string {
+	if len(word) >= 2 && (word[0] == '\'' || word[0] == '"') && word[len(word)-1] == word[0] {
+		return word[1 : len(word)-1]
+	}
+	return word
+}
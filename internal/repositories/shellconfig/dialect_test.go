@@ -0,0 +1,312 @@
+package shellconfig
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/ with the current output")
+
+// assertGolden compares got with the golden file testdata/name, or rewrites it with -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("Failed to update golden file %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file %s (run the test with -update to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// goldenAliases are written in every dialect, but for the kinds it does not support.
+var goldenAliases = []alias.Alias{
+	{Name: "g", Command: "git"},
+	{Name: "gs", Command: "git status"},
+	{Name: "lg", Command: "git log --oneline | less"},
+	{Name: "hi", Command: `echo 'it''s' "$HOME" \o/`},
+	{Name: "G", Command: "| grep -i", Kind: alias.KindGlobal},
+	{Name: "md", Command: "code", Kind: alias.KindSuffix},
+}
+
+func TestShellDialects_Golden(t *testing.T) {
+	tests := []struct {
+		shell  string
+		style  string
+		golden string
+	}{
+		{shell: "bash", golden: "bash.golden"},
+		{shell: "zsh", golden: "zsh.golden"},
+		{shell: "fish", golden: "fish.golden"},
+		{shell: "fish", style: aliasStyle, golden: "fish_alias.golden"},
+		{shell: "nu", golden: "nu.golden"},
+		{shell: "pwsh", golden: "pwsh.golden"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.TrimSuffix(tt.golden, ".golden"), func(t *testing.T) {
+			aliasesFile := filepath.Join(t.TempDir(), generatedAliasesDir, generatedAliasesFilename)
+			sca := &ShellConfigAccessor{shell: tt.shell, generatedAliasesFilePath: aliasesFile}
+			if err := sca.SelectAliasStyle(tt.style); err != nil {
+				t.Fatalf("SelectAliasStyle(%q) error = %v", tt.style, err)
+			}
+
+			var written []alias.Alias
+			for _, a := range goldenAliases {
+				if !sca.SupportsKind(a.Kind) {
+					continue
+				}
+				if _, err := sca.AddAlias(a); err != nil {
+					t.Fatalf("AddAlias(%+v) error = %v", a, err)
+				}
+				a.Group = generatedAliasesFilename
+				written = append(written, a)
+			}
+			content, err := os.ReadFile(aliasesFile)
+			if err != nil {
+				t.Fatalf("Failed to read the alias file: %v", err)
+			}
+			assertGolden(t, tt.golden, string(content))
+
+			// What a dialect writes, it reads back.
+			definitions, err := sca.GetAliasDefinitions()
+			if err != nil {
+				t.Fatalf("GetAliasDefinitions() error = %v", err)
+			}
+			var read []alias.Alias
+			for _, def := range definitions {
+				read = append(read, def.Alias)
+			}
+			if !reflect.DeepEqual(read, written) {
+				t.Errorf("GetAliasDefinitions() = %+v, want %+v", read, written)
+			}
+
+			if err := sca.RemoveAlias("gs"); err != nil {
+				t.Fatalf("RemoveAlias() error = %v", err)
+			}
+			if aliases, _ := sca.GetExistingAliases(); len(aliases) != len(written)-1 || aliases["gs"] != "" {
+				t.Errorf("GetExistingAliases() after RemoveAlias() = %v, want all but gs", aliases)
+			}
+		})
+	}
+}
+
+func TestShellDialects_LoaderGolden(t *testing.T) {
+	for _, d := range shellDialects {
+		t.Run(d.name, func(t *testing.T) {
+			sca := &ShellConfigAccessor{shell: d.name}
+			assertGolden(t, d.name+"_loader.golden", sca.AliasLoader()+"\n")
+		})
+	}
+}
+
+func TestShellConfigAccessor_SelectAliasDialect(t *testing.T) {
+	sca := &ShellConfigAccessor{shell: "bash", style: aliasStyle}
+	if err := sca.SelectAliasDialect("tcsh"); err == nil || !strings.Contains(err.Error(), "available: bash, zsh, fish, nu, pwsh") {
+		t.Errorf("SelectAliasDialect(\"tcsh\") error = %v, want the available dialects", err)
+	}
+
+	if err := sca.SelectAliasDialect("powershell"); err != nil {
+		t.Fatalf("SelectAliasDialect(\"powershell\") error = %v", err)
+	}
+	if got := sca.currentDialect().name; got != "pwsh" {
+		t.Errorf("currentDialect() = %q, want pwsh", got)
+	}
+	if err := sca.SelectAliasStyle(abbrStyle); err == nil || !strings.Contains(err.Error(), "not supported by PowerShell") {
+		t.Errorf("SelectAliasStyle(%q) for PowerShell error = %v, want not supported", abbrStyle, err)
+	}
+
+	if err := sca.SelectAliasDialect("fish"); err != nil || sca.FormatDefinition(alias.Alias{Name: "gs", Command: "git status"}) != "abbr -a gs 'git status'\n" {
+		t.Errorf("SelectAliasDialect(\"fish\") = %v, want fish abbreviations by default", err)
+	}
+
+	if err := sca.SelectAliasDialect(""); err != nil || sca.currentDialect().name != "bash" {
+		t.Errorf("SelectAliasDialect(\"\") = %v, dialect %q, want the dialect of $SHELL", err, sca.currentDialect().name)
+	}
+	if got := (&ShellConfigAccessor{shell: "dash"}).currentDialect().name; got != "bash" {
+		t.Errorf("currentDialect() for dash = %q, want the POSIX dialect", got)
+	}
+}
+
+func TestShellConfigAccessor_NuOnlyUsesTheDefaultGroup(t *testing.T) {
+	aliasesFile := filepath.Join(t.TempDir(), generatedAliasesDir, generatedAliasesFilename)
+	sca := &ShellConfigAccessor{shell: "nu", generatedAliasesFilePath: aliasesFile}
+
+	if _, err := sca.AddAlias(alias.Alias{Name: "gs", Command: "git status", Group: "git"}); err == nil || !strings.Contains(err.Error(), "Nushell only loads the default group") {
+		t.Errorf("AddAlias() to group git error = %v, want the group refused", err)
+	}
+	if _, err := sca.AddAlias(alias.Alias{Name: "gs", Command: "git status"}); err != nil {
+		t.Fatalf("AddAlias() to the default group error = %v", err)
+	}
+	if err := sca.MoveAlias("gs", "git"); err == nil || !strings.Contains(err.Error(), "Nushell only loads the default group") {
+		t.Errorf("MoveAlias() to group git error = %v, want the group refused", err)
+	}
+
+	// A group file written for another shell is not loaded by Nushell, so its aliases are not reported.
+	groupFile := filepath.Join(filepath.Dir(aliasesFile), "work")
+	if err := os.WriteFile(groupFile, []byte("alias ll = ls -l\n"), 0644); err != nil {
+		t.Fatalf("Failed to write group file: %v", err)
+	}
+	result, err := sca.AddAlias(alias.Alias{Name: "ll", Command: "ls -la"})
+	if err != nil || !result.Added {
+		t.Fatalf("AddAlias(ll) = %+v, %v, want added", result, err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "ignored alias file") {
+		t.Errorf("AddAlias(ll) warnings = %q, want the group file ignored", result.Warnings)
+	}
+	if aliases, err := sca.GetExistingAliases(); err != nil || !reflect.DeepEqual(aliases, map[string]string{"gs": "git status", "ll": "ls -la"}) {
+		t.Errorf("GetExistingAliases() = %v, %v, want the default group only", aliases, err)
+	}
+}
+
+func TestParseFishDefinitionLine(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantName    string
+		wantCommand string
+		wantOK      bool
+	}{
+		{`abbr -a gs 'git status'`, "gs", "git status", true},
+		{`alias ll='ls -l'`, "ll", "ls -l", true},
+		{`alias q='it\'s'`, "q", "it's", true},
+		{`alias rmi "rm -i"`, "rmi", "rm -i", true},
+		{`alias --save gs='git status'`, "", "", false},
+		{`alias`, "", "", false},
+		{`# alias ll='ls -l'`, "", "", false},
+	}
+	for _, tt := range tests {
+		name, command, _, ok := parseFishDefinitionLine(tt.line)
+		if name != tt.wantName || command != tt.wantCommand || ok != tt.wantOK {
+			t.Errorf("parseFishDefinitionLine(%q) = %q, %q, %v, want %q, %q, %v", tt.line, name, command, ok, tt.wantName, tt.wantCommand, tt.wantOK)
+		}
+	}
+}
+
+func TestParseNuAliasLine(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantName    string
+		wantCommand string
+		wantOK      bool
+	}{
+		{`alias gs = git status`, "gs", "git status", true},
+		{`  export alias ll = ls -l | where size > 1kb`, "ll", "ls -l | where size > 1kb", true},
+		{`alias gs=git status`, "gs", "git status", true},
+		{`alias gs =`, "", "", false},
+		{`alias my alias = ls`, "", "", false},
+		{`# alias gs = git status`, "", "", false},
+		{`def gs [] { git status }`, "", "", false},
+	}
+	for _, tt := range tests {
+		name, command, _, ok := parseNuAliasLine(tt.line)
+		if name != tt.wantName || command != tt.wantCommand || ok != tt.wantOK {
+			t.Errorf("parseNuAliasLine(%q) = %q, %q, %v, want %q, %q, %v", tt.line, name, command, ok, tt.wantName, tt.wantCommand, tt.wantOK)
+		}
+	}
+}
+
+func TestParsePowerShellAliasLine(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantName    string
+		wantCommand string
+		wantOK      bool
+	}{
+		{`Set-Alias -Name g -Value git`, "g", "git", true},
+		{`set-alias g git`, "g", "git", true},
+		{`Set-Alias -Value 'kubectl' -Name k -Scope Global -Force`, "k", "kubectl", true},
+		{`Set-Alias -Name np notepad.exe`, "np", "notepad.exe", true},
+		{`function gs { git status @args }`, "gs", "git status", true},
+		{`Function ll {ls -Force @args}`, "ll", "ls -Force", true},
+		{`function prompt { "PS> " }`, "", "", false},
+		{`function x { @args }`, "", "", false},
+		{`Set-Alias -Name g`, "", "", false},
+		{`Set-Alias -WhatIf g git`, "", "", false},
+		{`# Set-Alias g git`, "", "", false},
+	}
+	for _, tt := range tests {
+		name, command, _, ok := parsePowerShellAliasLine(tt.line)
+		if name != tt.wantName || command != tt.wantCommand || ok != tt.wantOK {
+			t.Errorf("parsePowerShellAliasLine(%q) = %q, %q, %v, want %q, %q, %v", tt.line, name, command, ok, tt.wantName, tt.wantCommand, tt.wantOK)
+		}
+	}
+}
+
+func TestShellConfigAccessor_QuoteString(t *testing.T) {
+	tests := []struct {
+		shell   string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{shell: "bash", input: `it's $HOME`, want: `'it'\''s $HOME'`},
+		{shell: "sh", input: "a b", want: "'a b'"},
+		{shell: "fish", input: `it's C:\`, want: `'it\'s C:\\'`},
+		{shell: "nu", input: "a b", want: "'a b'"},
+		{shell: "nu", input: "it's", want: "r#'it's'#"},
+		{shell: "nu", input: "'#", want: "r##''#'##"},
+		{shell: "pwsh", input: "it's", want: "'it''s'"},
+		{shell: "", input: "it's", want: `'it'\''s'`},
+		{shell: "tcsh", input: "a", wantErr: true},
+	}
+	sca := &ShellConfigAccessor{shell: "zsh"}
+	for _, tt := range tests {
+		got, err := sca.QuoteString(tt.shell, tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("QuoteString(%q, %q) = %q, %v, want %q, error %v", tt.shell, tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestShellConfigAccessor_StartupFile(t *testing.T) {
+	tests := []struct {
+		shell      string
+		wantFile   string
+		wantReload string
+	}{
+		{shell: "bash", wantFile: "~/.bashrc", wantReload: "source ~/.bashrc"},
+		{shell: "zsh", wantFile: "~/.zshrc", wantReload: "source ~/.zshrc"},
+		{shell: "fish", wantFile: "~/.config/fish/config.fish", wantReload: "source ~/.config/fish/config.fish"},
+		{shell: "nu", wantFile: "~/.config/nushell/config.nu", wantReload: ""},
+		{shell: "pwsh", wantFile: "your PowerShell profile ($PROFILE)", wantReload: ". $PROFILE"},
+	}
+	for _, tt := range tests {
+		sca := &ShellConfigAccessor{shell: tt.shell}
+		if file, reload := sca.StartupFile(); file != tt.wantFile || reload != tt.wantReload {
+			t.Errorf("StartupFile() for %s = %q, %q, want %q, %q", tt.shell, file, reload, tt.wantFile, tt.wantReload)
+		}
+	}
+}
+
+func TestShellConfigAccessor_UnloadCommand(t *testing.T) {
+	tests := []struct {
+		shell string
+		style string
+		want  string
+	}{
+		{shell: "bash", want: "unalias gs"},
+		{shell: "zsh", want: "unalias gs"},
+		{shell: "fish", want: "abbr --erase gs"},
+		{shell: "fish", style: aliasStyle, want: "functions --erase gs"},
+		{shell: "nu", want: "hide gs"},
+		{shell: "pwsh", want: "Remove-Item alias:gs"},
+	}
+	for _, tt := range tests {
+		sca := &ShellConfigAccessor{shell: tt.shell, style: tt.style}
+		if got := sca.UnloadCommand("gs"); got != tt.want {
+			t.Errorf("UnloadCommand(\"gs\") for %s in style %q = %q, want %q", tt.shell, tt.style, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 	"github.com/AntonioJCosta/nicksh/internal/core/ports"
@@ -13,19 +15,40 @@ import (
 const generatedAliasesDir = ".nicksh"
 const generatedAliasesFilename = "generated_aliases"
 
+// Alias styles: how ShellConfigAccessor writes alias definitions.
+const (
+	aliasStyle = "alias" // alias gs='git status'
+	abbrStyle  = "abbr"  // abbr -a gs 'git status', a fish abbreviation
+)
+
 // userFriendlyGeneratedPath constructs a path string for display to the user.
 func userFriendlyGeneratedPath() string {
 	return filepath.Join("~/", generatedAliasesDir, generatedAliasesFilename)
 }
 
 // ShellConfigAccessor provides access to shell configuration files via the file system.
+// Every file in the aliases directory is an alias group; the group name is the file name.
+// Definitions are read and written in the dialect of the user's shell (see shellDialects), or in the
+// selected one, and written in the selected style, e.g. as fish abbreviations.
+// It also implements the ports.AliasDialectSelector and ports.AliasStyleSelector interfaces.
 type ShellConfigAccessor struct {
-	shell                    string
+	shell                    string // Base name of $SHELL.
+	dialectName              string // Name of the selected dialect; "" uses the dialect of shell.
+	style                    string // One of the styles of the dialect; "" is its default style.
 	generatedAliasesFilePath string
+	secrets                  ports.SecretDetector   // Can be nil, in which case commands are not checked for secrets.
+	validator                ports.AliasValidator   // Can be nil, in which case writes are not validated.
+	env                      ports.ShellEnvironment // Can be nil, in which case no startup file is read.
+	logger                   ports.Logger           // Can be nil, in which case nothing is logged.
 }
 
 // NewShellConfigAccessor creates a new FileShellConfigAccessor.
-func NewShellConfigAccessor() (ports.ShellConfigAccessor, error) {
+// secrets guards AddAlias against writing commands that contain secrets; it can be nil.
+// validator guards writes against making aliases loop, and finds the other issues they bring in; it can be nil.
+// env gives access to the shell's startup files, for the aliases defined there; it can be nil.
+// logger receives the files read and the warnings about those that cannot be; it can be nil.
+// Definitions are written in the dialect and default style of the user's shell until others are selected.
+func NewShellConfigAccessor(secrets ports.SecretDetector, validator ports.AliasValidator, env ports.ShellEnvironment, logger ports.Logger) (*ShellConfigAccessor, error) {
 	usr, err := user.Current()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
@@ -43,82 +66,463 @@ func NewShellConfigAccessor() (ports.ShellConfigAccessor, error) {
 
 	return &ShellConfigAccessor{
 		shell:                    shellName,
+		style:                    dialectForShell(shellName).styles[0],
 		generatedAliasesFilePath: generatedAliasesFileFullPath,
+		secrets:                  secrets,
+		validator:                validator,
+		env:                      env,
+		logger:                   logger,
 	}, nil
 }
 
-// ...existing code...
-// GetExistingAliases implements the ports.ShellConfigAccessor interface.
-// It now reads all files from the $HOME/.nicksh/ directory.
-func (sca *ShellConfigAccessor) GetExistingAliases() (map[string]string, error) {
-	aliases := make(map[string]string)
-	aliasesDir := filepath.Dir(sca.generatedAliasesFilePath) // Get the $HOME/.nicksh directory
+// GetAliasDefinitions implements the ports.ShellConfigAccessor interface.
+// It reads all files from the $HOME/.nicksh/ directory, in file name order.
+// Files that cannot be read are skipped with a warning to the logger.
+func (sca *ShellConfigAccessor) GetAliasDefinitions() ([]alias.Definition, error) {
+	definitions, warnings, err := sca.readDefinitions()
+	for _, warning := range warnings {
+		sca.log().Warn(warning)
+	}
+	return definitions, err
+}
+
+// GetStartupAliasDefinitions implements the ports.ShellConfigAccessor interface.
+// It reads the startup files of the user's shell, in the order the shell reads them.
+func (sca *ShellConfigAccessor) GetStartupAliasDefinitions() ([]alias.Definition, error) {
+	definitions := []alias.Definition{}
+	if sca.env == nil {
+		return definitions, nil
+	}
+	shell := sca.targetShell()
+	rcFiles, err := sca.env.RCFiles(shell)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the startup files of %s: %w", shell, err)
+	}
+	for _, rcFile := range rcFiles {
+		fileDefinitions := parseDefinitions(rcFile.Content, rcFile.Path, "", sca.currentDialect().parse)
+		sca.log().Debug("read startup file", "file", toUserFriendlyPath(rcFile.Path), "aliases", len(fileDefinitions))
+		definitions = append(definitions, fileDefinitions...)
+	}
+	return definitions, nil
+}
+
+// log returns the accessor's logger, or ports.NopLogger if it has none.
+func (sca *ShellConfigAccessor) log() ports.Logger {
+	if sca.logger == nil {
+		return ports.NopLogger
+	}
+	return sca.logger
+}
+
+// readDefinitions reads all files from the $HOME/.nicksh/ directory, in file name order.
+// Files that cannot be read, or that the dialect does not load, are skipped, and described
+// in the returned warnings.
+func (sca *ShellConfigAccessor) readDefinitions() ([]alias.Definition, []string, error) {
+	definitions := []alias.Definition{}
+	aliasesDir := sca.aliasesDir()
 
 	// Ensure the directory exists, but don't error if it doesn't; just return no aliases.
 	if _, err := os.Stat(aliasesDir); os.IsNotExist(err) {
-		return aliases, nil // No directory, so no aliases from it.
+		return definitions, nil, nil // No directory, so no aliases from it.
 	}
 
 	dirEntries, err := os.ReadDir(aliasesDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read alias directory %s: %w", userFriendlyGeneratedPath(), err)
+		return nil, nil, fmt.Errorf("failed to read alias directory %s: %w", toUserFriendlyPath(aliasesDir), err)
 	}
 
+	var warnings []string
 	for _, entry := range dirEntries {
-		if !entry.IsDir() {
-			filePath := filepath.Join(aliasesDir, entry.Name())
-			fileAliases, err := sca.getAliasesFromFile(filePath)
-			if err != nil {
-				// Log a warning but continue with other files
-				fmt.Fprintf(os.Stderr, "Warning: could not read aliases from file %s: %v\n", toUserFriendlyPath(filePath), err)
-				continue
-			}
-			for name, cmdVal := range fileAliases {
-				if _, exists := aliases[name]; exists {
-					// If an alias with the same name is found in multiple files,
-					// log a warning. The last one read will take precedence.
-					// Consider if a more sophisticated conflict resolution is needed.
-					fmt.Fprintf(os.Stderr, "Warning: Alias '%s' found in multiple files. Using the definition from %s.\n", name, toUserFriendlyPath(filePath))
-				}
-				aliases[name] = cmdVal
-			}
+		if entry.IsDir() {
+			continue
+		}
+		filePath := filepath.Join(aliasesDir, entry.Name())
+		if sca.currentDialect().singleFile && filePath != sca.generatedAliasesFilePath {
+			// Its aliases are not defined in the shell, so they must not be reported as such.
+			warnings = append(warnings, fmt.Sprintf("ignored alias file %s: %s only loads %s", toUserFriendlyPath(filePath), sca.currentDialect().displayName, userFriendlyGeneratedPath()))
+			continue
+		}
+		fileDefinitions, err := sca.getDefinitionsFromFile(filePath)
+		if err != nil {
+			// Continue with the other files.
+			warnings = append(warnings, fmt.Sprintf("could not read aliases from file %s: %v", toUserFriendlyPath(filePath), err))
+			continue
 		}
+		sca.log().Debug("read alias file", "file", toUserFriendlyPath(filePath), "aliases", len(fileDefinitions))
+		definitions = append(definitions, fileDefinitions...)
 	}
 
+	return definitions, warnings, nil
+}
+
+// GetExistingAliases implements the ports.ShellConfigAccessor interface.
+// It reads all files from the $HOME/.nicksh/ directory. An alias name defined in
+// more than one file is an error, reported with the file and line of each definition.
+func (sca *ShellConfigAccessor) GetExistingAliases() (map[string]string, error) {
+	definitions, err := sca.GetAliasDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	if dupErr := findDuplicateDefinitions(definitions); dupErr != nil {
+		return nil, dupErr
+	}
+
+	aliases := make(map[string]string, len(definitions))
+	for _, def := range definitions {
+		aliases[def.Name] = def.Command // Within a single file, the last definition wins, as in the shell.
+	}
 	return aliases, nil
 }
 
 // AddAlias implements the ports.ShellConfigAccessor interface.
-// ...existing code...
+// The alias is appended to the file of its group, unless the name is already defined in any group.
+// Alias files that cannot be read are reported in the result's warnings rather than logged.
+func (sca *ShellConfigAccessor) AddAlias(newAlias alias.Alias) (ports.AddAliasResult, error) {
+	if err := sca.checkKind(newAlias); err != nil {
+		return ports.AddAliasResult{}, err
+	}
+	// Last line of defense: whatever suggested the alias, a secret is never written to an alias file.
+	if sca.secrets != nil {
+		if kinds := sca.secrets.DetectSecrets(newAlias.Command); len(kinds) > 0 {
+			return ports.AddAliasResult{}, &alias.SecretError{Name: newAlias.Name, Kinds: kinds}
+		}
+	}
 
-// AddAlias implements the ports.ShellConfigAccessor interface.
-func (sca *ShellConfigAccessor) AddAlias(newAlias alias.Alias) (bool, error) {
-	dirPath := filepath.Dir(sca.generatedAliasesFilePath)
+	targetPath, err := sca.groupFilePath(newAlias.Group)
+	if err != nil {
+		return ports.AddAliasResult{}, err
+	}
+
+	dirPath := sca.aliasesDir()
 	if err := os.MkdirAll(dirPath, 0755); err != nil {
-		return false, fmt.Errorf("failed to create directory %s: %w", dirPath, err)
+		return ports.AddAliasResult{}, &alias.ConfigWriteError{Path: toUserFriendlyPath(dirPath), Err: err}
+	}
+
+	definitions, warnings, err := sca.readDefinitions()
+	if err != nil {
+		return ports.AddAliasResult{}, fmt.Errorf("failed to read existing aliases: %w", err)
+	}
+
+	for _, def := range definitions {
+		if def.Name == newAlias.Name {
+			return ports.AddAliasResult{File: def.File, Line: def.Line, ExistingCommand: def.Command, Warnings: warnings}, nil
+		}
+	}
+
+	issues, err := sca.checkWrite(newAlias.Name, definitions, append(slices.Clone(definitions), alias.Definition{Alias: newAlias, File: targetPath}))
+	if err != nil {
+		return ports.AddAliasResult{}, err
+	}
+	warnings = append(warnings, issues...)
+
+	line, err := appendAliasLine(targetPath, sca.FormatDefinition(newAlias))
+	if err != nil {
+		return ports.AddAliasResult{}, err
+	}
+	sca.log().Info("appended alias", "name", newAlias.Name, "file", toUserFriendlyPath(targetPath), "line", line)
+	return ports.AddAliasResult{Added: true, File: targetPath, Line: line, Warnings: warnings}, nil
+}
+
+// MoveAlias implements the ports.ShellConfigAccessor interface.
+// The definition is appended to the target group file and then removed from its current file.
+// It is not validated: a move changes no alias expansion, and refuses names defined in several files.
+func (sca *ShellConfigAccessor) MoveAlias(name, targetGroup string) error {
+	targetPath, err := sca.groupFilePath(targetGroup)
+	if err != nil {
+		return err
+	}
+
+	definitions, err := sca.GetAliasDefinitions()
+	if err != nil {
+		return fmt.Errorf("failed to read existing aliases: %w", err)
+	}
+
+	var found []alias.Definition
+	for _, def := range definitions {
+		if def.Name == name {
+			found = append(found, def)
+		}
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("alias '%s' not found in %s", name, toUserFriendlyPath(sca.aliasesDir()))
+	}
+	if dupErr := findDuplicateDefinitions(found); dupErr != nil {
+		return dupErr
+	}
+
+	current := found[len(found)-1] // The last definition in the file is the effective one.
+	if current.File == targetPath {
+		return nil // Already in the target group.
+	}
+
+	if err := os.MkdirAll(sca.aliasesDir(), 0755); err != nil {
+		return &alias.ConfigWriteError{Path: toUserFriendlyPath(sca.aliasesDir()), Err: err}
+	}
+	if _, err := appendAliasLine(targetPath, sca.FormatDefinition(current.Alias)); err != nil {
+		return err
+	}
+	if err := removeAliasFromFile(current.File, name, sca.currentDialect().parse); err != nil {
+		return fmt.Errorf("alias '%s' was copied to %s but could not be removed from %s: %w",
+			name, toUserFriendlyPath(targetPath), toUserFriendlyPath(current.File), err)
+	}
+	sca.log().Info("moved alias", "name", name, "from", toUserFriendlyPath(current.File), "to", toUserFriendlyPath(targetPath))
+	return nil
+}
+
+// ReplaceAlias implements the ports.ShellConfigAccessor interface.
+// The new definition is appended to the file of its group before the old ones are removed,
+// so a failure never loses the alias.
+func (sca *ShellConfigAccessor) ReplaceAlias(newAlias alias.Alias) error {
+	if err := sca.checkKind(newAlias); err != nil {
+		return err
+	}
+	if sca.secrets != nil {
+		if kinds := sca.secrets.DetectSecrets(newAlias.Command); len(kinds) > 0 {
+			return &alias.SecretError{Name: newAlias.Name, Kinds: kinds}
+		}
 	}
 
-	existingGeneratedAliases, err := sca.getAliasesFromFile(sca.generatedAliasesFilePath)
+	targetPath, err := sca.groupFilePath(newAlias.Group)
 	if err != nil {
-		return false, fmt.Errorf("failed to read existing generated aliases from %s: %w", userFriendlyGeneratedPath(), err)
+		return err
 	}
 
-	if _, exists := existingGeneratedAliases[newAlias.Name]; exists {
-		fmt.Printf("Alias '%s' already exists in %s. Skipping.\n", newAlias.Name, userFriendlyGeneratedPath())
-		return false, nil
+	definitions, err := sca.GetAliasDefinitions()
+	if err != nil {
+		return fmt.Errorf("failed to read existing aliases: %w", err)
+	}
+	filesToClean := make(map[string]bool)
+	for _, def := range definitions {
+		if def.Name == newAlias.Name {
+			filesToClean[def.File] = true
+		}
+	}
+	after := append(withoutAlias(definitions, newAlias.Name), alias.Definition{Alias: newAlias, File: targetPath})
+	issues, err := sca.checkWrite(newAlias.Name, definitions, after)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		sca.log().Warn(issue)
 	}
 
-	aliasLine := fmt.Sprintf("alias %s='%s'\n", newAlias.Name, newAlias.Command)
+	// The old definitions of the target file are removed first, so the new one is kept.
+	if filesToClean[targetPath] {
+		if err := removeAliasFromFile(targetPath, newAlias.Name, sca.currentDialect().parse); err != nil {
+			return err
+		}
+		delete(filesToClean, targetPath)
+	}
+	if err := os.MkdirAll(sca.aliasesDir(), 0755); err != nil {
+		return &alias.ConfigWriteError{Path: toUserFriendlyPath(sca.aliasesDir()), Err: err}
+	}
+	if _, err := appendAliasLine(targetPath, sca.FormatDefinition(newAlias)); err != nil {
+		return err
+	}
+	for file := range filesToClean {
+		if err := removeAliasFromFile(file, newAlias.Name, sca.currentDialect().parse); err != nil {
+			return fmt.Errorf("alias '%s' was written to %s but its old definition could not be removed from %s: %w",
+				newAlias.Name, toUserFriendlyPath(targetPath), toUserFriendlyPath(file), err)
+		}
+	}
+	sca.log().Info("replaced alias", "name", newAlias.Name, "file", toUserFriendlyPath(targetPath))
+	return nil
+}
 
-	file, err := os.OpenFile(sca.generatedAliasesFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// RemoveAlias implements the ports.ShellConfigAccessor interface.
+func (sca *ShellConfigAccessor) RemoveAlias(name string) error {
+	definitions, err := sca.GetAliasDefinitions()
 	if err != nil {
-		return false, fmt.Errorf("failed to open generated aliases file %s for appending: %w", userFriendlyGeneratedPath(), err)
+		return fmt.Errorf("failed to read existing aliases: %w", err)
+	}
+	// Removing an alias cannot make another one loop, but can leave it running a command that does not exist.
+	issues, err := sca.checkWrite(name, definitions, withoutAlias(definitions, name))
+	if err != nil {
+		return err
+	}
+
+	removedFrom := make(map[string]bool)
+	for _, def := range definitions {
+		if def.Name != name || removedFrom[def.File] {
+			continue
+		}
+		if err := removeAliasFromFile(def.File, name, sca.currentDialect().parse); err != nil {
+			return err
+		}
+		removedFrom[def.File] = true
+		sca.log().Info("removed alias", "name", name, "file", toUserFriendlyPath(def.File))
+	}
+	if len(removedFrom) == 0 {
+		return fmt.Errorf("alias '%s' not found in %s", name, toUserFriendlyPath(sca.aliasesDir()))
+	}
+	for _, issue := range issues {
+		sca.log().Warn(issue)
+	}
+	return nil
+}
+
+// checkWrite validates the definitions a write of the alias name would leave, against those it starts from.
+// It returns a *alias.CycleError if the write would make an alias loop, and describes the other issues the
+// write would bring in; issues already there are not its concern.
+func (sca *ShellConfigAccessor) checkWrite(name string, before, after []alias.Definition) ([]string, error) {
+	if sca.validator == nil {
+		return nil, nil
+	}
+	existing := make(map[string]bool)
+	for _, issue := range sca.validator.Validate(before) {
+		existing[issue.String()] = true
+	}
+	var issues []string
+	for _, issue := range sca.validator.Validate(after) {
+		if existing[issue.String()] {
+			continue
+		}
+		if issue.IsError() {
+			return nil, &alias.CycleError{Name: name, Chain: issue.Chain}
+		}
+		issues = append(issues, issue.String())
+	}
+	return issues, nil
+}
+
+// SupportsKind implements the ports.ShellConfigAccessor interface.
+// Global and suffix aliases are only supported by zsh.
+func (sca *ShellConfigAccessor) SupportsKind(kind string) bool {
+	return slices.Contains(sca.currentDialect().kinds, kind)
+}
+
+// AliasLoader implements the ports.ShellConfigAccessor interface.
+func (sca *ShellConfigAccessor) AliasLoader() string {
+	return sca.currentDialect().loader
+}
+
+// StartupFile implements the ports.ShellConfigAccessor interface.
+func (sca *ShellConfigAccessor) StartupFile() (string, string) {
+	d := sca.currentDialect()
+	return d.startupFile, d.reload
+}
+
+// QuoteString implements the ports.ShellConfigAccessor interface.
+func (sca *ShellConfigAccessor) QuoteString(shell, s string) (string, error) {
+	d := sca.currentDialect()
+	if shell != "" {
+		var ok bool
+		if d, ok = findShellDialect(shell); !ok {
+			return "", fmt.Errorf("unknown shell dialect '%s' (available: %s)", shell, strings.Join(sca.AvailableAliasDialects(), ", "))
+		}
+	}
+	return d.quote(s), nil
+}
+
+// checkKind returns an error if the user's shell does not support the kind of a.
+func (sca *ShellConfigAccessor) checkKind(a alias.Alias) error {
+	if !sca.SupportsKind(a.Kind) {
+		return fmt.Errorf("cannot write %s alias '%s': %s aliases are only supported by zsh, not %s", a.Kind, a.Name, a.Kind, sca.targetShell())
+	}
+	return nil
+}
+
+// SelectAliasDialect implements the ports.AliasDialectSelector interface.
+// The shells using a dialect select it too, e.g. "powershell" selects "pwsh".
+// The style is reset to the default style of the dialect.
+func (sca *ShellConfigAccessor) SelectAliasDialect(name string) error {
+	if name == "" {
+		sca.dialectName = ""
+	} else {
+		d, ok := findShellDialect(name)
+		if !ok {
+			return fmt.Errorf("unknown shell dialect '%s' (available: %s)", name, strings.Join(sca.AvailableAliasDialects(), ", "))
+		}
+		sca.dialectName = d.name
 	}
-	defer file.Close()
+	sca.style = ""
+	sca.log().Debug("selected shell dialect", "dialect", sca.currentDialect().name)
+	return nil
+}
+
+// AvailableAliasDialects implements the ports.AliasDialectSelector interface.
+func (sca *ShellConfigAccessor) AvailableAliasDialects() []string {
+	names := make([]string, 0, len(shellDialects))
+	for _, d := range shellDialects {
+		names = append(names, d.name)
+	}
+	return names
+}
+
+// SelectAliasStyle implements the ports.AliasStyleSelector interface.
+// Abbreviations are a fish feature, so the abbr style cannot be selected for other shells.
+func (sca *ShellConfigAccessor) SelectAliasStyle(name string) error {
+	d := sca.currentDialect()
+	switch {
+	case name == "":
+		sca.style = d.styles[0]
+	case slices.Contains(d.styles, name):
+		sca.style = name
+	case slices.Contains(sca.AvailableAliasStyles(), name):
+		return fmt.Errorf("the '%s' style is not supported by %s (available: %s)", name, d.displayName, strings.Join(d.styles, ", "))
+	default:
+		return fmt.Errorf("unknown alias style '%s' (available: %s)", name, strings.Join(sca.AvailableAliasStyles(), ", "))
+	}
+	sca.log().Debug("selected alias style", "style", sca.style)
+	return nil
+}
+
+// AvailableAliasStyles implements the ports.AliasStyleSelector interface.
+func (sca *ShellConfigAccessor) AvailableAliasStyles() []string {
+	return []string{aliasStyle, abbrStyle}
+}
+
+// currentDialect returns the selected dialect, or the dialect of the user's shell if none was selected.
+func (sca *ShellConfigAccessor) currentDialect() shellDialect {
+	return dialectForShell(sca.targetShell())
+}
+
+// targetShell returns the name of the shell aliases are written for: the selected dialect's, or the user's.
+func (sca *ShellConfigAccessor) targetShell() string {
+	if sca.dialectName != "" {
+		return sca.dialectName
+	}
+	return sca.shell
+}
+
+// FormatDefinition implements the ports.ShellConfigAccessor interface.
+// It renders a in the selected dialect and style.
+func (sca *ShellConfigAccessor) FormatDefinition(a alias.Alias) string {
+	return sca.currentDialect().format(a, sca.currentStyle())
+}
+
+// aliasesDir returns the $HOME/.nicksh directory holding all alias group files.
+func (sca *ShellConfigAccessor) aliasesDir() string {
+	return filepath.Dir(sca.generatedAliasesFilePath)
+}
+
+// groupFilePath returns the file path backing the given group.
+// An empty group name refers to the default generated aliases file, the only
+// group of dialects loading a single file.
+func (sca *ShellConfigAccessor) groupFilePath(group string) (string, error) {
+	if group == "" {
+		return sca.generatedAliasesFilePath, nil
+	}
+	if !validGroupNameRegex.MatchString(group) {
+		return "", fmt.Errorf("invalid group name '%s': use letters, digits, '.', '_' or '-'", group)
+	}
+	path := filepath.Join(sca.aliasesDir(), group)
+	if d := sca.currentDialect(); d.singleFile && path != sca.generatedAliasesFilePath {
+		return "", fmt.Errorf("cannot use group '%s': %s only loads the default group, %s", group, d.displayName, userFriendlyGeneratedPath())
+	}
+	return path, nil
+}
+
+// UnloadCommand implements the ports.ShellConfigAccessor interface.
+func (sca *ShellConfigAccessor) UnloadCommand(name string) string {
+	return sca.currentDialect().unload(name, sca.currentStyle())
+}
 
-	if _, err := file.WriteString(aliasLine); err != nil {
-		return false, fmt.Errorf("failed to write alias to generated aliases file %s: %w", userFriendlyGeneratedPath(), err)
+// currentStyle returns the selected style, or the default style of the selected dialect.
+func (sca *ShellConfigAccessor) currentStyle() string {
+	if sca.style == "" {
+		return sca.currentDialect().styles[0]
 	}
-	fmt.Printf("Alias '%s' added to %s.\n", newAlias.Name, userFriendlyGeneratedPath())
-	return true, nil
+	return sca.style
 }
@@ -1,61 +1,297 @@
 package shellconfig
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 	"path/filepath"
+	"regexp"
 	"strings"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 )
 
-func (sca *ShellConfigAccessor) getAliasesFromFile(filePath string) (map[string]string, error) {
-	aliases := make(map[string]string)
-	file, err := os.Open(filePath)
+// validGroupNameRegex restricts group names to plain file names inside the aliases directory.
+var validGroupNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// getDefinitionsFromFile reads the alias definitions of a single file, in file order.
+// The group of each definition is the base name of the file.
+func (sca *ShellConfigAccessor) getDefinitionsFromFile(filePath string) ([]alias.Definition, error) {
+	content, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return aliases, nil // File not existing is not an error for reading, just means no aliases there yet
+			return []alias.Definition{}, nil // File not existing is not an error for reading, just means no aliases there yet
+		}
+		return nil, fmt.Errorf("failed to read alias file %s: %w", filePath, err)
+	}
+	return parseDefinitions(string(content), filePath, filepath.Base(filePath), sca.currentDialect().parse), nil
+}
+
+// parseDefinitions returns the alias definitions found in content, read from filePath, in order,
+// as parse reads them. Every definition is given group.
+func parseDefinitions(content, filePath, group string, parse lineParser) []alias.Definition {
+	definitions := []alias.Definition{}
+	for i, line := range strings.Split(content, "\n") {
+		name, command, kind, isDefinition := parse(line)
+		if isDefinition {
+			definitions = append(definitions, alias.Definition{
+				Alias: alias.Alias{Name: name, Command: command, Group: group, Kind: kind},
+				File:  filePath,
+				Line:  i + 1,
+			})
+		}
+	}
+	return definitions
+}
+
+// findDuplicateDefinitions returns an *alias.DuplicateNameError for every alias name
+// defined in more than one file, joined into a single error. It returns nil if there are none.
+func findDuplicateDefinitions(definitions []alias.Definition) error {
+	byName := make(map[string][]alias.Definition)
+	filesByName := make(map[string]map[string]bool)
+	var names []string // Preserves the order in which names were first seen.
+	for _, def := range definitions {
+		if _, seen := byName[def.Name]; !seen {
+			names = append(names, def.Name)
+			filesByName[def.Name] = make(map[string]bool)
+		}
+		byName[def.Name] = append(byName[def.Name], def)
+		filesByName[def.Name][def.File] = true
+	}
+
+	var dupErrs []error
+	for _, name := range names {
+		if len(filesByName[name]) > 1 {
+			dupErrs = append(dupErrs, &alias.DuplicateNameError{Name: name, Definitions: byName[name]})
+		}
+	}
+	return errors.Join(dupErrs...)
+}
+
+// withoutAlias returns a copy of definitions without those of the alias name.
+func withoutAlias(definitions []alias.Definition, name string) []alias.Definition {
+	kept := make([]alias.Definition, 0, len(definitions))
+	for _, def := range definitions {
+		if def.Name != name {
+			kept = append(kept, def)
 		}
-		return nil, fmt.Errorf("failed to open alias file %s: %w", filePath, err)
+	}
+	return kept
+}
+
+// formatAliasLine renders an alias as a single POSIX shell alias definition line.
+// Global and suffix aliases are written with the zsh options -g and -s.
+func formatAliasLine(a alias.Alias) string {
+	switch a.Kind {
+	case alias.KindGlobal:
+		return fmt.Sprintf("alias -g %s=%s\n", a.Name, posixSingleQuote(a.Command))
+	case alias.KindSuffix:
+		return fmt.Sprintf("alias -s %s=%s\n", a.Name, posixSingleQuote(a.Command))
+	}
+	return fmt.Sprintf("alias %s=%s\n", a.Name, posixSingleQuote(a.Command))
+}
+
+// posixSingleQuote quotes s for a POSIX shell. A single quote closes the quotes, is escaped and reopens them.
+func posixSingleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// formatAbbrLine renders an alias as a single fish abbreviation line, e.g. "abbr -a gs 'git status'".
+func formatAbbrLine(a alias.Alias) string {
+	return fmt.Sprintf("abbr -a %s %s\n", a.Name, fishSingleQuote(a.Command))
+}
+
+// fishSingleQuote quotes s for fish, escaping backslashes and single quotes.
+func fishSingleQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// appendAliasLine appends definition, a line formatted by a shell dialect, to the
+// file at filePath, creating it if needed, and returns the line (1-based) it was written on.
+func appendAliasLine(filePath, definition string) (int, error) {
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return 0, &alias.ConfigWriteError{Path: toUserFriendlyPath(filePath), Err: err}
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		name, command, isAlias := parseAliasLineFromString(scanner.Text())
-		if isAlias {
-			aliases[name] = command
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read alias file %s: %w", toUserFriendlyPath(filePath), err)
+	}
+	line := definition
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		line = "\n" + line // Never glue the definition to an unterminated last line.
+	}
+	if _, err := file.WriteString(line); err != nil {
+		return 0, &alias.ConfigWriteError{Path: toUserFriendlyPath(filePath), Err: err}
+	}
+	return strings.Count(string(content)+line, "\n"), nil
+}
+
+// removeAliasFromFile rewrites the file at filePath without any line defining the alias name,
+// as parse reads them. All other lines, including comments, are kept as they are.
+func removeAliasFromFile(filePath, name string, parse lineParser) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat alias file %s: %w", toUserFriendlyPath(filePath), err)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read alias file %s: %w", toUserFriendlyPath(filePath), err)
+	}
+
+	var kept []string
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		if line == "" {
+			continue
+		}
+		if lineName, _, _, isDefinition := parse(line); isDefinition && lineName == name {
+			continue
 		}
+		kept = append(kept, line)
 	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error scanning alias file %s: %w", filePath, err)
+
+	if err := os.WriteFile(filePath, []byte(strings.Join(kept, "")), info.Mode().Perm()); err != nil {
+		return &alias.ConfigWriteError{Path: toUserFriendlyPath(filePath), Err: err}
 	}
-	return aliases, nil
+	return nil
 }
 
-// parseAliasLineFromString remains an internal helper
+// parseAbbrLineFromString parses a line adding a fish abbreviation, e.g. "abbr -a gs 'git status'".
+// The scope options -g and -U are accepted; lines with any other option (e.g. "abbr --erase gs",
+// or "abbr -a --position anywhere ...") define no abbreviation nicksh can manage.
+func parseAbbrLineFromString(line string) (name string, command string, isAbbr bool) {
+	words, ok := fishWords(strings.TrimSpace(line))
+	if !ok || len(words) == 0 || words[0] != "abbr" {
+		return "", "", false
+	}
 
-// ...existing code...
-func parseAliasLineFromString(line string) (name string, command string, isAlias bool) {
+	added := false
+	args := words[1:]
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		option := args[0]
+		args = args[1:]
+		if option == "--" {
+			break
+		}
+		switch option {
+		case "-a", "--add":
+			added = true
+		case "-g", "--global", "-U", "--universal":
+		default:
+			return "", "", false
+		}
+	}
+	if !added || len(args) < 2 {
+		return "", "", false // Listing, erasing or incomplete.
+	}
+	// Like fish, the words after the name are joined into the expansion.
+	return args[0], strings.Join(args[1:], " "), true
+}
+
+// fishWords splits line into words as fish does, removing quotes and escapes, up to a comment.
+// It returns false if a quote is left open.
+func fishWords(line string) ([]string, bool) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	runes := []rune(line)
+scan:
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			// Within single quotes, only \' and \\ are escapes; double quotes also escape \" and \$.
+			if r == '\\' && i+1 < len(runes) && (runes[i+1] == quote || runes[i+1] == '\\' || (quote == '"' && runes[i+1] == '$')) {
+				i++
+				r = runes[i]
+			}
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case r == '#' && !inWord:
+			break scan
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, false
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, true
+}
+
+// parseAliasLineFromString parses a line defining an alias, e.g. "alias gs='git status'".
+// The zsh options -g and -s define a global and a suffix alias, e.g. "alias -g G='| grep'";
+// lines with any other option (e.g. "alias -L", which lists the aliases) define none.
+func parseAliasLineFromString(line string) (name string, command string, kind string, isAlias bool) {
 	trimmedLine := strings.TrimSpace(line)
 
 	if strings.HasPrefix(trimmedLine, "#") {
-		return "", "", false // It's a comment
+		return "", "", "", false // It's a comment
 	}
 
 	if !strings.HasPrefix(trimmedLine, "alias ") {
-		return "", "", false // Not an alias definition
+		return "", "", "", false // Not an alias definition
 	}
 
 	// Remove "alias " prefix
 	content := strings.TrimPrefix(trimmedLine, "alias ")
 
+	// Options come first, up to "--" or the first word that is not one.
+	kind = alias.KindRegular
+	for {
+		content = strings.TrimLeft(content, " \t")
+		option, rest, _ := strings.Cut(content, " ")
+		if option == "--" {
+			content = rest
+			break
+		}
+		if !strings.HasPrefix(option, "-") || strings.Contains(option, "=") {
+			break
+		}
+		switch option {
+		case "-g":
+			kind = alias.KindGlobal
+		case "-s":
+			kind = alias.KindSuffix
+		case "-r":
+			kind = alias.KindRegular
+		default:
+			return "", "", "", false
+		}
+		content = rest
+	}
+
 	// Split into name and value by the first '='
 	parts := strings.SplitN(content, "=", 2)
 	if len(parts) < 2 {
 		// No '=' found after "alias name", so it's not a complete alias definition like "alias name=command"
 		// Example: "alias foo" is not processed here as a full alias with a command.
-		return "", "", false
+		return "", "", "", false
 	}
 
 	name = strings.TrimSpace(parts[0])
@@ -66,7 +302,10 @@ func parseAliasLineFromString(line string) (name string, command string, isAlias
 		firstChar := commandValue[0]
 		lastChar := commandValue[len(commandValue)-1]
 
-		if (firstChar == '\'' && lastChar == '\'') || (firstChar == '"' && lastChar == '"') {
+		if firstChar == '\'' && lastChar == '\'' {
+			// A single quote within single quotes is escaped between two quoted parts, as posixSingleQuote does.
+			command = strings.ReplaceAll(commandValue[1:len(commandValue)-1], `'\''`, "'")
+		} else if firstChar == '"' && lastChar == '"' {
 			command = commandValue[1 : len(commandValue)-1]
 		} else {
 			command = commandValue // Not enclosed in matching quotes, or quotes are internal
@@ -86,7 +325,7 @@ func parseAliasLineFromString(line string) (name string, command string, isAlias
 	// If name is present, but command part is empty (e.g. "alias foo="), it's considered an alias.
 	// If both name and part[0] of command are empty after trim (e.g. "alias ="), it's an alias.
 
-	return name, command, true
+	return name, command, kind, true
 }
 
 // ...existing code...
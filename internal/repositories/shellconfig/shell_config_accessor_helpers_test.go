@@ -5,8 +5,9 @@ import (
 	"os/user"
 	"path/filepath"
 	"reflect"
-	"strings"
 	"testing"
+
+	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
 )
 
 // manageTestFile creates a file at the given path for the test and ensures it's cleaned up.
@@ -34,6 +35,7 @@ func TestParseAliasLineFromString(t *testing.T) {
 		line        string
 		wantName    string
 		wantCommand string
+		wantKind    string
 		wantIsAlias bool
 	}{
 		{
@@ -155,17 +157,56 @@ func TestParseAliasLineFromString(t *testing.T) {
 			wantCommand: `echo "hello"`,
 			wantIsAlias: true,
 		},
+		{
+			name:        "zsh global alias",
+			line:        `alias -g G='| grep -i'`,
+			wantName:    "G",
+			wantCommand: "| grep -i",
+			wantKind:    alias.KindGlobal,
+			wantIsAlias: true,
+		},
+		{
+			name:        "zsh suffix alias",
+			line:        `alias -s md=code`,
+			wantName:    "md",
+			wantCommand: "code",
+			wantKind:    alias.KindSuffix,
+			wantIsAlias: true,
+		},
+		{
+			name:        "options ended by --",
+			line:        `alias -g -- -L='| less'`,
+			wantName:    "-L",
+			wantCommand: "| less",
+			wantKind:    alias.KindGlobal,
+			wantIsAlias: true,
+		},
+		{
+			name:        "explicit regular alias",
+			line:        `alias -r ll='ls -l'`,
+			wantName:    "ll",
+			wantCommand: "ls -l",
+			wantIsAlias: true,
+		},
+		{
+			name:        "listing option",
+			line:        `alias -L`,
+			wantIsAlias: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotName, gotCommand, gotIsAlias := parseAliasLineFromString(tt.line)
+			gotName, gotCommand, gotKind, gotIsAlias := parseAliasLineFromString(tt.line)
 			if gotName != tt.wantName {
 				t.Errorf("parseAliasLineFromString() gotName = %v, want %v", gotName, tt.wantName)
 			}
 			if gotCommand != tt.wantCommand {
 				t.Errorf("parseAliasLineFromString() gotCommand = %v, want %v", gotCommand, tt.wantCommand)
 			}
+			if gotKind != tt.wantKind {
+				t.Errorf("parseAliasLineFromString() gotKind = %v, want %v", gotKind, tt.wantKind)
+			}
 			if gotIsAlias != tt.wantIsAlias {
 				t.Errorf("parseAliasLineFromString() gotIsAlias = %v, want %v", gotIsAlias, tt.wantIsAlias)
 			}
@@ -173,130 +214,136 @@ func TestParseAliasLineFromString(t *testing.T) {
 	}
 }
 
-func TestGetAliasesFromFile(t *testing.T) {
-	sca := &ShellConfigAccessor{} // The method doesn't use sca's fields, so a simple instance is fine.
-	tempDir := t.TempDir()
+func TestParseAbbrLineFromString(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantName    string
+		wantCommand string
+		wantIsAbbr  bool
+	}{
+		{`abbr -a gs 'git status'`, "gs", "git status", true},
+		{`abbr --add gco git checkout`, "gco", "git checkout", true},
+		{`  abbr -a -g l "ls -la"  # listing`, "l", "ls -la", true},
+		{`abbr -a -- -x 'echo x'`, "-x", "echo x", true},
+		{`abbr -a q 'echo \'hi\' \\o/'`, "q", `echo 'hi' \o/`, true},
+		{`abbr -a h "echo \"\$HOME\""`, "h", `echo "$HOME"`, true},
+		{`abbr -a gs`, "", "", false},
+		{`abbr gs 'git status'`, "", "", false},
+		{`abbr --erase gs`, "", "", false},
+		{`abbr -a --position anywhere L '| less'`, "", "", false},
+		{`abbr -a gs 'git status`, "", "", false},
+		{`# abbr -a gs 'git status'`, "", "", false},
+		{`alias gs='git status'`, "", "", false},
+	}
+	for _, tt := range tests {
+		gotName, gotCommand, gotIsAbbr := parseAbbrLineFromString(tt.line)
+		if gotName != tt.wantName || gotCommand != tt.wantCommand || gotIsAbbr != tt.wantIsAbbr {
+			t.Errorf("parseAbbrLineFromString(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.line, gotName, gotCommand, gotIsAbbr, tt.wantName, tt.wantCommand, tt.wantIsAbbr)
+		}
+	}
+}
+
+func TestFormatAbbrLine_RoundTrip(t *testing.T) {
+	for _, command := range []string{"git status", `echo 'it''s' \n`, `grep "$x" | less`} {
+		line := formatAbbrLine(alias.Alias{Name: "a", Command: command})
+		if name, got, ok := parseAbbrLineFromString(line); !ok || name != "a" || got != command {
+			t.Errorf("parseAbbrLineFromString(%q) = %q, %q, %v, want a, %q", line, name, got, ok, command)
+		}
+	}
+}
 
+func TestGetAliasDefinitions_FileContent(t *testing.T) {
 	tests := []struct {
 		name        string
-		filePath    func() string // Function to generate path, for non-existent cases
-		fileContent []byte
+		fileContent *string // Content of the default alias file; nil if it does not exist.
 		wantAliases map[string]string
-		wantErr     bool
-		wantErrMsg  string
 	}{
 		{
-			name: "file does not exist",
-			filePath: func() string {
-				return filepath.Join(tempDir, "non_existent_aliases.txt")
-			},
+			name:        "file does not exist",
 			fileContent: nil,
 			wantAliases: map[string]string{},
-			wantErr:     false,
 		},
 		{
-			name: "empty file",
-			filePath: func() string {
-				path := filepath.Join(tempDir, "empty_aliases.txt")
-				manageTestFile(t, path, []byte{})
-				return path
-			},
-			fileContent: []byte{}, // Content managed by filePath func
+			name:        "empty file",
+			fileContent: ptr(""),
 			wantAliases: map[string]string{},
-			wantErr:     false,
 		},
 		{
 			name: "file with valid aliases",
-			filePath: func() string {
-				path := filepath.Join(tempDir, "valid_aliases.txt")
-				content := `
+			fileContent: ptr(`
 alias ls='ls -G'
 alias ll="ls -alF"
 alias ..="cd .."
-`
-				manageTestFile(t, path, []byte(content))
-				return path
-			},
+`),
 			wantAliases: map[string]string{
 				"ls": "ls -G",
 				"ll": "ls -alF",
 				"..": "cd ..",
 			},
-			wantErr: false,
 		},
 		{
 			name: "file with mixed content (aliases, comments, empty lines)",
-			filePath: func() string {
-				path := filepath.Join(tempDir, "mixed_aliases.txt")
-				content := `
+			fileContent: ptr(`
 # This is a comment
 alias g=git
 alias ga="git add"
 
 export SOME_VAR="value" # Not an alias
 alias gl='git log --oneline'
-`
-				manageTestFile(t, path, []byte(content))
-				return path
-			},
+`),
 			wantAliases: map[string]string{
 				"g":  "git",
 				"ga": "git add",
 				"gl": "git log --oneline",
 			},
-			wantErr: false,
 		},
 		{
 			name: "file with malformed aliases",
-			filePath: func() string {
-				path := filepath.Join(tempDir, "malformed_aliases.txt")
-				content := `
+			fileContent: ptr(`
 alias ok1="command1"
 alias noequals
 alias ok2='command2'
 alias = "missingname"
 alias emptyname=
-`
-				manageTestFile(t, path, []byte(content))
-				return path
-			},
+`),
 			wantAliases: map[string]string{
 				"ok1":       "command1",
 				"ok2":       "command2",
 				"":          "missingname", // Current parser allows empty name
 				"emptyname": "",
 			},
-			wantErr: false,
 		},
-		// Note: Testing os.Open failure for reasons other than IsNotExist (e.g. permissions)
-		// is harder to do reliably in a cross-platform way without more complex test setup.
-		// The current test covers the IsNotExist path.
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filePath := tt.filePath() // Generate/setup file path
-
-			gotAliases, err := sca.getAliasesFromFile(filePath)
+			aliasesFile := filepath.Join(t.TempDir(), generatedAliasesDir, generatedAliasesFilename)
+			if tt.fileContent != nil {
+				manageTestFile(t, aliasesFile, []byte(*tt.fileContent))
+			}
+			sca := &ShellConfigAccessor{shell: "bash", generatedAliasesFilePath: aliasesFile}
 
-			if (err != nil) != tt.wantErr {
-				t.Errorf("getAliasesFromFile() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			definitions, err := sca.GetAliasDefinitions()
+			if err != nil {
+				t.Fatalf("GetAliasDefinitions() unexpected error: %v", err)
 			}
-			if tt.wantErr {
-				if !strings.Contains(err.Error(), tt.wantErrMsg) {
-					t.Errorf("getAliasesFromFile() error msg = %q, want to contain %q", err.Error(), tt.wantErrMsg)
-				}
-				return // No need to check aliases if an error was expected and occurred
+			gotAliases := make(map[string]string, len(definitions))
+			for _, def := range definitions {
+				gotAliases[def.Name] = def.Command
 			}
-
 			if !reflect.DeepEqual(gotAliases, tt.wantAliases) {
-				t.Errorf("getAliasesFromFile() gotAliases = %v, want %v", gotAliases, tt.wantAliases)
+				t.Errorf("GetAliasDefinitions() aliases = %v, want %v", gotAliases, tt.wantAliases)
 			}
 		})
 	}
 }
 
+// ptr returns a pointer to s.
+func ptr(s string) *string {
+	return &s
+}
+
 func TestToUserFriendlyPath(t *testing.T) {
 	currentUser, err := user.Current()
 	if err != nil {
@@ -1,6 +1,8 @@
 package shellconfig
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/user"
@@ -10,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/AntonioJCosta/nicksh/internal/core/domain/alias"
+	"github.com/AntonioJCosta/nicksh/internal/core/ports"
+	"github.com/AntonioJCosta/nicksh/internal/core/testutil"
 )
 
 // Helper to manage environment variables for tests
@@ -55,6 +59,7 @@ func TestNewShellConfigAccessor(t *testing.T) {
 		wantErr                  bool
 		wantErrorContains        string
 		expectedShell            string
+		expectedStyle            string // aliasStyle if empty.
 		expectedGenAliasesPathFn func(home string) string
 	}{
 		{
@@ -79,6 +84,18 @@ func TestNewShellConfigAccessor(t *testing.T) {
 				return filepath.Join(home, ".nicksh", "generated_aliases")
 			},
 		},
+		{
+			name: "fish writes abbreviations",
+			setupFunc: func() {
+				setupEnvVar(t, "SHELL", "/usr/bin/fish")
+			},
+			wantErr:       false,
+			expectedShell: "fish",
+			expectedStyle: abbrStyle,
+			expectedGenAliasesPathFn: func(home string) string {
+				return filepath.Join(home, ".nicksh", "generated_aliases")
+			},
+		},
 		{
 			name: "SHELL variable not set",
 			setupFunc: func() {
@@ -93,7 +110,7 @@ func TestNewShellConfigAccessor(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupFunc()
 
-			accessor, err := NewShellConfigAccessor()
+			accessor, err := NewShellConfigAccessor(nil, nil, nil, nil)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewShellConfigAccessor() error = %v, wantErr %v", err, tt.wantErr)
@@ -106,19 +123,23 @@ func TestNewShellConfigAccessor(t *testing.T) {
 				return
 			}
 
-			if accessor == nil {
+			sca := accessor
+			if sca == nil {
 				t.Fatal("NewShellConfigAccessor() returned nil accessor on success")
 			}
 
-			sca, ok := accessor.(*ShellConfigAccessor)
-			if !ok {
-				t.Fatal("NewShellConfigAccessor() did not return a *ShellConfigAccessor")
-			}
-
 			if sca.shell != tt.expectedShell {
 				t.Errorf("NewShellConfigAccessor() shell = %q, want %q", sca.shell, tt.expectedShell)
 			}
 
+			expectedStyle := tt.expectedStyle
+			if expectedStyle == "" {
+				expectedStyle = aliasStyle
+			}
+			if sca.style != expectedStyle {
+				t.Errorf("NewShellConfigAccessor() style = %q, want %q", sca.style, expectedStyle)
+			}
+
 			expectedPath := tt.expectedGenAliasesPathFn(expectedHomeDir)
 			if sca.generatedAliasesFilePath != expectedPath {
 				t.Errorf("NewShellConfigAccessor() generatedAliasesFilePath = %q, want %q", sca.generatedAliasesFilePath, expectedPath)
@@ -180,30 +201,37 @@ func TestShellConfigAccessor_GetExistingAliases(t *testing.T) {
 			wantErr:        false,
 		},
 		{
-			name: "alias directory with multiple files, with conflicts (last wins)",
+			name: "alias directory with multiple files, with conflicts",
 			setupFiles: func(aliasesDir string) {
 				if err := os.MkdirAll(aliasesDir, 0755); err != nil {
 					t.Fatalf("Failed to create aliasesDir: %v", err)
 				}
-				// Order of ReadDir is not guaranteed, but we can check for the warning
-				// and that one of them wins. Let's assume file1 is read then file2.
 				manageTestFile(t, filepath.Join(aliasesDir, "file1.aliases"), []byte("alias c=cmd1"))
-				manageTestFile(t, filepath.Join(aliasesDir, "file2.aliases"), []byte("alias c=cmd2\nalias k=kubectl"))
+				manageTestFile(t, filepath.Join(aliasesDir, "file2.aliases"), []byte("alias k=kubectl\nalias c=cmd2"))
+			},
+			expectedOutput: nil,
+			wantErr:        true,
+			wantErrMsg:     "alias 'c' is defined in multiple files: ",
+		},
+		{
+			name: "same alias defined twice in one file (last wins)",
+			setupFiles: func(aliasesDir string) {
+				if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+					t.Fatalf("Failed to create aliasesDir: %v", err)
+				}
+				manageTestFile(t, filepath.Join(aliasesDir, "file1.aliases"), []byte("alias c=cmd1\nalias c=cmd2"))
 			},
-			expectedOutput: map[string]string{"c": "cmd2", "k": "kubectl"}, // cmd2 from file2 should win
+			expectedOutput: map[string]string{"c": "cmd2"},
 			wantErr:        false,
-			expectedStderr: "Warning: Alias 'c' found in multiple files.", // Check if warning is logged
 		},
 		{
-			name: "alias directory with a file that causes getAliasesFromFile to error",
+			name: "alias directory with a file that cannot be read",
 			setupFiles: func(aliasesDir string) {
 				if err := os.MkdirAll(aliasesDir, 0755); err != nil {
 					t.Fatalf("Failed to create aliasesDir: %v", err)
 				}
 				manageTestFile(t, filepath.Join(aliasesDir, "good.aliases"), []byte("alias g=git"))
-				// Create a file that might cause scanning error if getAliasesFromFile was more complex
-				// For now, getAliasesFromFile handles os.Open errors.
-				// Let's simulate a problematic file by making it a directory (os.Open will fail)
+				// Simulate a problematic file by making it a directory (os.ReadFile will fail)
 				if err := os.Mkdir(filepath.Join(aliasesDir, "badfile.aliases"), 0755); err != nil {
 					t.Fatalf("failed to create badfile.aliases dir: %v", err)
 				}
@@ -285,7 +313,8 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 		expectedFileContent string
 		wantErr             bool
 		wantErrMsg          string
-		expectedStdout      string
+		expectedLine        int
+		expectedExisting    string // Command reported for an existing alias.
 	}{
 		{
 			name:                "add to non-existent file",
@@ -294,7 +323,7 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 			expectedAdded:       true,
 			expectedFileContent: "alias g='git'\n",
 			wantErr:             false,
-			expectedStdout:      "Alias 'g' added to",
+			expectedLine:        1,
 		},
 		{
 			name:                "add to existing empty file",
@@ -303,7 +332,7 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 			expectedAdded:       true,
 			expectedFileContent: "alias ll='ls -l'\n",
 			wantErr:             false,
-			expectedStdout:      "Alias 'll' added to",
+			expectedLine:        1,
 		},
 		{
 			name:                "add to existing file with content",
@@ -312,7 +341,16 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 			expectedAdded:       true,
 			expectedFileContent: "alias k=kubectl\nalias gp='git push'\n",
 			wantErr:             false,
-			expectedStdout:      "Alias 'gp' added to",
+			expectedLine:        2,
+		},
+		{
+			name:                "add to file without a trailing newline",
+			initialFileContent:  stringp("# team aliases\nalias k=kubectl"),
+			aliasToAdd:          alias.Alias{Name: "gp", Command: "git push"},
+			expectedAdded:       true,
+			expectedFileContent: "# team aliases\nalias k=kubectl\nalias gp='git push'\n",
+			wantErr:             false,
+			expectedLine:        3,
 		},
 		{
 			name:                "add alias that already exists",
@@ -321,7 +359,8 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 			expectedAdded:       false,
 			expectedFileContent: "alias g='git'\n", // File should not change
 			wantErr:             false,
-			expectedStdout:      "Alias 'g' already exists",
+			expectedLine:        1,
+			expectedExisting:    "git",
 		},
 		// Error cases for os.MkdirAll, os.OpenFile, file.WriteString are harder to test
 		// without more complex mocking of os-level functions or specific file system states.
@@ -351,21 +390,7 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 				manageTestFile(t, generatedFile, []byte(*tt.initialFileContent))
 			}
 
-			// Capture stdout
-			oldStdout := os.Stdout
-			rOut, wOut, _ := os.Pipe()
-			os.Stdout = wOut
-			defer func() {
-				os.Stdout = oldStdout
-				wOut.Close()
-				rOut.Close()
-			}()
-
-			added, err := sca.AddAlias(tt.aliasToAdd)
-
-			wOut.Close() // Close writer to allow reader to get EOF
-			stdoutBytes, _ := io.ReadAll(rOut)
-			stdoutOutput := string(stdoutBytes)
+			result, err := sca.AddAlias(tt.aliasToAdd)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddAlias() error = %v, wantErr %v", err, tt.wantErr)
@@ -378,8 +403,9 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 				return
 			}
 
-			if added != tt.expectedAdded {
-				t.Errorf("AddAlias() added = %v, want %v", added, tt.expectedAdded)
+			wantResult := ports.AddAliasResult{Added: tt.expectedAdded, File: generatedFile, Line: tt.expectedLine, ExistingCommand: tt.expectedExisting}
+			if !reflect.DeepEqual(result, wantResult) {
+				t.Errorf("AddAlias() result = %+v, want %+v", result, wantResult)
 			}
 
 			if tt.expectedAdded || (!tt.expectedAdded && tt.initialFileContent != nil && *tt.initialFileContent == tt.expectedFileContent) { // Check content if added or if skipped and content should remain same
@@ -397,10 +423,6 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 				}
 			}
 
-			if tt.expectedStdout != "" && !strings.Contains(stdoutOutput, tt.expectedStdout) {
-				t.Errorf("AddAlias() stdout = %q, want to contain %q", stdoutOutput, tt.expectedStdout)
-			}
-
 			// Cleanup the specific generated file if it was created and no initial content was set
 			// This helps if the test failed before t.Cleanup on TempDir runs.
 			if tt.initialFileContent == nil {
@@ -415,3 +437,563 @@ func TestShellConfigAccessor_AddAlias(t *testing.T) {
 func stringp(s string) *string {
 	return &s
 }
+
+func TestShellConfigAccessor_GetExistingAliases_DuplicateLocations(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+		t.Fatalf("Failed to create aliasesDir: %v", err)
+	}
+	gitFile := filepath.Join(aliasesDir, "git")
+	workFile := filepath.Join(aliasesDir, "work")
+	manageTestFile(t, gitFile, []byte("alias gs='git status'\n"))
+	manageTestFile(t, workFile, []byte("# work aliases\nalias k=kubectl\nalias gs='git switch'\n"))
+
+	sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename)}
+
+	_, err := sca.GetExistingAliases()
+
+	var dupErr *alias.DuplicateNameError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("GetExistingAliases() error = %v, want *alias.DuplicateNameError", err)
+	}
+	if dupErr.Name != "gs" {
+		t.Errorf("DuplicateNameError.Name = %q, want %q", dupErr.Name, "gs")
+	}
+	wantLocations := []string{gitFile + ":1", workFile + ":3"}
+	var gotLocations []string
+	for _, def := range dupErr.Definitions {
+		gotLocations = append(gotLocations, fmt.Sprintf("%s:%d", def.File, def.Line))
+	}
+	if !reflect.DeepEqual(gotLocations, wantLocations) {
+		t.Errorf("DuplicateNameError locations = %v, want %v", gotLocations, wantLocations)
+	}
+}
+
+func TestShellConfigAccessor_GetStartupAliasDefinitions(t *testing.T) {
+	env := &testutil.MockShellEnvironment{RCFilesByShell: map[string][]ports.RCFile{"zsh": {
+		{Path: "/home/u/.zshenv", Content: "export EDITOR=vim\n"},
+		{Path: "/home/u/.zshrc", Content: "# aliases\nalias ll='ls -l'\nsource ~/.nicksh/git\nalias g=git\n"},
+	}}}
+	sca := &ShellConfigAccessor{shell: "zsh", env: env}
+
+	got, err := sca.GetStartupAliasDefinitions()
+	if err != nil {
+		t.Fatalf("GetStartupAliasDefinitions() error = %v", err)
+	}
+	want := []alias.Definition{
+		{Alias: alias.Alias{Name: "ll", Command: "ls -l"}, File: "/home/u/.zshrc", Line: 2},
+		{Alias: alias.Alias{Name: "g", Command: "git"}, File: "/home/u/.zshrc", Line: 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStartupAliasDefinitions() = %+v, want %+v", got, want)
+	}
+
+	env.RCFilesErr = errors.New("permission denied")
+	if _, err := sca.GetStartupAliasDefinitions(); err == nil {
+		t.Error("GetStartupAliasDefinitions() error = nil, want the startup files error")
+	}
+
+	sca.env = nil
+	if got, err := sca.GetStartupAliasDefinitions(); err != nil || len(got) != 0 {
+		t.Errorf("GetStartupAliasDefinitions() without an environment = %v, %v, want no definitions", got, err)
+	}
+}
+
+func TestShellConfigAccessor_AddAlias_Groups(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingFiles map[string]string
+		aliasToAdd    alias.Alias
+		wantAdded     bool
+		wantErrMsg    string
+		wantFiles     map[string]string
+	}{
+		{
+			name:       "add into named group",
+			aliasToAdd: alias.Alias{Name: "gs", Command: "git status", Group: "git"},
+			wantAdded:  true,
+			wantFiles:  map[string]string{"git": "alias gs='git status'\n"},
+		},
+		{
+			name:          "skip when name exists in another group",
+			existingFiles: map[string]string{"work": "alias gs='git switch'\n"},
+			aliasToAdd:    alias.Alias{Name: "gs", Command: "git status", Group: "git"},
+			wantAdded:     false,
+			wantFiles:     map[string]string{"work": "alias gs='git switch'\n"},
+		},
+		{
+			name:       "invalid group name",
+			aliasToAdd: alias.Alias{Name: "gs", Command: "git status", Group: "../escape"},
+			wantErrMsg: "invalid group name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+			if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+				t.Fatalf("Failed to create aliasesDir: %v", err)
+			}
+			for file, content := range tt.existingFiles {
+				manageTestFile(t, filepath.Join(aliasesDir, file), []byte(content))
+			}
+			sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename)}
+
+			result, err := sca.AddAlias(tt.aliasToAdd)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("AddAlias() error = %v, want error containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddAlias() unexpected error: %v", err)
+			}
+			if result.Added != tt.wantAdded {
+				t.Errorf("AddAlias() added = %v, want %v", result.Added, tt.wantAdded)
+			}
+			for file, want := range tt.wantFiles {
+				got, readErr := os.ReadFile(filepath.Join(aliasesDir, file))
+				if readErr != nil {
+					t.Fatalf("Failed to read %s: %v", file, readErr)
+				}
+				if string(got) != want {
+					t.Errorf("file %s content = %q, want %q", file, string(got), want)
+				}
+			}
+		})
+	}
+}
+
+func TestShellConfigAccessor_AddAlias_RefusesSecrets(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	secrets := &testutil.MockSecretDetector{DetectSecretsFunc: func(command string) []string {
+		if strings.Contains(command, "hunter2") {
+			return []string{"password or token argument"}
+		}
+		return nil
+	}}
+	sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename), secrets: secrets}
+
+	result, err := sca.AddAlias(alias.Alias{Name: "db", Command: "mysql --password=hunter2"})
+	var secretErr *alias.SecretError
+	if !errors.As(err, &secretErr) {
+		t.Fatalf("AddAlias() error = %v, want *alias.SecretError", err)
+	}
+	if result.Added || secretErr.Name != "db" || !reflect.DeepEqual(secretErr.Kinds, []string{"password or token argument"}) {
+		t.Errorf("AddAlias() = %+v, %+v, want not added with the kinds found", result, secretErr)
+	}
+	if _, statErr := os.Stat(filepath.Join(aliasesDir, generatedAliasesFilename)); !os.IsNotExist(statErr) {
+		t.Errorf("AddAlias() wrote the alias file although the command contains a secret")
+	}
+
+	if result, err := sca.AddAlias(alias.Alias{Name: "gs", Command: "git status"}); err != nil || !result.Added {
+		t.Errorf("AddAlias() = %+v, %v for a command without secrets, want added", result, err)
+	}
+}
+
+func TestShellConfigAccessor_AddAlias_ConfigWriteError(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	// A directory where the group file should be cannot be opened for appending.
+	if err := os.MkdirAll(filepath.Join(aliasesDir, "git"), 0755); err != nil {
+		t.Fatalf("Failed to create the blocking directory: %v", err)
+	}
+	sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename)}
+
+	result, err := sca.AddAlias(alias.Alias{Name: "gs", Command: "git status", Group: "git"})
+	var writeErr *alias.ConfigWriteError
+	if result.Added || !errors.Is(err, alias.ErrConfigWrite) || !errors.As(err, &writeErr) {
+		t.Fatalf("AddAlias() = %+v, %v, want a *alias.ConfigWriteError matching alias.ErrConfigWrite", result, err)
+	}
+	if !strings.HasSuffix(writeErr.Path, filepath.Join(generatedAliasesDir, "git")) || writeErr.Err == nil {
+		t.Errorf("AddAlias() error = %+v, want the group file path and the underlying error", writeErr)
+	}
+}
+
+func TestShellConfigAccessor_UnreadableFiles(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+		t.Fatalf("Failed to create aliasesDir: %v", err)
+	}
+	manageTestFile(t, filepath.Join(aliasesDir, "git"), []byte("alias gs='git status'\n"))
+	// A symlink to a directory is listed as a file but cannot be read.
+	if err := os.Symlink(t.TempDir(), filepath.Join(aliasesDir, "broken")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	logger := &testutil.MockLogger{}
+	sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename), logger: logger}
+
+	definitions, err := sca.GetAliasDefinitions()
+	if err != nil || len(definitions) != 1 {
+		t.Fatalf("GetAliasDefinitions() = %+v, %v, want the readable definition only", definitions, err)
+	}
+	warnings := logger.EntriesAt("WARN")
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "WARN could not read aliases from file") {
+		t.Errorf("GetAliasDefinitions() logged %q, want one warning about the unreadable file", logger.Entries)
+	}
+	if debug := logger.EntriesAt("DEBUG"); len(debug) != 1 || !strings.Contains(debug[0], "read alias file file=") {
+		t.Errorf("GetAliasDefinitions() logged %q, want the readable file logged at debug level", logger.Entries)
+	}
+
+	// AddAlias reports the same problem in its result instead of logging it.
+	logger.Entries = nil
+	result, err := sca.AddAlias(alias.Alias{Name: "gp", Command: "git push", Group: "git"})
+	if err != nil || !result.Added || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "broken") {
+		t.Errorf("AddAlias() = %+v, %v, want added with a warning about the unreadable file", result, err)
+	}
+	if warnings := logger.EntriesAt("WARN"); len(warnings) != 0 {
+		t.Errorf("AddAlias() logged %q, want no warnings", warnings)
+	}
+}
+
+func TestShellConfigAccessor_MoveAlias(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingFiles map[string]string
+		aliasName     string
+		targetGroup   string
+		wantErrMsg    string
+		wantFiles     map[string]string
+	}{
+		{
+			name: "move from default group to new group",
+			existingFiles: map[string]string{
+				generatedAliasesFilename: "# header\nalias gs='git status'\nalias ll='ls -l'\n",
+			},
+			aliasName:   "gs",
+			targetGroup: "git",
+			wantFiles: map[string]string{
+				generatedAliasesFilename: "# header\nalias ll='ls -l'\n",
+				"git":                    "alias gs='git status'\n",
+			},
+		},
+		{
+			name: "move into existing group file",
+			existingFiles: map[string]string{
+				"work": "alias k='kubectl'\n",
+				"git":  "alias gp='git push'\n",
+			},
+			aliasName:   "k",
+			targetGroup: "git",
+			wantFiles: map[string]string{
+				"work": "",
+				"git":  "alias gp='git push'\nalias k='kubectl'\n",
+			},
+		},
+		{
+			name:          "already in target group is a no-op",
+			existingFiles: map[string]string{"git": "alias gp='git push'\n"},
+			aliasName:     "gp",
+			targetGroup:   "git",
+			wantFiles:     map[string]string{"git": "alias gp='git push'\n"},
+		},
+		{
+			name:          "alias not found",
+			existingFiles: map[string]string{"git": "alias gp='git push'\n"},
+			aliasName:     "missing",
+			targetGroup:   "git",
+			wantErrMsg:    "alias 'missing' not found",
+		},
+		{
+			name: "duplicate definitions are an error",
+			existingFiles: map[string]string{
+				"git":  "alias gp='git push'\n",
+				"work": "alias gp='git pull'\n",
+			},
+			aliasName:   "gp",
+			targetGroup: "other",
+			wantErrMsg:  "alias 'gp' is defined in multiple files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+			if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+				t.Fatalf("Failed to create aliasesDir: %v", err)
+			}
+			for file, content := range tt.existingFiles {
+				manageTestFile(t, filepath.Join(aliasesDir, file), []byte(content))
+			}
+			sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename)}
+
+			err := sca.MoveAlias(tt.aliasName, tt.targetGroup)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("MoveAlias() error = %v, want error containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MoveAlias() unexpected error: %v", err)
+			}
+			for file, want := range tt.wantFiles {
+				got, readErr := os.ReadFile(filepath.Join(aliasesDir, file))
+				if readErr != nil {
+					t.Fatalf("Failed to read %s: %v", file, readErr)
+				}
+				if string(got) != want {
+					t.Errorf("file %s content = %q, want %q", file, string(got), want)
+				}
+			}
+		})
+	}
+}
+
+func TestShellConfigAccessor_ReplaceAlias(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingFiles map[string]string
+		newAlias      alias.Alias
+		wantErrMsg    string
+		wantFiles     map[string]string
+	}{
+		{
+			name:          "replace in the same group",
+			existingFiles: map[string]string{"git": "# git\nalias gp='git push'\nalias gs='git status'\n"},
+			newAlias:      alias.Alias{Name: "gp", Command: "git push --force-with-lease", Group: "git"},
+			wantFiles:     map[string]string{"git": "# git\nalias gs='git status'\nalias gp='git push --force-with-lease'\n"},
+		},
+		{
+			name: "replace definitions of other groups",
+			existingFiles: map[string]string{
+				generatedAliasesFilename: "alias k='kubectl'\n",
+				"work":                   "alias k='kubectl --context work'\n",
+			},
+			newAlias: alias.Alias{Name: "k", Command: "kubecolor", Group: "k8s"},
+			wantFiles: map[string]string{
+				generatedAliasesFilename: "",
+				"work":                   "",
+				"k8s":                    "alias k='kubecolor'\n",
+			},
+		},
+		{
+			name:      "new name is added",
+			newAlias:  alias.Alias{Name: "ll", Command: "ls -l"},
+			wantFiles: map[string]string{generatedAliasesFilename: "alias ll='ls -l'\n"},
+		},
+		{
+			name:       "invalid group",
+			newAlias:   alias.Alias{Name: "ll", Command: "ls -l", Group: "../etc"},
+			wantErrMsg: "invalid group name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+			if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+				t.Fatalf("Failed to create aliasesDir: %v", err)
+			}
+			for file, content := range tt.existingFiles {
+				manageTestFile(t, filepath.Join(aliasesDir, file), []byte(content))
+			}
+			sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename)}
+
+			err := sca.ReplaceAlias(tt.newAlias)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("ReplaceAlias() error = %v, want error containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReplaceAlias() unexpected error: %v", err)
+			}
+			for file, want := range tt.wantFiles {
+				got, readErr := os.ReadFile(filepath.Join(aliasesDir, file))
+				if readErr != nil {
+					t.Fatalf("Failed to read %s: %v", file, readErr)
+				}
+				if string(got) != want {
+					t.Errorf("file %s content = %q, want %q", file, string(got), want)
+				}
+			}
+		})
+	}
+}
+
+func TestShellConfigAccessor_RemoveAlias(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+		t.Fatalf("Failed to create aliasesDir: %v", err)
+	}
+	manageTestFile(t, filepath.Join(aliasesDir, generatedAliasesFilename), []byte("# mine\nalias gs='git status'\nalias ll='ls -l'\n"))
+	manageTestFile(t, filepath.Join(aliasesDir, "git"), []byte("alias gs='git status -sb'\n"))
+	sca := &ShellConfigAccessor{generatedAliasesFilePath: filepath.Join(aliasesDir, generatedAliasesFilename)}
+
+	if err := sca.RemoveAlias("gs"); err != nil {
+		t.Fatalf("RemoveAlias() unexpected error: %v", err)
+	}
+	wantFiles := map[string]string{generatedAliasesFilename: "# mine\nalias ll='ls -l'\n", "git": ""}
+	for file, want := range wantFiles {
+		got, err := os.ReadFile(filepath.Join(aliasesDir, file))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if string(got) != want {
+			t.Errorf("file %s content = %q, want %q", file, string(got), want)
+		}
+	}
+
+	if err := sca.RemoveAlias("gs"); err == nil || !strings.Contains(err.Error(), "alias 'gs' not found") {
+		t.Errorf("RemoveAlias() of a missing alias error = %v, want not found", err)
+	}
+}
+
+func TestShellConfigAccessor_ValidatesWrites(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	if err := os.MkdirAll(aliasesDir, 0755); err != nil {
+		t.Fatalf("Failed to create aliasesDir: %v", err)
+	}
+	aliasesFile := filepath.Join(aliasesDir, generatedAliasesFilename)
+	initial := "alias a='b'\nalias t='missing'\nalias m='mine'\n"
+	manageTestFile(t, aliasesFile, []byte(initial))
+	// a and b loop into each other; an alias of 'missing' or of a removed 'mine' alias dangles.
+	validator := &testutil.MockAliasValidator{ValidateFunc: func(definitions []alias.Definition) []alias.Issue {
+		var issues []alias.Issue
+		names := make(map[string]bool)
+		for _, def := range definitions {
+			names[def.Name] = true
+		}
+		for _, def := range definitions {
+			if def.Command == "missing" || (def.Command == "mine" && !names["mine"]) {
+				issues = append(issues, alias.Issue{Kind: alias.IssueDangling, Name: def.Name, Command: def.Command})
+			}
+		}
+		if names["a"] && names["b"] {
+			issues = append(issues, alias.Issue{Kind: alias.IssueCycle, Name: "a", Chain: []string{"a", "b", "a"}})
+		}
+		return issues
+	}}
+	logger := &testutil.MockLogger{}
+	sca := &ShellConfigAccessor{generatedAliasesFilePath: aliasesFile, validator: validator, logger: logger}
+
+	var cycleErr *alias.CycleError
+	if _, err := sca.AddAlias(alias.Alias{Name: "b", Command: "a"}); !errors.As(err, &cycleErr) || cycleErr.Name != "b" {
+		t.Errorf("AddAlias() of a loop error = %v, want *alias.CycleError for 'b'", err)
+	}
+	if err := sca.ReplaceAlias(alias.Alias{Name: "b", Command: "a"}); !errors.As(err, &cycleErr) {
+		t.Errorf("ReplaceAlias() of a loop error = %v, want *alias.CycleError", err)
+	}
+	if got, _ := os.ReadFile(aliasesFile); string(got) != initial {
+		t.Errorf("refused writes changed the alias file to %q", string(got))
+	}
+
+	// Only the issues the write brings in are reported, not the dangling 't' already there.
+	result, err := sca.AddAlias(alias.Alias{Name: "u", Command: "missing"})
+	if err != nil || !result.Added || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "alias 'u' runs 'missing'") {
+		t.Errorf("AddAlias() = %+v, %v, want added with a warning about 'u' only", result, err)
+	}
+
+	if err := sca.RemoveAlias("mine"); err == nil {
+		t.Errorf("RemoveAlias() of a missing alias succeeded")
+	}
+	manageTestFile(t, filepath.Join(aliasesDir, "tools"), []byte("alias mine='ls'\n"))
+	if err := sca.RemoveAlias("mine"); err != nil {
+		t.Fatalf("RemoveAlias() unexpected error: %v", err)
+	}
+	if warnings := logger.EntriesAt("WARN"); len(warnings) != 1 || !strings.Contains(warnings[0], "alias 'm' runs 'mine'") {
+		t.Errorf("RemoveAlias() logged %q, want a warning about 'm' dangling", logger.Entries)
+	}
+}
+
+func TestShellConfigAccessor_AliasKinds(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	aliasesFile := filepath.Join(aliasesDir, generatedAliasesFilename)
+
+	bash := &ShellConfigAccessor{shell: "bash", generatedAliasesFilePath: aliasesFile}
+	if bash.SupportsKind(alias.KindGlobal) || bash.SupportsKind(alias.KindSuffix) || !bash.SupportsKind(alias.KindRegular) {
+		t.Errorf("SupportsKind() for bash, want regular aliases only")
+	}
+	if _, err := bash.AddAlias(alias.Alias{Name: "G", Command: "| grep", Kind: alias.KindGlobal}); err == nil || !strings.Contains(err.Error(), "only supported by zsh") {
+		t.Errorf("AddAlias() of a global alias for bash error = %v, want only supported by zsh", err)
+	}
+	if err := bash.ReplaceAlias(alias.Alias{Name: "md", Command: "code", Kind: alias.KindSuffix}); err == nil {
+		t.Errorf("ReplaceAlias() of a suffix alias for bash succeeded")
+	}
+
+	zsh := &ShellConfigAccessor{shell: "zsh", generatedAliasesFilePath: aliasesFile}
+	for _, a := range []alias.Alias{
+		{Name: "G", Command: "| grep -i", Kind: alias.KindGlobal},
+		{Name: "md", Command: "code", Kind: alias.KindSuffix},
+		{Name: "gs", Command: "git status"},
+	} {
+		if result, err := zsh.AddAlias(a); err != nil || !result.Added {
+			t.Fatalf("AddAlias(%+v) = %+v, %v, want added", a, result, err)
+		}
+	}
+	got, err := os.ReadFile(aliasesFile)
+	if err != nil {
+		t.Fatalf("Failed to read the alias file: %v", err)
+	}
+	if want := "alias -g G='| grep -i'\nalias -s md='code'\nalias gs='git status'\n"; string(got) != want {
+		t.Errorf("alias file content = %q, want %q", string(got), want)
+	}
+
+	definitions, err := zsh.GetAliasDefinitions()
+	if err != nil || len(definitions) != 3 {
+		t.Fatalf("GetAliasDefinitions() = %+v, %v, want the 3 aliases", definitions, err)
+	}
+	for i, wantKind := range []string{alias.KindGlobal, alias.KindSuffix, alias.KindRegular} {
+		if definitions[i].Kind != wantKind {
+			t.Errorf("GetAliasDefinitions()[%d].Kind = %q, want %q", i, definitions[i].Kind, wantKind)
+		}
+	}
+	if err := zsh.RemoveAlias("G"); err != nil {
+		t.Errorf("RemoveAlias() of a global alias error = %v", err)
+	}
+}
+
+func TestShellConfigAccessor_AliasStyles(t *testing.T) {
+	aliasesDir := filepath.Join(t.TempDir(), generatedAliasesDir)
+	aliasesFile := filepath.Join(aliasesDir, generatedAliasesFilename)
+
+	bash := &ShellConfigAccessor{shell: "bash", generatedAliasesFilePath: aliasesFile}
+	if err := bash.SelectAliasStyle(abbrStyle); err == nil {
+		t.Errorf("SelectAliasStyle(%q) for bash succeeded, want an error", abbrStyle)
+	}
+	if err := bash.SelectAliasStyle("function"); err == nil || !strings.Contains(err.Error(), "available: alias, abbr") {
+		t.Errorf("SelectAliasStyle(\"function\") error = %v, want the available styles", err)
+	}
+
+	fish := &ShellConfigAccessor{shell: "fish", generatedAliasesFilePath: aliasesFile}
+	if err := fish.SelectAliasStyle(""); err != nil || fish.style != abbrStyle {
+		t.Fatalf("SelectAliasStyle(\"\") for fish = %v, style %q, want %q", err, fish.style, abbrStyle)
+	}
+	manageTestFile(t, aliasesFile, []byte("alias ll='ls -l'\n"))
+	if result, err := fish.AddAlias(alias.Alias{Name: "gs", Command: "git status"}); err != nil || !result.Added || result.Line != 2 {
+		t.Fatalf("AddAlias() = %+v, %v, want added on line 2", result, err)
+	}
+	if err := fish.SelectAliasStyle(aliasStyle); err != nil {
+		t.Fatalf("SelectAliasStyle(%q) error = %v", aliasStyle, err)
+	}
+	if _, err := fish.AddAlias(alias.Alias{Name: "gd", Command: "git diff"}); err != nil {
+		t.Fatalf("AddAlias() error = %v", err)
+	}
+	got, err := os.ReadFile(aliasesFile)
+	if err != nil {
+		t.Fatalf("Failed to read the alias file: %v", err)
+	}
+	if want := "alias ll='ls -l'\nabbr -a gs 'git status'\nalias gd='git diff'\n"; string(got) != want {
+		t.Errorf("alias file content = %q, want %q", string(got), want)
+	}
+
+	// Abbreviations are read back like aliases: listed, in conflict with new names, and removable.
+	aliases, err := fish.GetExistingAliases()
+	if err != nil || aliases["gs"] != "git status" || len(aliases) != 3 {
+		t.Errorf("GetExistingAliases() = %v, %v, want the abbreviation among the 3 aliases", aliases, err)
+	}
+	if result, err := fish.AddAlias(alias.Alias{Name: "gs", Command: "git switch"}); err != nil || result.Added || result.ExistingCommand != "git status" {
+		t.Errorf("AddAlias() of an abbreviation's name = %+v, %v, want the existing abbreviation", result, err)
+	}
+	if err := fish.RemoveAlias("gs"); err != nil {
+		t.Fatalf("RemoveAlias() of an abbreviation error = %v", err)
+	}
+	if got, _ := os.ReadFile(aliasesFile); string(got) != "alias ll='ls -l'\nalias gd='git diff'\n" {
+		t.Errorf("alias file content after RemoveAlias() = %q", string(got))
+	}
+}